@@ -11,10 +11,13 @@ import (
 	"github.com/kqns91/gitlab-mcp/internal/gitlab"
 	"github.com/kqns91/gitlab-mcp/internal/registry"
 	"github.com/kqns91/gitlab-mcp/internal/tools/approval"
+	"github.com/kqns91/gitlab-mcp/internal/tools/completion"
 	"github.com/kqns91/gitlab-mcp/internal/tools/discussion"
 	"github.com/kqns91/gitlab-mcp/internal/tools/issue"
 	"github.com/kqns91/gitlab-mcp/internal/tools/mergerequest"
 	"github.com/kqns91/gitlab-mcp/internal/tools/pipeline"
+	"github.com/kqns91/gitlab-mcp/internal/tools/repository"
+	"github.com/kqns91/gitlab-mcp/internal/tools/variable"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -35,6 +38,8 @@ func run() error {
 		log.Printf("Configuration loaded: %s", cfg)
 	}
 
+	gitlab.SetLanguage(gitlab.ParseLanguage(cfg.Language))
+
 	// Initialize GitLab client
 	client, err := gitlab.NewClient(cfg.GitLabURL, cfg.GitLabToken)
 	if err != nil {
@@ -49,6 +54,10 @@ func run() error {
 	reg := registry.New(cfg)
 	registerAllTools(reg, client)
 
+	if names := reg.UnconfirmableTools(); len(names) > 0 {
+		log.Printf("Warning: GITLAB_MCP_CONFIRM_TOOLS lists tools that cannot ask for confirmation and will run without it: %v", names)
+	}
+
 	if cfg.Debug {
 		enabled := reg.GetEnabledTools()
 		log.Printf("Registered %d tools: %v", len(enabled), enabled)
@@ -75,6 +84,9 @@ func registerAllTools(reg *registry.Registry, client *gitlab.Client) {
 	approval.Register(reg, client)
 	pipeline.Register(reg, client)
 	issue.Register(reg, client)
+	variable.Register(reg, client)
+	repository.Register(reg, client)
+	completion.Register(reg, client)
 }
 
 func init() {
@@ -5,9 +5,25 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// リソース購読のポーリングのデフォルト値
+const (
+	DefaultPollInterval  = 30 * time.Second
+	DefaultPollRateLimit = 60
+)
+
+// DefaultConfirmTools は実行前にユーザーの確認を求めるツールのデフォルト
+var DefaultConfirmTools = []string{
+	"delete_issue",
+	"delete_merge_request_comment",
+	"merge_merge_request",
+	"cancel_pipeline",
+}
+
 // Config はアプリケーション設定を保持する
 type Config struct {
 	GitLabURL     string
@@ -15,6 +31,20 @@ type Config struct {
 	EnabledTools  []string // nil = all enabled
 	DisabledTools []string
 	Debug         bool
+	// ExposeSecretVariables が true の場合、masked/protected な CI/CD 変数の値もツールの出力に含める
+	ExposeSecretVariables bool
+	// GeneratedFilePatterns は差分取得時に生成ファイルとして扱うパスの glob（nil = 組み込みのデフォルト）
+	GeneratedFilePatterns []string
+	// Language はエラーメッセージの言語（"en" または "ja"、空の場合は英語）
+	Language string
+	// PollInterval は購読中のリソースの変更を確認する間隔
+	PollInterval time.Duration
+	// PollRateLimit はポーリングで GitLab に送るリクエストの 1 分あたりの上限
+	PollRateLimit int
+	// ConfirmTools は実行前にユーザーの確認を求めるツール（nil = DefaultConfirmTools、空 = 確認しない）
+	ConfirmTools []string
+	// DryRun が true の場合、書き込みを行うツールは入力と対象を検証し、送信するはずのリクエストを返すだけで GitLab を変更しない
+	DryRun bool
 }
 
 // Load は環境変数から設定を読み込む
@@ -32,40 +62,74 @@ func Load() (*Config, error) {
 	cfg := &Config{
 		GitLabURL:   gitlabURL,
 		GitLabToken: gitlabToken,
-		Debug:       parseDebug(os.Getenv("GITLAB_MCP_DEBUG")),
+		Debug:       parseBool(os.Getenv("GITLAB_MCP_DEBUG")),
+	}
+
+	cfg.ExposeSecretVariables = parseBool(os.Getenv("GITLAB_MCP_EXPOSE_SECRET_VARIABLES"))
+	cfg.DryRun = parseBool(os.Getenv("GITLAB_MCP_DRY_RUN"))
+	cfg.Language = strings.TrimSpace(os.Getenv("GITLAB_MCP_LANG"))
+
+	cfg.PollInterval = DefaultPollInterval
+	if v := os.Getenv("GITLAB_MCP_POLL_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("GITLAB_MCP_POLL_INTERVAL must be a positive duration (e.g. 30s): %q", v)
+		}
+		cfg.PollInterval = interval
+	}
+
+	cfg.PollRateLimit = DefaultPollRateLimit
+	if v := os.Getenv("GITLAB_MCP_POLL_RATE_LIMIT"); v != "" {
+		limit, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("GITLAB_MCP_POLL_RATE_LIMIT must be a positive integer: %q", v)
+		}
+		cfg.PollRateLimit = limit
+	}
+
+	if patterns := os.Getenv("GITLAB_MCP_GENERATED_FILE_PATTERNS"); patterns != "" {
+		cfg.GeneratedFilePatterns = parseList(patterns)
+	}
+
+	// 空文字列が設定された場合はどのツールも確認しない
+	if confirmTools, ok := os.LookupEnv("GITLAB_MCP_CONFIRM_TOOLS"); ok {
+		cfg.ConfirmTools = parseList(confirmTools)
+		if cfg.ConfirmTools == nil {
+			cfg.ConfirmTools = []string{}
+		}
 	}
 
 	if enabledTools := os.Getenv("GITLAB_MCP_ENABLED_TOOLS"); enabledTools != "" {
-		cfg.EnabledTools = parseToolList(enabledTools)
+		cfg.EnabledTools = parseList(enabledTools)
 	}
 
 	if disabledTools := os.Getenv("GITLAB_MCP_DISABLED_TOOLS"); disabledTools != "" {
-		cfg.DisabledTools = parseToolList(disabledTools)
+		cfg.DisabledTools = parseList(disabledTools)
 	}
 
 	return cfg, nil
 }
 
-// parseDebug は debug フラグをパースする
-func parseDebug(value string) bool {
+// parseBool は真偽値の環境変数をパースする
+func parseBool(value string) bool {
 	v := strings.ToLower(strings.TrimSpace(value))
 	return v == "true" || v == "1" || v == "yes"
 }
 
-// parseToolList はカンマ区切りのツール名リストをパースする
-func parseToolList(value string) []string {
+// parseList はカンマ区切りのリストをパースする
+func parseList(value string) []string {
 	if value == "" {
 		return nil
 	}
 	parts := strings.Split(value, ",")
-	tools := make([]string, 0, len(parts))
+	items := make([]string, 0, len(parts))
 	for _, p := range parts {
 		trimmed := strings.TrimSpace(p)
 		if trimmed != "" {
-			tools = append(tools, trimmed)
+			items = append(items, trimmed)
 		}
 	}
-	return tools
+	return items
 }
 
 // String は設定の文字列表現を返す（トークンはマスキング）
@@ -74,8 +138,8 @@ func (c *Config) String() string {
 	if len(c.GitLabToken) > 4 {
 		maskedToken = c.GitLabToken[:2] + "***" + c.GitLabToken[len(c.GitLabToken)-2:]
 	}
-	return fmt.Sprintf("Config{GitLabURL: %q, GitLabToken: %q, EnabledTools: %v, DisabledTools: %v, Debug: %v}",
-		c.GitLabURL, maskedToken, c.EnabledTools, c.DisabledTools, c.Debug)
+	return fmt.Sprintf("Config{GitLabURL: %q, GitLabToken: %q, EnabledTools: %v, DisabledTools: %v, Debug: %v, ExposeSecretVariables: %v, DryRun: %v}",
+		c.GitLabURL, maskedToken, c.EnabledTools, c.DisabledTools, c.Debug, c.ExposeSecretVariables, c.DryRun)
 }
 
 // IsToolEnabled はツールが有効かどうかを判定する
@@ -94,3 +158,17 @@ func (c *Config) IsToolEnabled(toolName string) bool {
 	// No restrictions - tool is enabled
 	return true
 }
+
+// RequiresConfirmation はツールの実行前にユーザーの確認を求めるかどうかを判定する
+func (c *Config) RequiresConfirmation(toolName string) bool {
+	return slices.Contains(c.ConfirmToolNames(), toolName)
+}
+
+// ConfirmToolNames は実行前にユーザーの確認を求めるツールの名前を返す
+// ConfirmTools が未設定の場合は DefaultConfirmTools を返す
+func (c *Config) ConfirmToolNames() []string {
+	if c.ConfirmTools == nil {
+		return DefaultConfirmTools
+	}
+	return c.ConfirmTools
+}
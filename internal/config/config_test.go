@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -76,6 +77,131 @@ func TestLoad_DebugMode(t *testing.T) {
 	assert.True(t, cfg.Debug)
 }
 
+func TestLoad_ExposeSecretVariables(t *testing.T) {
+	// Setup
+	os.Setenv("GITLAB_URL", "https://gitlab.example.com")
+	os.Setenv("GITLAB_TOKEN", "test-token")
+	os.Setenv("GITLAB_MCP_EXPOSE_SECRET_VARIABLES", "1")
+	defer func() {
+		os.Unsetenv("GITLAB_URL")
+		os.Unsetenv("GITLAB_TOKEN")
+		os.Unsetenv("GITLAB_MCP_EXPOSE_SECRET_VARIABLES")
+	}()
+
+	// Execute
+	cfg, err := Load()
+
+	// Verify
+	require.NoError(t, err)
+	assert.True(t, cfg.ExposeSecretVariables)
+}
+
+func TestLoad_DryRun(t *testing.T) {
+	// Setup
+	os.Setenv("GITLAB_URL", "https://gitlab.example.com")
+	os.Setenv("GITLAB_TOKEN", "test-token")
+	os.Setenv("GITLAB_MCP_DRY_RUN", "true")
+	defer func() {
+		os.Unsetenv("GITLAB_URL")
+		os.Unsetenv("GITLAB_TOKEN")
+		os.Unsetenv("GITLAB_MCP_DRY_RUN")
+	}()
+
+	// Execute
+	cfg, err := Load()
+
+	// Verify
+	require.NoError(t, err)
+	assert.True(t, cfg.DryRun)
+}
+
+func TestLoad_GeneratedFilePatterns(t *testing.T) {
+	// Setup
+	os.Setenv("GITLAB_URL", "https://gitlab.example.com")
+	os.Setenv("GITLAB_TOKEN", "test-token")
+	os.Setenv("GITLAB_MCP_GENERATED_FILE_PATTERNS", "*.pb.go, gen/**")
+	defer func() {
+		os.Unsetenv("GITLAB_URL")
+		os.Unsetenv("GITLAB_TOKEN")
+		os.Unsetenv("GITLAB_MCP_GENERATED_FILE_PATTERNS")
+	}()
+
+	// Execute
+	cfg, err := Load()
+
+	// Verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.pb.go", "gen/**"}, cfg.GeneratedFilePatterns)
+}
+
+func TestLoad_Language(t *testing.T) {
+	// Setup
+	os.Setenv("GITLAB_URL", "https://gitlab.example.com")
+	os.Setenv("GITLAB_TOKEN", "test-token")
+	os.Setenv("GITLAB_MCP_LANG", " ja ")
+	defer func() {
+		os.Unsetenv("GITLAB_URL")
+		os.Unsetenv("GITLAB_TOKEN")
+		os.Unsetenv("GITLAB_MCP_LANG")
+	}()
+
+	// Execute
+	cfg, err := Load()
+
+	// Verify
+	require.NoError(t, err)
+	assert.Equal(t, "ja", cfg.Language)
+}
+
+func TestLoad_Polling(t *testing.T) {
+	// Setup
+	os.Setenv("GITLAB_URL", "https://gitlab.example.com")
+	os.Setenv("GITLAB_TOKEN", "test-token")
+	defer func() {
+		os.Unsetenv("GITLAB_URL")
+		os.Unsetenv("GITLAB_TOKEN")
+		os.Unsetenv("GITLAB_MCP_POLL_INTERVAL")
+		os.Unsetenv("GITLAB_MCP_POLL_RATE_LIMIT")
+	}()
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultPollInterval, cfg.PollInterval)
+		assert.Equal(t, DefaultPollRateLimit, cfg.PollRateLimit)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		os.Setenv("GITLAB_MCP_POLL_INTERVAL", "2m")
+		os.Setenv("GITLAB_MCP_POLL_RATE_LIMIT", "10")
+
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, cfg.PollInterval)
+		assert.Equal(t, 10, cfg.PollRateLimit)
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		os.Setenv("GITLAB_MCP_POLL_INTERVAL", "soon")
+		os.Setenv("GITLAB_MCP_POLL_RATE_LIMIT", "10")
+
+		_, err := Load()
+
+		assert.ErrorContains(t, err, "GITLAB_MCP_POLL_INTERVAL")
+	})
+
+	t.Run("invalid rate limit", func(t *testing.T) {
+		os.Setenv("GITLAB_MCP_POLL_INTERVAL", "30s")
+		os.Setenv("GITLAB_MCP_POLL_RATE_LIMIT", "0")
+
+		_, err := Load()
+
+		assert.ErrorContains(t, err, "GITLAB_MCP_POLL_RATE_LIMIT")
+	})
+}
+
 func TestLoad_EnabledTools(t *testing.T) {
 	// Setup
 	os.Setenv("GITLAB_URL", "https://gitlab.example.com")
@@ -114,6 +240,45 @@ func TestLoad_DisabledTools(t *testing.T) {
 	assert.Equal(t, []string{"merge_merge_request", "approve_merge_request"}, cfg.DisabledTools)
 }
 
+func TestLoad_ConfirmTools(t *testing.T) {
+	// Setup
+	os.Setenv("GITLAB_URL", "https://gitlab.example.com")
+	os.Setenv("GITLAB_TOKEN", "test-token")
+	defer func() {
+		os.Unsetenv("GITLAB_URL")
+		os.Unsetenv("GITLAB_TOKEN")
+		os.Unsetenv("GITLAB_MCP_CONFIRM_TOOLS")
+	}()
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.Nil(t, cfg.ConfirmTools)
+		assert.True(t, cfg.RequiresConfirmation("merge_merge_request"))
+	})
+
+	t.Run("custom list", func(t *testing.T) {
+		os.Setenv("GITLAB_MCP_CONFIRM_TOOLS", "merge_merge_request, delete_issue")
+
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"merge_merge_request", "delete_issue"}, cfg.ConfirmTools)
+		assert.False(t, cfg.RequiresConfirmation("cancel_pipeline"))
+	})
+
+	t.Run("empty disables confirmation", func(t *testing.T) {
+		os.Setenv("GITLAB_MCP_CONFIRM_TOOLS", "")
+
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{}, cfg.ConfirmTools)
+		assert.False(t, cfg.RequiresConfirmation("merge_merge_request"))
+	})
+}
+
 func TestConfig_String_MasksToken(t *testing.T) {
 	cfg := &Config{
 		GitLabURL:   "https://gitlab.example.com",
@@ -182,3 +347,25 @@ func TestIsToolEnabled_DisabledTakesPrecedence(t *testing.T) {
 	assert.False(t, cfg.IsToolEnabled("merge_merge_request")) // disabled even though in enabled list
 	assert.False(t, cfg.IsToolEnabled("get_merge_request"))   // not in enabled list
 }
+
+func TestRequiresConfirmation(t *testing.T) {
+	tests := []struct {
+		name         string
+		confirmTools []string
+		tool         string
+		want         bool
+	}{
+		{name: "default list", confirmTools: nil, tool: "delete_issue", want: true},
+		{name: "not in default list", confirmTools: nil, tool: "close_issue", want: false},
+		{name: "custom list", confirmTools: []string{"close_issue"}, tool: "close_issue", want: true},
+		{name: "custom list replaces defaults", confirmTools: []string{"close_issue"}, tool: "delete_issue", want: false},
+		{name: "empty list", confirmTools: []string{}, tool: "delete_issue", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ConfirmTools: tt.confirmTools}
+			assert.Equal(t, tt.want, cfg.RequiresConfirmation(tt.tool))
+		})
+	}
+}
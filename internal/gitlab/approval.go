@@ -6,7 +6,11 @@ import (
 
 // ApproveMergeRequest はMRを承認する
 func (c *Client) ApproveMergeRequest(projectID string, mrIID int) (*gogitlab.MergeRequestApprovals, error) {
-	approvals, resp, err := c.client.MergeRequestApprovals.ApproveMergeRequest(projectID, int64(mrIID), nil)
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
+	}
+
+	approvals, resp, err := c.client.MergeRequestApprovals.ApproveMergeRequest(projectID, int64(mrIID), nil, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -15,7 +19,11 @@ func (c *Client) ApproveMergeRequest(projectID string, mrIID int) (*gogitlab.Mer
 
 // UnapproveMergeRequest はMRの承認を取り消す
 func (c *Client) UnapproveMergeRequest(projectID string, mrIID int) error {
-	resp, err := c.client.MergeRequestApprovals.UnapproveMergeRequest(projectID, int64(mrIID))
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.MergeRequestApprovals.UnapproveMergeRequest(projectID, int64(mrIID), c.requestOptions()...)
 	if err != nil {
 		return FromGitLabResponse(err, resp)
 	}
@@ -24,7 +32,7 @@ func (c *Client) UnapproveMergeRequest(projectID string, mrIID int) error {
 
 // GetMergeRequestApprovals はMRの承認状態を取得する
 func (c *Client) GetMergeRequestApprovals(projectID string, mrIID int) (*gogitlab.MergeRequestApprovals, error) {
-	approvals, resp, err := c.client.MergeRequestApprovals.GetConfiguration(projectID, int64(mrIID))
+	approvals, resp, err := c.client.MergeRequestApprovals.GetConfiguration(projectID, int64(mrIID), c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
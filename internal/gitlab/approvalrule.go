@@ -0,0 +1,210 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ApprovalRuleOptions は承認ルールの作成・更新のオプション
+// nil のフィールドは送信しない。Usernames・ProtectedBranchIDs・AppliesToAllProtectedBranches はプロジェクトのルールのみ、
+// ApprovalProjectRuleID は MR のルールの作成時のみ使用する
+type ApprovalRuleOptions struct {
+	Name                          *string
+	ApprovalsRequired             *int
+	UserIDs                       []int
+	GroupIDs                      []int
+	Usernames                     []string
+	ProtectedBranchIDs            []int
+	AppliesToAllProtectedBranches *bool
+	ApprovalProjectRuleID         *int
+}
+
+// int64Slice は ID のリストを SDK の型に変換する（nil の場合は nil を返す）
+func int64Slice(ids []int) *[]int64 {
+	if ids == nil {
+		return nil
+	}
+	converted := make([]int64, len(ids))
+	for i, id := range ids {
+		converted[i] = int64(id)
+	}
+	return &converted
+}
+
+// int64Ptr は int のポインタを int64 のポインタに変換する
+func int64Ptr(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	converted := int64(*v)
+	return &converted
+}
+
+// GetMergeRequestApprovalState は MR の承認ルールごとの承認状態を取得する
+func (c *Client) GetMergeRequestApprovalState(projectID string, mrIID int) (*gogitlab.MergeRequestApprovalState, error) {
+	state, resp, err := c.client.MergeRequestApprovals.GetApprovalState(projectID, int64(mrIID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return state, nil
+}
+
+// ListMergeRequestApprovalRules は MR の承認ルール一覧を取得する
+func (c *Client) ListMergeRequestApprovalRules(projectID string, mrIID int) ([]*gogitlab.MergeRequestApprovalRule, error) {
+	rules, resp, err := c.client.MergeRequestApprovals.GetApprovalRules(projectID, int64(mrIID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return rules, nil
+}
+
+// GetMergeRequestApprovalRule は MR の承認ルールを取得する
+// 個別に取得する API がないため一覧から探す
+func (c *Client) GetMergeRequestApprovalRule(projectID string, mrIID, ruleID int) (*gogitlab.MergeRequestApprovalRule, error) {
+	rules, err := c.ListMergeRequestApprovalRules(projectID, mrIID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.ID == int64(ruleID) {
+			return r, nil
+		}
+	}
+	return nil, &MCPError{Code: ErrCodeNotFound, Message: Msg(MsgNotFound)}
+}
+
+// CreateMergeRequestApprovalRule は MR の承認ルールを作成する
+func (c *Client) CreateMergeRequestApprovalRule(projectID string, mrIID int, opts *ApprovalRuleOptions) (*gogitlab.MergeRequestApprovalRule, error) {
+	createOpts := &gogitlab.CreateMergeRequestApprovalRuleOptions{
+		Name:                  opts.Name,
+		ApprovalsRequired:     int64Ptr(opts.ApprovalsRequired),
+		ApprovalProjectRuleID: int64Ptr(opts.ApprovalProjectRuleID),
+		UserIDs:               int64Slice(opts.UserIDs),
+		GroupIDs:              int64Slice(opts.GroupIDs),
+	}
+
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
+	}
+
+	rule, resp, err := c.client.MergeRequestApprovals.CreateApprovalRule(projectID, int64(mrIID), createOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return rule, nil
+}
+
+// UpdateMergeRequestApprovalRule は MR の承認ルールを更新する
+func (c *Client) UpdateMergeRequestApprovalRule(projectID string, mrIID, ruleID int, opts *ApprovalRuleOptions) (*gogitlab.MergeRequestApprovalRule, error) {
+	updateOpts := &gogitlab.UpdateMergeRequestApprovalRuleOptions{
+		Name:              opts.Name,
+		ApprovalsRequired: int64Ptr(opts.ApprovalsRequired),
+		UserIDs:           int64Slice(opts.UserIDs),
+		GroupIDs:          int64Slice(opts.GroupIDs),
+	}
+
+	if err := c.requireMergeRequestApprovalRule(projectID, mrIID, ruleID); err != nil {
+		return nil, err
+	}
+
+	rule, resp, err := c.client.MergeRequestApprovals.UpdateApprovalRule(projectID, int64(mrIID), int64(ruleID), updateOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return rule, nil
+}
+
+// DeleteMergeRequestApprovalRule は MR の承認ルールを削除する
+func (c *Client) DeleteMergeRequestApprovalRule(projectID string, mrIID, ruleID int) error {
+	if err := c.requireMergeRequestApprovalRule(projectID, mrIID, ruleID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.MergeRequestApprovals.DeleteApprovalRule(projectID, int64(mrIID), int64(ruleID), c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+// ListProjectApprovalRules はプロジェクトの承認ルール一覧を取得する
+func (c *Client) ListProjectApprovalRules(projectID string, pagination *PaginationOptions) ([]*gogitlab.ProjectApprovalRule, error) {
+	opts := &gogitlab.GetProjectApprovalRulesListsOptions{ListOptions: listOptions(pagination)}
+
+	rules, resp, err := c.client.Projects.GetProjectApprovalRules(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return rules, nil
+}
+
+// CreateProjectApprovalRule はプロジェクトの承認ルールを作成する
+func (c *Client) CreateProjectApprovalRule(projectID string, opts *ApprovalRuleOptions) (*gogitlab.ProjectApprovalRule, error) {
+	createOpts := &gogitlab.CreateProjectLevelRuleOptions{
+		Name:                          opts.Name,
+		ApprovalsRequired:             int64Ptr(opts.ApprovalsRequired),
+		UserIDs:                       int64Slice(opts.UserIDs),
+		GroupIDs:                      int64Slice(opts.GroupIDs),
+		ProtectedBranchIDs:            int64Slice(opts.ProtectedBranchIDs),
+		AppliesToAllProtectedBranches: opts.AppliesToAllProtectedBranches,
+	}
+	if opts.Usernames != nil {
+		createOpts.Usernames = &opts.Usernames
+	}
+
+	if err := c.requireProject(projectID); err != nil {
+		return nil, err
+	}
+
+	rule, resp, err := c.client.Projects.CreateProjectApprovalRule(projectID, createOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return rule, nil
+}
+
+// UpdateProjectApprovalRule はプロジェクトの承認ルールを更新する
+func (c *Client) UpdateProjectApprovalRule(projectID string, ruleID int, opts *ApprovalRuleOptions) (*gogitlab.ProjectApprovalRule, error) {
+	updateOpts := &gogitlab.UpdateProjectLevelRuleOptions{
+		Name:                          opts.Name,
+		ApprovalsRequired:             int64Ptr(opts.ApprovalsRequired),
+		UserIDs:                       int64Slice(opts.UserIDs),
+		GroupIDs:                      int64Slice(opts.GroupIDs),
+		ProtectedBranchIDs:            int64Slice(opts.ProtectedBranchIDs),
+		AppliesToAllProtectedBranches: opts.AppliesToAllProtectedBranches,
+	}
+	if opts.Usernames != nil {
+		updateOpts.Usernames = &opts.Usernames
+	}
+
+	if err := c.requireProjectApprovalRule(projectID, ruleID); err != nil {
+		return nil, err
+	}
+
+	rule, resp, err := c.client.Projects.UpdateProjectApprovalRule(projectID, int64(ruleID), updateOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return rule, nil
+}
+
+// GetProjectApprovalRule はプロジェクトの承認ルールを取得する
+func (c *Client) GetProjectApprovalRule(projectID string, ruleID int) (*gogitlab.ProjectApprovalRule, error) {
+	rule, resp, err := c.client.Projects.GetProjectApprovalRule(projectID, int64(ruleID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return rule, nil
+}
+
+// DeleteProjectApprovalRule はプロジェクトの承認ルールを削除する
+func (c *Client) DeleteProjectApprovalRule(projectID string, ruleID int) error {
+	if err := c.requireProjectApprovalRule(projectID, ruleID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Projects.DeleteProjectApprovalRule(projectID, int64(ruleID), c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMergeRequestApprovalState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/approval_state", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"approval_rules_overwritten": true,
+			"rules": []map[string]any{
+				{"id": 1, "name": "Backend", "rule_type": "regular", "approvals_required": 2, "approved": false},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	state, err := client.GetMergeRequestApprovalState("test-project", 1)
+
+	require.NoError(t, err)
+	assert.True(t, state.ApprovalRulesOverwritten)
+	require.Len(t, state.Rules, 1)
+	assert.Equal(t, "Backend", state.Rules[0].Name)
+}
+
+func TestCreateMergeRequestApprovalRule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/approval_rules", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "Security", body["name"])
+		assert.Equal(t, float64(0), body["approvals_required"])
+		assert.Equal(t, []any{float64(10), float64(11)}, body["user_ids"])
+		assert.NotContains(t, body, "group_ids")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"id": 5, "name": "Security"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	name := "Security"
+	required := 0
+	rule, err := client.CreateMergeRequestApprovalRule("test-project", 1, &ApprovalRuleOptions{
+		Name:              &name,
+		ApprovalsRequired: &required,
+		UserIDs:           []int{10, 11},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), rule.ID)
+}
+
+func TestDeleteMergeRequestApprovalRule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/approval_rules/5", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	err = client.DeleteMergeRequestApprovalRule("test-project", 1, 5)
+
+	require.NoError(t, err)
+}
+
+func TestUpdateProjectApprovalRule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/approval_rules/7", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, []any{"alice"}, body["usernames"])
+		assert.Equal(t, true, body["applies_to_all_protected_branches"])
+		assert.NotContains(t, body, "name")
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 7, "name": "Default"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	all := true
+	rule, err := client.UpdateProjectApprovalRule("test-project", 7, &ApprovalRuleOptions{
+		Usernames:                     []string{"alice"},
+		AppliesToAllProtectedBranches: &all,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Default", rule.Name)
+}
+
+func TestListProjectApprovalRules_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"message": "403 Forbidden"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	rules, err := client.ListProjectApprovalRules("test-project", nil)
+
+	assert.Nil(t, rules)
+	mcpErr, ok := err.(*MCPError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeForbidden, mcpErr.Code)
+}
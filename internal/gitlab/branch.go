@@ -0,0 +1,39 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// SearchBranches はプロジェクトのブランチを名前で検索する
+func (c *Client) SearchBranches(projectID, search string, limit int) ([]*gogitlab.Branch, error) {
+	opts := &gogitlab.ListBranchesOptions{
+		ListOptions: gogitlab.ListOptions{PerPage: int64(limit)},
+	}
+	if search != "" {
+		opts.Search = &search
+	}
+
+	branches, resp, err := c.client.Branches.ListBranches(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return branches, nil
+}
+
+// SearchTags はプロジェクトのタグを名前で検索する
+// 最近更新されたタグから limit 件を返す
+func (c *Client) SearchTags(projectID, search string, limit int) ([]*gogitlab.Tag, error) {
+	opts := &gogitlab.ListTagsOptions{
+		ListOptions: gogitlab.ListOptions{PerPage: int64(limit)},
+		OrderBy:     gogitlab.Ptr("updated"),
+	}
+	if search != "" {
+		opts.Search = &search
+	}
+
+	tags, resp, err := c.client.Tags.ListTags(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return tags, nil
+}
@@ -0,0 +1,54 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchBranches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/repository/branches", r.URL.Path)
+		assert.Equal(t, "feat", r.URL.Query().Get("search"))
+		assert.Equal(t, "20", r.URL.Query().Get("per_page"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"name": "feature/login"},
+			{"name": "feature/billing"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	branches, err := client.SearchBranches("test-project", "feat", 20)
+
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	assert.Equal(t, "feature/login", branches[0].Name)
+}
+
+func TestSearchTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/repository/tags", r.URL.Path)
+		assert.Equal(t, "v1", r.URL.Query().Get("search"))
+		assert.Equal(t, "updated", r.URL.Query().Get("order_by"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"name": "v1.2.0"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	tags, err := client.SearchTags("test-project", "v1", 20)
+
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "v1.2.0", tags[0].Name)
+}
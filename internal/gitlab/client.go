@@ -9,6 +9,9 @@ import (
 // Client は GitLab API クライアントのラッパー
 type Client struct {
 	client *gogitlab.Client
+
+	// plan はドライランのクライアントが書き込みを記録する DryRunPlan（ドライランでなければ nil）
+	plan *DryRunPlan
 }
 
 // NewClient は新しい GitLab クライアントを作成する
@@ -20,11 +23,11 @@ func NewClient(baseURL, token string) (*Client, error) {
 		return nil, errors.New("GitLab token is required")
 	}
 
-	client, err := gogitlab.NewClient(token, gogitlab.WithBaseURL(baseURL))
+	// ドライランのクライアントもこのクライアントを共有し、書き込みはリクエストの DryRunPlan に記録する
+	client, err := gogitlab.NewClient(token, gogitlab.WithBaseURL(baseURL), gogitlab.WithInterceptor(dryRunInterceptor))
 	if err != nil {
 		return nil, err
 	}
-
 	return &Client{client: client}, nil
 }
 
@@ -62,3 +65,33 @@ func (c *Client) Notes() gogitlab.NotesServiceInterface {
 func (c *Client) Issues() gogitlab.IssuesServiceInterface {
 	return c.client.Issues
 }
+
+// PipelineSchedules returns the PipelineSchedulesService
+func (c *Client) PipelineSchedules() gogitlab.PipelineSchedulesServiceInterface {
+	return c.client.PipelineSchedules
+}
+
+// ProjectVariables returns the ProjectVariablesService
+func (c *Client) ProjectVariables() gogitlab.ProjectVariablesServiceInterface {
+	return c.client.ProjectVariables
+}
+
+// GroupVariables returns the GroupVariablesService
+func (c *Client) GroupVariables() gogitlab.GroupVariablesServiceInterface {
+	return c.client.GroupVariables
+}
+
+// DraftNotes returns the DraftNotesService
+func (c *Client) DraftNotes() gogitlab.DraftNotesServiceInterface {
+	return c.client.DraftNotes
+}
+
+// Repositories returns the RepositoriesService
+func (c *Client) Repositories() gogitlab.RepositoriesServiceInterface {
+	return c.client.Repositories
+}
+
+// RepositoryFiles returns the RepositoryFilesService
+func (c *Client) RepositoryFiles() gogitlab.RepositoryFilesServiceInterface {
+	return c.client.RepositoryFiles
+}
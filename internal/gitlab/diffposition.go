@@ -0,0 +1,284 @@
+package gitlab
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// 差分行の種類
+const (
+	DiffLineAdded   = "added"
+	DiffLineRemoved = "removed"
+	DiffLineContext = "context"
+)
+
+// hunkHeaderPattern は unified diff のハンクヘッダ（@@ -a,b +c,d @@）にマッチする
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// DiffLine は差分内の1行を表す
+// 追加行は OldLine が 0、削除行は NewLine が 0 になる
+// OldPos/NewPos は GitLab の line_code 計算に使う位置で、追加・削除行でも直前の行番号の次を指す
+type DiffLine struct {
+	Type    string
+	OldLine int
+	NewLine int
+	OldPos  int
+	NewPos  int
+}
+
+// ParseDiffLines は unified diff をパースして差分に含まれる行の一覧を返す
+func ParseDiffLines(diff string) []DiffLine {
+	var lines []DiffLine
+	var oldLine, newLine int
+	inHunk := false
+
+	for _, text := range strings.Split(diff, "\n") {
+		if m := hunkHeaderPattern.FindStringSubmatch(text); m != nil {
+			oldLine, _ = strconv.Atoi(m[1])
+			newLine, _ = strconv.Atoi(m[2])
+			inHunk = true
+			continue
+		}
+		if !inHunk || text == "" {
+			continue
+		}
+
+		switch text[0] {
+		case '+':
+			lines = append(lines, DiffLine{Type: DiffLineAdded, NewLine: newLine, OldPos: oldLine, NewPos: newLine})
+			newLine++
+		case '-':
+			lines = append(lines, DiffLine{Type: DiffLineRemoved, OldLine: oldLine, OldPos: oldLine, NewPos: newLine})
+			oldLine++
+		case ' ':
+			lines = append(lines, DiffLine{Type: DiffLineContext, OldLine: oldLine, NewLine: newLine, OldPos: oldLine, NewPos: newLine})
+			oldLine++
+			newLine++
+		}
+		// "\ No newline at end of file" などは行番号に影響しないため無視する
+	}
+	return lines
+}
+
+// LinePosition は複数行コメントの範囲の端点
+// Type は追加行が "new"、削除行が "old"、コンテキスト行は空になる
+type LinePosition struct {
+	LineCode string
+	Type     string
+	OldLine  *int
+	NewLine  *int
+}
+
+// LineRange は複数行コメントの範囲
+type LineRange struct {
+	Start LinePosition
+	End   LinePosition
+}
+
+// ResolvedPosition は差分上の位置を GitLab の position 形式に解決した結果
+// LineRange は複数行コメントの場合のみ設定され、行番号は範囲の最終行を指す
+type ResolvedPosition struct {
+	BaseSHA   string
+	StartSHA  string
+	HeadSHA   string
+	OldPath   string
+	NewPath   string
+	OldLine   *int
+	NewLine   *int
+	LineType  string
+	LineRange *LineRange
+}
+
+// LineCode は GitLab の line_code（<パスの SHA1>_<old位置>_<new位置>）を計算する
+func LineCode(filePath string, line DiffLine) string {
+	return fmt.Sprintf("%x_%d_%d", sha1.Sum([]byte(filePath)), line.OldPos, line.NewPos)
+}
+
+// diffFilePath は GitLab が line_code の計算に使うファイルパスを返す
+func diffFilePath(diff *gogitlab.Diff) string {
+	if diff.NewPath != "" {
+		return diff.NewPath
+	}
+	return diff.OldPath
+}
+
+// newLinePosition は差分行から範囲の端点を作成する
+func newLinePosition(diff *gogitlab.Diff, line DiffLine) LinePosition {
+	pos := LinePosition{LineCode: LineCode(diffFilePath(diff), line)}
+	switch line.Type {
+	case DiffLineAdded:
+		pos.Type = "new"
+	case DiffLineRemoved:
+		pos.Type = "old"
+	}
+	if line.Type != DiffLineAdded {
+		pos.OldLine = &line.OldLine
+	}
+	if line.Type != DiffLineRemoved {
+		pos.NewLine = &line.NewLine
+	}
+	return pos
+}
+
+// FindDiffFile は差分の中からファイルパス（変更前・変更後のどちらでも可）に一致するファイルを探す
+func FindDiffFile(diffs []*gogitlab.Diff, filePath string) (*gogitlab.Diff, error) {
+	for _, d := range diffs {
+		if d.NewPath == filePath {
+			return d, nil
+		}
+	}
+	for _, d := range diffs {
+		if d.OldPath == filePath {
+			return d, nil
+		}
+	}
+	return nil, &MCPError{
+		Code:    ErrCodeBadRequest,
+		Message: Msg(MsgFileNotInDiff, filePath),
+	}
+}
+
+// FindDiffLine は差分の行一覧から指定された行を探す
+// newLine が指定された場合は変更後の行番号、そうでなければ oldLine を変更前の行番号として探す
+func FindDiffLine(lines []DiffLine, newLine, oldLine *int) (DiffLine, bool) {
+	i := findDiffLineIndex(lines, newLine, oldLine)
+	if i < 0 {
+		return DiffLine{}, false
+	}
+	return lines[i], true
+}
+
+// findDiffLineIndex は FindDiffLine と同じ条件で行のインデックスを返す（見つからない場合は -1）
+func findDiffLineIndex(lines []DiffLine, newLine, oldLine *int) int {
+	for i, l := range lines {
+		if newLine != nil {
+			if l.NewLine == *newLine && (oldLine == nil || l.OldLine == *oldLine) {
+				return i
+			}
+			continue
+		}
+		if oldLine != nil && l.OldLine == *oldLine {
+			return i
+		}
+	}
+	return -1
+}
+
+// ResolveDiffPosition は差分バージョンとファイル・行番号から GitLab の position を解決する
+// 追加行は new_line のみ、削除行は old_line のみ、コンテキスト行は両方を設定する
+func ResolveDiffPosition(version *gogitlab.MergeRequestDiffVersion, filePath string, newLine, oldLine *int) (*ResolvedPosition, error) {
+	return ResolveDiffRange(version, filePath, nil, nil, newLine, oldLine)
+}
+
+// ResolveDiffRange は複数行コメントの position を解決する
+// 開始行（startNewLine/startOldLine）が省略された場合は単一行の position になる
+// GitLab の仕様に合わせ、position の行番号は範囲の最終行（newLine/oldLine）に設定する
+func ResolveDiffRange(version *gogitlab.MergeRequestDiffVersion, filePath string, startNewLine, startOldLine, newLine, oldLine *int) (*ResolvedPosition, error) {
+	if newLine == nil && oldLine == nil {
+		return nil, &MCPError{
+			Code:    ErrCodeBadRequest,
+			Message: Msg(MsgLineRequired),
+		}
+	}
+
+	diff, err := FindDiffFile(version.Diffs, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := ParseDiffLines(diff.Diff)
+	end := findDiffLineIndex(lines, newLine, oldLine)
+	if end < 0 {
+		return nil, lineNotInDiffError(filePath, newLine, oldLine)
+	}
+	line := lines[end]
+
+	pos := &ResolvedPosition{
+		BaseSHA:  version.BaseCommitSHA,
+		StartSHA: version.StartCommitSHA,
+		HeadSHA:  version.HeadCommitSHA,
+		OldPath:  diff.OldPath,
+		NewPath:  diff.NewPath,
+		LineType: line.Type,
+	}
+	if line.Type != DiffLineAdded {
+		pos.OldLine = &line.OldLine
+	}
+	if line.Type != DiffLineRemoved {
+		pos.NewLine = &line.NewLine
+	}
+
+	if startNewLine != nil || startOldLine != nil {
+		start := findDiffLineIndex(lines, startNewLine, startOldLine)
+		if start < 0 {
+			return nil, lineNotInDiffError(filePath, startNewLine, startOldLine)
+		}
+		if start > end {
+			return nil, &MCPError{
+				Code:    ErrCodeBadRequest,
+				Message: Msg(MsgLineRangeOrder),
+			}
+		}
+		pos.LineRange = &LineRange{
+			Start: newLinePosition(diff, lines[start]),
+			End:   newLinePosition(diff, line),
+		}
+	}
+	return pos, nil
+}
+
+// lineNotInDiffError は指定行が差分に含まれない場合のエラーを作成する
+func lineNotInDiffError(filePath string, newLine, oldLine *int) *MCPError {
+	return &MCPError{
+		Code:    ErrCodeBadRequest,
+		Message: Msg(MsgLineNotInDiff, describeLine(newLine, oldLine), filePath),
+	}
+}
+
+// describeLine はエラーメッセージ用に行番号を文字列化する
+func describeLine(newLine, oldLine *int) string {
+	switch {
+	case newLine != nil && oldLine != nil:
+		return Msg(MsgLineOldNew, *oldLine, *newLine)
+	case newLine != nil:
+		return Msg(MsgLineNew, *newLine)
+	default:
+		return Msg(MsgLineOld, *oldLine)
+	}
+}
+
+// GetLatestMergeRequestDiffVersion は MR の最新の差分バージョンを差分付きで取得する
+func (c *Client) GetLatestMergeRequestDiffVersion(projectID string, mrIID int) (*gogitlab.MergeRequestDiffVersion, error) {
+	versions, err := c.ListMergeRequestDiffVersions(projectID, mrIID, &PaginationOptions{Page: 1, PerPage: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, &MCPError{
+			Code:    ErrCodeNotFound,
+			Message: Msg(MsgDiffVersionNotFound),
+		}
+	}
+
+	// 一覧には差分が含まれないため、最新バージョンを個別に取得する
+	return c.GetMergeRequestDiffVersion(projectID, mrIID, int(versions[0].ID))
+}
+
+// ResolveMergeRequestDiffPosition は MR の最新差分からファイル・行番号に対応する position を解決する
+func (c *Client) ResolveMergeRequestDiffPosition(projectID string, mrIID int, filePath string, newLine, oldLine *int) (*ResolvedPosition, error) {
+	return c.ResolveMergeRequestDiffRange(projectID, mrIID, filePath, nil, nil, newLine, oldLine)
+}
+
+// ResolveMergeRequestDiffRange は MR の最新差分から複数行コメントの position を解決する
+func (c *Client) ResolveMergeRequestDiffRange(projectID string, mrIID int, filePath string, startNewLine, startOldLine, newLine, oldLine *int) (*ResolvedPosition, error) {
+	version, err := c.GetLatestMergeRequestDiffVersion(projectID, mrIID)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveDiffRange(version, filePath, startNewLine, startOldLine, newLine, oldLine)
+}
@@ -0,0 +1,203 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// sampleDiff は追加・削除・コンテキスト行を含む差分
+const sampleDiff = `@@ -1,4 +1,5 @@
+ package main
+-import "fmt"
++import (
++	"fmt"
++)
+ 
+ func main() {
+@@ -10,2 +11,2 @@ func main() {
+ 	fmt.Println("a")
+-	fmt.Println("b")
++	fmt.Println("c")
+\ No newline at end of file
+`
+
+func sampleVersion() *gogitlab.MergeRequestDiffVersion {
+	return &gogitlab.MergeRequestDiffVersion{
+		ID:             3,
+		BaseCommitSHA:  "base",
+		StartCommitSHA: "start",
+		HeadCommitSHA:  "head",
+		Diffs: []*gogitlab.Diff{
+			{OldPath: "main.go", NewPath: "main.go", Diff: sampleDiff},
+			{OldPath: "old/name.go", NewPath: "new/name.go", RenamedFile: true, Diff: "@@ -5,2 +5,2 @@\n ctx\n-old\n+new\n"},
+		},
+	}
+}
+
+func TestParseDiffLines(t *testing.T) {
+	lines := ParseDiffLines(sampleDiff)
+
+	require.Len(t, lines, 10)
+	assert.Equal(t, DiffLine{Type: DiffLineContext, OldLine: 1, NewLine: 1, OldPos: 1, NewPos: 1}, lines[0])
+	assert.Equal(t, DiffLine{Type: DiffLineRemoved, OldLine: 2, OldPos: 2, NewPos: 2}, lines[1])
+	assert.Equal(t, DiffLine{Type: DiffLineAdded, NewLine: 2, OldPos: 3, NewPos: 2}, lines[2])
+	assert.Equal(t, DiffLine{Type: DiffLineAdded, NewLine: 4, OldPos: 3, NewPos: 4}, lines[4])
+	assert.Equal(t, DiffLine{Type: DiffLineContext, OldLine: 3, NewLine: 5, OldPos: 3, NewPos: 5}, lines[5])
+	assert.Equal(t, DiffLine{Type: DiffLineContext, OldLine: 10, NewLine: 11, OldPos: 10, NewPos: 11}, lines[7])
+	assert.Equal(t, DiffLine{Type: DiffLineRemoved, OldLine: 11, OldPos: 11, NewPos: 12}, lines[8])
+	assert.Equal(t, DiffLine{Type: DiffLineAdded, NewLine: 12, OldPos: 12, NewPos: 12}, lines[9])
+}
+
+func TestResolveDiffPosition(t *testing.T) {
+	t.Run("added line sets only new_line", func(t *testing.T) {
+		pos, err := ResolveDiffPosition(sampleVersion(), "main.go", intPtr(3), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, DiffLineAdded, pos.LineType)
+		assert.Nil(t, pos.OldLine)
+		assert.Equal(t, 3, *pos.NewLine)
+		assert.Equal(t, "base", pos.BaseSHA)
+		assert.Equal(t, "start", pos.StartSHA)
+		assert.Equal(t, "head", pos.HeadSHA)
+	})
+
+	t.Run("context line sets both lines", func(t *testing.T) {
+		pos, err := ResolveDiffPosition(sampleVersion(), "main.go", intPtr(11), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, DiffLineContext, pos.LineType)
+		assert.Equal(t, 10, *pos.OldLine)
+		assert.Equal(t, 11, *pos.NewLine)
+	})
+
+	t.Run("removed line is found by old_line", func(t *testing.T) {
+		pos, err := ResolveDiffPosition(sampleVersion(), "main.go", nil, intPtr(11))
+
+		require.NoError(t, err)
+		assert.Equal(t, DiffLineRemoved, pos.LineType)
+		assert.Equal(t, 11, *pos.OldLine)
+		assert.Nil(t, pos.NewLine)
+	})
+
+	t.Run("renamed file uses both paths", func(t *testing.T) {
+		pos, err := ResolveDiffPosition(sampleVersion(), "old/name.go", intPtr(6), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "old/name.go", pos.OldPath)
+		assert.Equal(t, "new/name.go", pos.NewPath)
+		assert.Equal(t, DiffLineAdded, pos.LineType)
+	})
+
+	t.Run("line outside the diff returns error", func(t *testing.T) {
+		pos, err := ResolveDiffPosition(sampleVersion(), "main.go", intPtr(8), nil)
+
+		assert.Nil(t, pos)
+		mcpErr, ok := err.(*MCPError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeBadRequest, mcpErr.Code)
+		assert.Contains(t, mcpErr.Message, "main.go")
+	})
+
+	t.Run("file outside the diff returns error", func(t *testing.T) {
+		_, err := ResolveDiffPosition(sampleVersion(), "README.md", intPtr(1), nil)
+
+		mcpErr, ok := err.(*MCPError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeBadRequest, mcpErr.Code)
+		assert.Contains(t, mcpErr.Message, "README.md")
+	})
+
+	t.Run("missing line returns error", func(t *testing.T) {
+		_, err := ResolveDiffPosition(sampleVersion(), "main.go", nil, nil)
+
+		require.Error(t, err)
+	})
+}
+
+func TestResolveDiffRange(t *testing.T) {
+	t.Run("builds line_range with line codes", func(t *testing.T) {
+		pos, err := ResolveDiffRange(sampleVersion(), "main.go", intPtr(1), nil, intPtr(4), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, *pos.NewLine)
+		assert.Nil(t, pos.OldLine)
+		require.NotNil(t, pos.LineRange)
+
+		// sha1("main.go")
+		const pathHash = "0607f785dfa3c3861b3239f6723eb276d8056461"
+		assert.Equal(t, LinePosition{
+			LineCode: pathHash + "_1_1",
+			OldLine:  intPtr(1),
+			NewLine:  intPtr(1),
+		}, pos.LineRange.Start)
+		assert.Equal(t, LinePosition{
+			LineCode: pathHash + "_3_4",
+			Type:     "new",
+			NewLine:  intPtr(4),
+		}, pos.LineRange.End)
+	})
+
+	t.Run("single line has no line_range", func(t *testing.T) {
+		pos, err := ResolveDiffRange(sampleVersion(), "main.go", nil, nil, intPtr(4), nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, pos.LineRange)
+	})
+
+	t.Run("start after end returns error", func(t *testing.T) {
+		_, err := ResolveDiffRange(sampleVersion(), "main.go", intPtr(4), nil, intPtr(2), nil)
+
+		mcpErr, ok := err.(*MCPError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeBadRequest, mcpErr.Code)
+	})
+
+	t.Run("start outside the diff returns error", func(t *testing.T) {
+		_, err := ResolveDiffRange(sampleVersion(), "main.go", intPtr(8), nil, intPtr(11), nil)
+
+		require.Error(t, err)
+	})
+}
+
+func TestResolveMergeRequestDiffPosition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/merge_requests/1/versions":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 3, "head_commit_sha": "head", "base_commit_sha": "base", "start_commit_sha": "start"},
+				{"id": 2, "head_commit_sha": "old-head", "base_commit_sha": "base", "start_commit_sha": "start"},
+			})
+		case "/api/v4/projects/test-project/merge_requests/1/versions/3":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":               3,
+				"head_commit_sha":  "head",
+				"base_commit_sha":  "base",
+				"start_commit_sha": "start",
+				"diffs": []map[string]any{
+					{"old_path": "main.go", "new_path": "main.go", "diff": sampleDiff},
+				},
+			})
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	pos, err := client.ResolveMergeRequestDiffPosition("test-project", 1, "main.go", intPtr(12), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "head", pos.HeadSHA)
+	assert.Equal(t, 12, *pos.NewLine)
+	assert.Nil(t, pos.OldLine)
+}
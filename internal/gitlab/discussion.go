@@ -10,73 +10,140 @@ func (c *Client) AddMergeRequestComment(projectID string, mrIID int, body string
 		Body: &body,
 	}
 
-	note, resp, err := c.client.Notes.CreateMergeRequestNote(projectID, int64(mrIID), opts)
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
+	}
+
+	note, resp, err := c.client.Notes.CreateMergeRequestNote(projectID, int64(mrIID), opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
 	return note, nil
 }
 
-// CreateDiscussionOptions はディスカッション作成のオプション
-type CreateDiscussionOptions struct {
-	Body     string
+// DiffPositionOptions は差分内の位置のオプション（FilePath が空の場合は位置なし）
+type DiffPositionOptions struct {
 	FilePath string
+	// OldPath は変更前のファイルパス（リネーム時のみ FilePath と異なる。省略時は FilePath）
+	OldPath  string
 	OldLine  *int
 	NewLine  *int
 	BaseSHA  string
 	HeadSHA  string
 	StartSHA string
+	// LineRange は複数行コメントの範囲（単一行の場合は nil）
+	LineRange *LineRange
+}
+
+// CreateDiscussionOptions はディスカッション作成のオプション
+type CreateDiscussionOptions struct {
+	Body string
+	DiffPositionOptions
 }
 
 // CreateMergeRequestDiscussion は行コメント（ディスカッション）を作成する
 func (c *Client) CreateMergeRequestDiscussion(projectID string, mrIID int, opts *CreateDiscussionOptions) (*gogitlab.Discussion, error) {
 	createOpts := &gogitlab.CreateMergeRequestDiscussionOptions{
-		Body: &opts.Body,
+		Body:     &opts.Body,
+		Position: toPositionOptions(&opts.DiffPositionOptions),
 	}
 
-	// 位置情報が指定されている場合
-	if opts.FilePath != "" {
-		positionType := "text"
-		position := &gogitlab.PositionOptions{
-			PositionType: &positionType,
-			NewPath:      &opts.FilePath,
-			OldPath:      &opts.FilePath,
-		}
-
-		if opts.NewLine != nil {
-			newLine := int64(*opts.NewLine)
-			position.NewLine = &newLine
-		}
-		if opts.OldLine != nil {
-			oldLine := int64(*opts.OldLine)
-			position.OldLine = &oldLine
-		}
-		if opts.BaseSHA != "" {
-			position.BaseSHA = &opts.BaseSHA
-		}
-		if opts.HeadSHA != "" {
-			position.HeadSHA = &opts.HeadSHA
-		}
-		if opts.StartSHA != "" {
-			position.StartSHA = &opts.StartSHA
-		}
-
-		createOpts.Position = position
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
 	}
 
-	discussion, resp, err := c.client.Discussions.CreateMergeRequestDiscussion(projectID, int64(mrIID), createOpts)
+	discussion, resp, err := c.client.Discussions.CreateMergeRequestDiscussion(projectID, int64(mrIID), createOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
 	return discussion, nil
 }
 
+// toPositionOptions は差分内の位置を SDK の型に変換する（位置が指定されていない場合は nil）
+func toPositionOptions(opts *DiffPositionOptions) *gogitlab.PositionOptions {
+	if opts == nil || opts.FilePath == "" {
+		return nil
+	}
+
+	positionType := "text"
+	oldPath := opts.OldPath
+	if oldPath == "" {
+		oldPath = opts.FilePath
+	}
+	position := &gogitlab.PositionOptions{
+		PositionType: &positionType,
+		NewPath:      &opts.FilePath,
+		OldPath:      &oldPath,
+	}
+
+	if opts.NewLine != nil {
+		newLine := int64(*opts.NewLine)
+		position.NewLine = &newLine
+	}
+	if opts.OldLine != nil {
+		oldLine := int64(*opts.OldLine)
+		position.OldLine = &oldLine
+	}
+	if opts.BaseSHA != "" {
+		position.BaseSHA = &opts.BaseSHA
+	}
+	if opts.HeadSHA != "" {
+		position.HeadSHA = &opts.HeadSHA
+	}
+	if opts.StartSHA != "" {
+		position.StartSHA = &opts.StartSHA
+	}
+	if opts.LineRange != nil {
+		position.LineRange = &gogitlab.LineRangeOptions{
+			Start: toLinePositionOptions(opts.LineRange.Start),
+			End:   toLinePositionOptions(opts.LineRange.End),
+		}
+	}
+	return position
+}
+
+// toLinePositionOptions は範囲の端点を SDK の型に変換する
+func toLinePositionOptions(p LinePosition) *gogitlab.LinePositionOptions {
+	opts := &gogitlab.LinePositionOptions{
+		LineCode: &p.LineCode,
+	}
+	if p.Type != "" {
+		opts.Type = &p.Type
+	}
+	if p.OldLine != nil {
+		oldLine := int64(*p.OldLine)
+		opts.OldLine = &oldLine
+	}
+	if p.NewLine != nil {
+		newLine := int64(*p.NewLine)
+		opts.NewLine = &newLine
+	}
+	return opts
+}
+
 // PaginationOptions はページネーションのオプション
 type PaginationOptions struct {
 	Page    int
 	PerPage int
 }
 
+// listOptions はページネーションのオプションを SDK の型に変換する
+func listOptions(pagination *PaginationOptions) gogitlab.ListOptions {
+	page, perPage := 1, 100
+	if pagination != nil {
+		if pagination.Page > 0 {
+			page = pagination.Page
+		}
+		if pagination.PerPage > 0 {
+			perPage = pagination.PerPage
+		}
+	}
+	return gogitlab.ListOptions{
+		Page:    int64(page),
+		PerPage: int64(perPage),
+	}
+}
+
 // ListMergeRequestDiscussions はMRのディスカッション一覧を取得する
 func (c *Client) ListMergeRequestDiscussions(projectID string, mrIID int, pagination *PaginationOptions) ([]*gogitlab.Discussion, error) {
 	page, perPage := 1, 100
@@ -95,7 +162,7 @@ func (c *Client) ListMergeRequestDiscussions(projectID string, mrIID int, pagina
 			PerPage: int64(perPage),
 		},
 	}
-	discussions, resp, err := c.client.Discussions.ListMergeRequestDiscussions(projectID, int64(mrIID), opts)
+	discussions, resp, err := c.client.Discussions.ListMergeRequestDiscussions(projectID, int64(mrIID), opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -108,16 +175,48 @@ func (c *Client) ResolveMergeRequestDiscussion(projectID string, mrIID int, disc
 		Resolved: &resolved,
 	}
 
-	discussion, resp, err := c.client.Discussions.ResolveMergeRequestDiscussion(projectID, int64(mrIID), discussionID, opts)
+	if err := c.requireMergeRequestDiscussion(projectID, mrIID, discussionID); err != nil {
+		return nil, err
+	}
+
+	discussion, resp, err := c.client.Discussions.ResolveMergeRequestDiscussion(projectID, int64(mrIID), discussionID, opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
 	return discussion, nil
 }
 
+// ListRecentMergeRequestNotes はMRのコメント（ノート）を新しい順に取得する
+func (c *Client) ListRecentMergeRequestNotes(projectID string, mrIID int, pagination *PaginationOptions) ([]*gogitlab.Note, error) {
+	opts := &gogitlab.ListMergeRequestNotesOptions{
+		ListOptions: listOptions(pagination),
+		OrderBy:     gogitlab.Ptr("created_at"),
+		Sort:        gogitlab.Ptr("desc"),
+	}
+
+	notes, resp, err := c.client.Notes.ListMergeRequestNotes(projectID, int64(mrIID), opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return notes, nil
+}
+
+// GetMergeRequestNote はMRのコメント（ノート）を取得する
+func (c *Client) GetMergeRequestNote(projectID string, mrIID int, noteID int) (*gogitlab.Note, error) {
+	note, resp, err := c.client.Notes.GetMergeRequestNote(projectID, int64(mrIID), int64(noteID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return note, nil
+}
+
 // DeleteMergeRequestNote はMRのコメント（ノート）を削除する
 func (c *Client) DeleteMergeRequestNote(projectID string, mrIID int, noteID int) error {
-	resp, err := c.client.Notes.DeleteMergeRequestNote(projectID, int64(mrIID), int64(noteID))
+	if err := c.requireMergeRequestNote(projectID, mrIID, noteID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Notes.DeleteMergeRequestNote(projectID, int64(mrIID), int64(noteID), c.requestOptions()...)
 	if err != nil {
 		return FromGitLabResponse(err, resp)
 	}
@@ -130,7 +229,11 @@ func (c *Client) AddMergeRequestDiscussionNote(projectID string, mrIID int, disc
 		Body: &body,
 	}
 
-	note, resp, err := c.client.Discussions.AddMergeRequestDiscussionNote(projectID, int64(mrIID), discussionID, opts)
+	if err := c.requireMergeRequestDiscussion(projectID, mrIID, discussionID); err != nil {
+		return nil, err
+	}
+
+	note, resp, err := c.client.Discussions.AddMergeRequestDiscussionNote(projectID, int64(mrIID), discussionID, opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
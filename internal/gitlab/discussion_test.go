@@ -81,9 +81,11 @@ func TestCreateMergeRequestDiscussion_Success(t *testing.T) {
 	require.NoError(t, err)
 
 	discussion, err := client.CreateMergeRequestDiscussion("test-project", 1, &CreateDiscussionOptions{
-		Body:     "Line comment",
-		FilePath: "main.go",
-		NewLine:  intPtr(10),
+		Body: "Line comment",
+		DiffPositionOptions: DiffPositionOptions{
+			FilePath: "main.go",
+			NewLine:  intPtr(10),
+		},
 	})
 
 	require.NoError(t, err)
@@ -92,6 +94,43 @@ func TestCreateMergeRequestDiscussion_Success(t *testing.T) {
 	assert.Equal(t, "Line comment", discussion.Notes[0].Body)
 }
 
+func TestCreateMergeRequestDiscussion_LineRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		position := body["position"].(map[string]any)
+		assert.Equal(t, "old.go", position["old_path"])
+		assert.Equal(t, "new.go", position["new_path"])
+		assert.Equal(t, map[string]any{
+			"start": map[string]any{"line_code": "abc_1_1", "old_line": float64(1), "new_line": float64(1)},
+			"end":   map[string]any{"line_code": "abc_2_3", "type": "new", "new_line": float64(3)},
+		}, position["line_range"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": "range1"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	discussion, err := client.CreateMergeRequestDiscussion("test-project", 1, &CreateDiscussionOptions{
+		Body: "Multi-line comment",
+		DiffPositionOptions: DiffPositionOptions{
+			FilePath: "new.go",
+			OldPath:  "old.go",
+			NewLine:  intPtr(3),
+			LineRange: &LineRange{
+				Start: LinePosition{LineCode: "abc_1_1", OldLine: intPtr(1), NewLine: intPtr(1)},
+				End:   LinePosition{LineCode: "abc_2_3", Type: "new", NewLine: intPtr(3)},
+			},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "range1", discussion.ID)
+}
+
 func TestListMergeRequestDiscussions_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/discussions", r.URL.Path)
@@ -176,6 +215,27 @@ func TestResolveMergeRequestDiscussion_Unresolve(t *testing.T) {
 	assert.False(t, discussion.Notes[0].Resolved)
 }
 
+func TestGetMergeRequestNote_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/notes/123", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":     123,
+			"body":   "Please rename this",
+			"author": map[string]any{"username": "alice"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	note, err := client.GetMergeRequestNote("test-project", 1, 123)
+	require.NoError(t, err)
+	assert.Equal(t, "Please rename this", note.Body)
+	assert.Equal(t, "alice", note.Author.Username)
+}
+
 func TestDeleteMergeRequestNote_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/notes/123", r.URL.Path)
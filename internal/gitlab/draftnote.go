@@ -0,0 +1,124 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// CreateDraftNoteOptions は下書きコメント作成のオプション
+type CreateDraftNoteOptions struct {
+	Note                  string
+	InReplyToDiscussionID string
+	ResolveDiscussion     *bool
+	// Position は行コメントにする場合の差分内の位置（nil の場合は一般コメント）
+	Position *DiffPositionOptions
+}
+
+// UpdateDraftNoteOptions は下書きコメント更新のオプション
+type UpdateDraftNoteOptions struct {
+	Note     *string
+	Position *DiffPositionOptions
+}
+
+// ListDraftNotes は MR の下書きコメント一覧を取得する
+func (c *Client) ListDraftNotes(projectID string, mrIID int, pagination *PaginationOptions) ([]*gogitlab.DraftNote, error) {
+	opts := &gogitlab.ListDraftNotesOptions{ListOptions: listOptions(pagination)}
+
+	notes, resp, err := c.client.DraftNotes.ListDraftNotes(projectID, int64(mrIID), opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return notes, nil
+}
+
+// ListAllDraftNotes は MR の下書きコメントを全ページ分取得する
+func (c *Client) ListAllDraftNotes(projectID string, mrIID int) ([]*gogitlab.DraftNote, error) {
+	opts := &gogitlab.ListDraftNotesOptions{ListOptions: gogitlab.ListOptions{Page: 1, PerPage: 100}}
+
+	var all []*gogitlab.DraftNote
+	for {
+		notes, resp, err := c.client.DraftNotes.ListDraftNotes(projectID, int64(mrIID), opts, c.requestOptions()...)
+		if err != nil {
+			return nil, FromGitLabResponse(err, resp)
+		}
+		all = append(all, notes...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetDraftNote は MR の下書きコメントを取得する
+func (c *Client) GetDraftNote(projectID string, mrIID, draftNoteID int) (*gogitlab.DraftNote, error) {
+	note, resp, err := c.client.DraftNotes.GetDraftNote(projectID, int64(mrIID), int64(draftNoteID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return note, nil
+}
+
+// CreateDraftNote は MR に下書きコメントを作成する
+func (c *Client) CreateDraftNote(projectID string, mrIID int, opts *CreateDraftNoteOptions) (*gogitlab.DraftNote, error) {
+	createOpts := &gogitlab.CreateDraftNoteOptions{
+		Note:              &opts.Note,
+		ResolveDiscussion: opts.ResolveDiscussion,
+		Position:          toPositionOptions(opts.Position),
+	}
+	if opts.InReplyToDiscussionID != "" {
+		createOpts.InReplyToDiscussionID = &opts.InReplyToDiscussionID
+	}
+
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
+	}
+
+	note, resp, err := c.client.DraftNotes.CreateDraftNote(projectID, int64(mrIID), createOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return note, nil
+}
+
+// UpdateDraftNote は下書きコメントを更新する
+func (c *Client) UpdateDraftNote(projectID string, mrIID, draftNoteID int, opts *UpdateDraftNoteOptions) (*gogitlab.DraftNote, error) {
+	updateOpts := &gogitlab.UpdateDraftNoteOptions{
+		Note:     opts.Note,
+		Position: toPositionOptions(opts.Position),
+	}
+
+	if err := c.requireDraftNote(projectID, mrIID, draftNoteID); err != nil {
+		return nil, err
+	}
+
+	note, resp, err := c.client.DraftNotes.UpdateDraftNote(projectID, int64(mrIID), int64(draftNoteID), updateOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return note, nil
+}
+
+// DeleteDraftNote は下書きコメントを削除する
+func (c *Client) DeleteDraftNote(projectID string, mrIID, draftNoteID int) error {
+	if err := c.requireDraftNote(projectID, mrIID, draftNoteID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.DraftNotes.DeleteDraftNote(projectID, int64(mrIID), int64(draftNoteID), c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+// PublishAllDraftNotes は自分の下書きコメントをすべて公開する
+func (c *Client) PublishAllDraftNotes(projectID string, mrIID int) error {
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.DraftNotes.PublishAllDraftNotes(projectID, int64(mrIID), c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
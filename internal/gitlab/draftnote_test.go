@@ -0,0 +1,118 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDraftNote_WithPosition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/draft_notes", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "Consider renaming", body["note"])
+		position := body["position"].(map[string]any)
+		assert.Equal(t, "main.go", position["new_path"])
+		assert.Equal(t, float64(12), position["new_line"])
+		assert.Equal(t, "head", position["head_sha"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":   7,
+			"note": "Consider renaming",
+			"position": map[string]any{
+				"new_path": "main.go",
+				"old_path": "main.go",
+				"new_line": 12,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	note, err := client.CreateDraftNote("test-project", 1, &CreateDraftNoteOptions{
+		Note: "Consider renaming",
+		Position: &DiffPositionOptions{
+			FilePath: "main.go",
+			NewLine:  intPtr(12),
+			BaseSHA:  "base",
+			StartSHA: "start",
+			HeadSHA:  "head",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), note.ID)
+	assert.Equal(t, int64(12), note.Position.NewLine)
+}
+
+func TestCreateDraftNote_Reply(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "disc1", body["in_reply_to_discussion_id"])
+		assert.Equal(t, true, body["resolve_discussion"])
+		assert.NotContains(t, body, "position")
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 8, "note": "Done", "discussion_id": "disc1", "resolve_discussion": true})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	resolve := true
+	note, err := client.CreateDraftNote("test-project", 1, &CreateDraftNoteOptions{
+		Note:                  "Done",
+		InReplyToDiscussionID: "disc1",
+		ResolveDiscussion:     &resolve,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "disc1", note.DiscussionID)
+}
+
+func TestPublishAllDraftNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/draft_notes/bulk_publish", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	err = client.PublishAllDraftNotes("test-project", 1)
+
+	require.NoError(t, err)
+}
+
+func TestDeleteDraftNote_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/draft_notes/99", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "404 Not found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	err = client.DeleteDraftNote("test-project", 1, 99)
+
+	mcpErr, ok := err.(*MCPError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeNotFound, mcpErr.Code)
+}
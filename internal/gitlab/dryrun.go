@@ -0,0 +1,336 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// DryRunRequest はドライランで送信しなかったリクエスト
+type DryRunRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// DryRunPlan はドライランのツール呼び出しで送信するはずだった書き込みのリクエストを記録する
+type DryRunPlan struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+// NewDryRunPlan は空の DryRunPlan を作成する
+func NewDryRunPlan() *DryRunPlan {
+	return &DryRunPlan{}
+}
+
+// Requests は記録したリクエストを送信するはずだった順に返す
+func (p *DryRunPlan) Requests() []DryRunRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.requests)
+}
+
+func (p *DryRunPlan) add(request DryRunRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, request)
+}
+
+func (p *DryRunPlan) empty() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.requests) == 0
+}
+
+// dryRunPlanKey はドライランの DryRunPlan を持つコンテキストのキー
+type dryRunPlanKey struct{}
+
+// ContextWithDryRunPlan は書き込みを plan に記録するドライランのコンテキストを返す
+func ContextWithDryRunPlan(ctx context.Context, plan *DryRunPlan) context.Context {
+	return context.WithValue(ctx, dryRunPlanKey{}, plan)
+}
+
+// WithDryRun は ctx がドライランの場合に、書き込みを送信せずに ctx の DryRunPlan に記録するクライアントを返す
+// ドライランでなければこのクライアントを返す
+// 記録した書き込みには空の成功レスポンスを返すため、ハンドラーは後続の書き込みまで続けて実行できる
+func (c *Client) WithDryRun(ctx context.Context) *Client {
+	plan, _ := ctx.Value(dryRunPlanKey{}).(*DryRunPlan)
+	if plan == nil || plan == c.plan {
+		return c
+	}
+	return &Client{client: c.client, plan: plan}
+}
+
+// IsDryRun はドライランのクライアントかどうかを返す
+// 書き込みの結果を GitLab から読み直すハンドラーは、ドライランでは書き込みが行われていないため読み直さない
+func (c *Client) IsDryRun() bool {
+	return c.plan != nil
+}
+
+// requestOptions はすべてのリクエストに付けるオプションを返す
+// ドライランのクライアントでは、dryRunInterceptor が書き込みを記録できるよう DryRunPlan をリクエストのコンテキストに設定する
+func (c *Client) requestOptions() []gogitlab.RequestOptionFunc {
+	if c.plan == nil {
+		return nil
+	}
+	plan := c.plan
+	return []gogitlab.RequestOptionFunc{func(req *retryablehttp.Request) error {
+		*req = *req.WithContext(ContextWithDryRunPlan(req.Context(), plan))
+		return nil
+	}}
+}
+
+// readOnlyRequestKey は POST でも GitLab を変更しないリクエストに付けるコンテキストのキー
+type readOnlyRequestKey struct{}
+
+// readOnlyRequest は POST でも GitLab を変更しないリクエスト（CI 設定の検証など）をドライランでも送信する
+// 呼び出し元がリクエストに設定したコンテキストはそのまま引き継ぐ
+func readOnlyRequest() gogitlab.RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), readOnlyRequestKey{}, true))
+		return nil
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// dryRunInterceptor はリクエストのコンテキストに DryRunPlan がある場合に、読み取りのリクエストだけを送信し、
+// 書き込みのリクエストは送信せずに記録して空の成功レスポンスを返す
+func dryRunInterceptor(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		plan, _ := req.Context().Value(dryRunPlanKey{}).(*DryRunPlan)
+		readOnly, _ := req.Context().Value(readOnlyRequestKey{}).(bool)
+		if plan == nil || readOnly || req.Method == http.MethodGet || req.Method == http.MethodHead {
+			return next.RoundTrip(req)
+		}
+
+		planned := DryRunRequest{Method: req.Method, URL: req.URL.String()}
+		if req.Body != nil {
+			body, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			planned.Body = dryRunBody(body)
+		}
+		plan.add(planned)
+		return simulatedResponse(req), nil
+	})
+}
+
+// simulatedResponse は記録した書き込みに GitLab の代わりに返す空の成功レスポンス
+// 削除でもボディを読む API があり、Issue などは id を必須とするため、常に ID だけのオブジェクトを返す
+func simulatedResponse(req *http.Request) *http.Response {
+	body := simulatedBody(req.URL.Path)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// simulatedBody は模擬したレスポンスのボディを返す
+// ディスカッションの ID は文字列のため、ディスカッション自体を返す API には文字列の ID を返す
+func simulatedBody(path string) string {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	n := len(segments)
+	if segments[n-1] == "discussions" || (n > 1 && segments[n-2] == "discussions") {
+		return `{"id":""}`
+	}
+	return `{"id":0}`
+}
+
+// dryRunBody はリクエストのボディを JSON として返す。JSON でない場合は文字列として返す
+func dryRunBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return body
+	}
+	data, _ := json.Marshal(string(body))
+	return data
+}
+
+// checkTargets はドライランで書き込みの対象が存在することを確認するかどうかを返す
+// 先に記録した書き込みで作成されるはずの対象は GitLab に存在しないため、最初の書き込みを記録するまでだけ確認する
+func (c *Client) checkTargets() bool {
+	return c.plan != nil && c.plan.empty()
+}
+
+// 以下はドライランの場合に書き込みの対象が存在することを確認する
+// ドライランでなければ書き込みのリクエスト自体が対象の存在を確認するため何もしない（checkTargets を参照）
+
+func (c *Client) requireProject(projectID string) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, resp, err := c.client.Projects.GetProject(projectID, nil, c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+func (c *Client) requireGroup(groupID string) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	withProjects := false
+	_, resp, err := c.client.Groups.GetGroup(groupID, &gogitlab.GetGroupOptions{WithProjects: &withProjects}, c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+func (c *Client) requireMergeRequest(projectID string, mrIID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetMergeRequest(projectID, mrIID)
+	return err
+}
+
+func (c *Client) requireMergeRequestNote(projectID string, mrIID, noteID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetMergeRequestNote(projectID, mrIID, noteID)
+	return err
+}
+
+func (c *Client) requireMergeRequestDiscussion(projectID string, mrIID int, discussionID string) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, resp, err := c.client.Discussions.GetMergeRequestDiscussion(projectID, int64(mrIID), discussionID, c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+func (c *Client) requireDraftNote(projectID string, mrIID, draftNoteID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetDraftNote(projectID, mrIID, draftNoteID)
+	return err
+}
+
+func (c *Client) requireMergeRequestApprovalRule(projectID string, mrIID, ruleID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetMergeRequestApprovalRule(projectID, mrIID, ruleID)
+	return err
+}
+
+func (c *Client) requireProjectApprovalRule(projectID string, ruleID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetProjectApprovalRule(projectID, ruleID)
+	return err
+}
+
+func (c *Client) requireIssue(projectID string, issueIID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetIssue(projectID, issueIID)
+	return err
+}
+
+func (c *Client) requireIssueNote(projectID string, issueIID, noteID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetIssueNote(projectID, issueIID, noteID)
+	return err
+}
+
+func (c *Client) requireIssueDiscussion(projectID string, issueIID int, discussionID string) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, resp, err := c.client.Discussions.GetIssueDiscussion(projectID, int64(issueIID), discussionID, c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+func (c *Client) requirePipeline(projectID string, pipelineID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetPipeline(projectID, pipelineID)
+	return err
+}
+
+func (c *Client) requireJob(projectID string, jobID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetJob(projectID, jobID)
+	return err
+}
+
+func (c *Client) requirePipelineSchedule(projectID string, scheduleID int) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetPipelineSchedule(projectID, scheduleID)
+	return err
+}
+
+// requirePipelineScheduleVariable はスケジュールの変数を個別に取得する API がないためスケジュールの詳細から探す
+func (c *Client) requirePipelineScheduleVariable(projectID string, scheduleID int, key string) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	schedule, err := c.GetPipelineSchedule(projectID, scheduleID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(schedule.Variables, func(v *gogitlab.PipelineVariable) bool { return v.Key == key }) {
+		return &MCPError{Code: ErrCodeNotFound, Message: Msg(MsgNotFound)}
+	}
+	return nil
+}
+
+func (c *Client) requireProjectVariable(projectID, key, environmentScope string) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetProjectVariable(projectID, key, environmentScope)
+	return err
+}
+
+func (c *Client) requireGroupVariable(groupID, key, environmentScope string) error {
+	if !c.checkTargets() {
+		return nil
+	}
+	_, err := c.GetGroupVariable(groupID, key, environmentScope)
+	return err
+}
@@ -0,0 +1,203 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestWithDryRun(t *testing.T) {
+	client, err := NewClient("https://gitlab.example.com", "test-token")
+	require.NoError(t, err)
+
+	ctx := ContextWithDryRunPlan(context.Background(), NewDryRunPlan())
+	dryRun := client.WithDryRun(ctx)
+
+	assert.Same(t, client, client.WithDryRun(context.Background()))
+	assert.False(t, client.IsDryRun())
+	assert.True(t, dryRun.IsDryRun())
+	assert.Same(t, dryRun, dryRun.WithDryRun(ctx))
+}
+
+func TestDryRun_RecordsWritesWithoutSending(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 1, "path_with_namespace": "group/test-project"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	plan := NewDryRunPlan()
+	dryRun := client.WithDryRun(ContextWithDryRunPlan(context.Background(), plan))
+
+	issue, err := dryRun.CreateIssue("group/test-project", &CreateIssueOptions{Title: "Broken build", Labels: []string{"bug"}})
+	require.NoError(t, err)
+	assert.NotNil(t, issue)
+
+	_, err = dryRun.CreateIssueNote("group/test-project", 0, "Reproduced on main")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"GET /api/v4/projects/group/test-project"}, methods, "only the first write checks its target")
+
+	requests := plan.Requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "POST", requests[0].Method)
+	assert.Equal(t, server.URL+"/api/v4/projects/group%2Ftest-project/issues", requests[0].URL)
+	assert.JSONEq(t, `{"title": "Broken build", "labels": "bug"}`, string(requests[0].Body))
+	assert.Equal(t, "POST", requests[1].Method)
+	assert.Equal(t, server.URL+"/api/v4/projects/group%2Ftest-project/issues/0/notes", requests[1].URL)
+	assert.JSONEq(t, `{"body": "Reproduced on main"}`, string(requests[1].Body))
+}
+
+func TestDryRun_DeleteHasNoBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 10, "iid": 3, "title": "Stale"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	plan := NewDryRunPlan()
+	err = client.WithDryRun(ContextWithDryRunPlan(context.Background(), plan)).DeleteIssue("test-project", 3)
+	require.NoError(t, err)
+
+	requests := plan.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "DELETE", requests[0].Method)
+	assert.Equal(t, server.URL+"/api/v4/projects/test-project/issues/3", requests[0].URL)
+	assert.Empty(t, requests[0].Body)
+}
+
+func TestDryRun_TargetNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "404 Pipeline Not Found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	plan := NewDryRunPlan()
+	pipeline, err := client.WithDryRun(ContextWithDryRunPlan(context.Background(), plan)).CancelPipeline("test-project", 99)
+
+	assert.Nil(t, pipeline)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, plan.Requests())
+}
+
+func TestDryRun_PipelineScheduleVariableNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":        5,
+			"variables": []map[string]any{{"key": "DEPLOY_ENV", "value": "staging"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	ctx := ContextWithDryRunPlan(context.Background(), NewDryRunPlan())
+	err = client.WithDryRun(ctx).DeletePipelineScheduleVariable("test-project", 5, "MISSING")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = client.WithDryRun(ctx).DeletePipelineScheduleVariable("test-project", 5, "DEPLOY_ENV")
+	assert.NoError(t, err)
+}
+
+func TestDryRun_SendsReadOnlyPost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"valid": true})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	plan := NewDryRunPlan()
+	content := "build:\n  script: make\n"
+	result, err := client.WithDryRun(ContextWithDryRunPlan(context.Background(), plan)).LintCIConfig("test-project", &LintCIConfigOptions{Content: &content})
+
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Empty(t, plan.Requests())
+}
+
+func TestReadOnlyRequest_KeepsContext(t *testing.T) {
+	client, err := NewClient("https://gitlab.example.com", "test-token")
+	require.NoError(t, err)
+
+	type callerKey struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), callerKey{}, "caller"))
+	defer cancel()
+
+	req, err := client.client.NewRequest(http.MethodPost, "projects/1/ci/lint", nil,
+		[]gogitlab.RequestOptionFunc{gogitlab.WithContext(ctx), readOnlyRequest()})
+	require.NoError(t, err)
+
+	assert.Equal(t, "caller", req.Context().Value(callerKey{}))
+	assert.Equal(t, true, req.Context().Value(readOnlyRequestKey{}))
+
+	cancel()
+	assert.ErrorIs(t, req.Context().Err(), context.Canceled)
+}
+
+func TestSimulatedBody(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/v4/projects/1/issues", want: `{"id":0}`},
+		{path: "/api/v4/projects/1/merge_requests/42/discussions", want: `{"id":""}`},
+		{path: "/api/v4/projects/1/merge_requests/42/discussions/6a9c1750", want: `{"id":""}`},
+		{path: "/api/v4/projects/1/merge_requests/42/discussions/6a9c1750/notes", want: `{"id":0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, simulatedBody(tt.path))
+		})
+	}
+}
+
+func TestDryRun_SharedClientStillSendsWrites(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 1, "iid": 3})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	plan := NewDryRunPlan()
+	require.NoError(t, client.WithDryRun(ContextWithDryRunPlan(context.Background(), plan)).DeleteIssue("test-project", 3))
+	require.NoError(t, client.DeleteIssue("test-project", 3))
+
+	assert.Len(t, plan.Requests(), 1)
+	assert.Equal(t, []string{
+		"GET /api/v4/projects/test-project/issues/3",
+		"DELETE /api/v4/projects/test-project/issues/3",
+	}, methods)
+}
@@ -1,8 +1,16 @@
 package gitlab
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	gogitlab "gitlab.com/gitlab-org/api/client-go"
 )
@@ -18,12 +26,67 @@ const (
 	ErrCodeBadRequest   ErrorCode = "bad_request"
 	ErrCodeServerError  ErrorCode = "server_error"
 	ErrCodeToolDisabled ErrorCode = "tool_disabled"
+
+	ErrCodeConflict         ErrorCode = "conflict"
+	ErrCodeMethodNotAllowed ErrorCode = "method_not_allowed"
+	ErrCodeNotMergeable     ErrorCode = "not_mergeable"
+	ErrCodeUnprocessable    ErrorCode = "unprocessable"
+	ErrCodeTimeout          ErrorCode = "timeout"
+	ErrCodeNetworkError     ErrorCode = "network_error"
+	ErrCodeCancelled        ErrorCode = "cancelled"
+
+	ErrCodeConfirmationRequired ErrorCode = "confirmation_required"
+	ErrCodeDeclined             ErrorCode = "declined"
+)
+
+// errors.Is でエラーコードを判定するためのセンチネル
+// 例: errors.Is(err, gitlab.ErrNotFound)
+var (
+	ErrUnauthorized     = &MCPError{Code: ErrCodeUnauthorized}
+	ErrForbidden        = &MCPError{Code: ErrCodeForbidden}
+	ErrNotFound         = &MCPError{Code: ErrCodeNotFound}
+	ErrRateLimited      = &MCPError{Code: ErrCodeRateLimited}
+	ErrBadRequest       = &MCPError{Code: ErrCodeBadRequest}
+	ErrServerError      = &MCPError{Code: ErrCodeServerError}
+	ErrToolDisabled     = &MCPError{Code: ErrCodeToolDisabled}
+	ErrConflict         = &MCPError{Code: ErrCodeConflict}
+	ErrMethodNotAllowed = &MCPError{Code: ErrCodeMethodNotAllowed}
+	ErrNotMergeable     = &MCPError{Code: ErrCodeNotMergeable}
+	ErrUnprocessable    = &MCPError{Code: ErrCodeUnprocessable}
+	ErrTimeout          = &MCPError{Code: ErrCodeTimeout}
+	ErrNetworkError     = &MCPError{Code: ErrCodeNetworkError}
+	ErrCancelled        = &MCPError{Code: ErrCodeCancelled}
+
+	ErrConfirmationRequired = &MCPError{Code: ErrCodeConfirmationRequired}
+	ErrDeclined             = &MCPError{Code: ErrCodeDeclined}
 )
 
 // MCPError は MCP 互換エラー
+// GitLab API のエラーの場合は HTTP ステータスや GitLab 自身のエラーメッセージなどの詳細も保持する
 type MCPError struct {
 	Code    ErrorCode
 	Message string
+
+	HTTPStatus    int
+	GitLabMessage string
+	FieldErrors   map[string][]string
+	RetryAfter    int
+	RequestID     string
+
+	// Err は元のエラー（errors.Unwrap で取り出せる）
+	Err error
+}
+
+// ErrorDetail はツールの結果としてクライアントに返すエラーの詳細
+type ErrorDetail struct {
+	Code          ErrorCode           `json:"code"`
+	Message       string              `json:"message"`
+	HTTPStatus    int                 `json:"http_status,omitempty"`
+	GitLabMessage string              `json:"gitlab_message,omitempty"`
+	FieldErrors   map[string][]string `json:"field_errors,omitempty"`
+	Retryable     bool                `json:"retryable"`
+	RetryAfter    int                 `json:"retry_after,omitempty"`
+	RequestID     string              `json:"request_id,omitempty"`
 }
 
 // Error implements the error interface
@@ -31,57 +94,212 @@ func (e *MCPError) Error() string {
 	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
 }
 
+// Unwrap は元のエラーを返す
+func (e *MCPError) Unwrap() error {
+	return e.Err
+}
+
+// Is は target が同じエラーコードの MCPError の場合に true を返す
+func (e *MCPError) Is(target error) bool {
+	t, ok := target.(*MCPError)
+	return ok && t.Code == e.Code
+}
+
 // IsRetryable はリトライ可能なエラーかどうかを返す
 func (e *MCPError) IsRetryable() bool {
-	return e.Code == ErrCodeRateLimited || e.Code == ErrCodeServerError
+	switch e.Code {
+	case ErrCodeRateLimited, ErrCodeServerError, ErrCodeTimeout, ErrCodeNetworkError:
+		return true
+	default:
+		return false
+	}
+}
+
+// Detail はエラーの詳細を返す
+func (e *MCPError) Detail() ErrorDetail {
+	return ErrorDetail{
+		Code:          e.Code,
+		Message:       e.Message,
+		HTTPStatus:    e.HTTPStatus,
+		GitLabMessage: e.GitLabMessage,
+		FieldErrors:   e.FieldErrors,
+		Retryable:     e.IsRetryable(),
+		RetryAfter:    e.RetryAfter,
+		RequestID:     e.RequestID,
+	}
 }
 
 // FromGitLabResponse は GitLab SDK レスポンスから MCPError を作成する
+// Message は現在の言語の概要で、GitLab 自身のエラーメッセージとフィールドごとのエラーは GitLabMessage・FieldErrors に保持する
 func FromGitLabResponse(err error, resp *gogitlab.Response) *MCPError {
-	if resp == nil {
-		return &MCPError{
-			Code:    ErrCodeServerError,
-			Message: fmt.Sprintf("GitLab API エラー: %v", err),
+	if resp == nil || resp.Response == nil {
+		return FromError(err)
+	}
+
+	mcpErr := &MCPError{
+		Err:        err,
+		HTTPStatus: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		RetryAfter: retryAfterSeconds(resp.Header),
+	}
+
+	var errResp *gogitlab.ErrorResponse
+	if errors.As(err, &errResp) {
+		mcpErr.GitLabMessage, mcpErr.FieldErrors = parseErrorBody(errResp.Body)
+		if mcpErr.GitLabMessage == "" {
+			mcpErr.GitLabMessage = errResp.Message
 		}
+	} else if err != nil {
+		// 404 などはボディを含まないエラー（gogitlab.ErrNotFound など）として返される
+		mcpErr.GitLabMessage = err.Error()
+	}
+
+	detail := mcpErr.GitLabMessage
+	if detail == "" && err != nil {
+		detail = err.Error()
 	}
 
 	switch resp.StatusCode {
 	case http.StatusUnauthorized:
-		return &MCPError{
-			Code:    ErrCodeUnauthorized,
-			Message: "認証トークンが無効または期限切れです",
-		}
+		mcpErr.Code, mcpErr.Message = ErrCodeUnauthorized, Msg(MsgUnauthorized)
 	case http.StatusForbidden:
-		return &MCPError{
-			Code:    ErrCodeForbidden,
-			Message: "この操作を実行する権限がありません",
-		}
+		mcpErr.Code, mcpErr.Message = ErrCodeForbidden, Msg(MsgForbidden)
 	case http.StatusNotFound:
-		return &MCPError{
-			Code:    ErrCodeNotFound,
-			Message: "指定されたリソースが見つかりません",
-		}
+		mcpErr.Code, mcpErr.Message = ErrCodeNotFound, Msg(MsgNotFound)
 	case http.StatusTooManyRequests:
-		return &MCPError{
-			Code:    ErrCodeRateLimited,
-			Message: "API レート制限に達しました。しばらく待ってから再試行してください",
-		}
+		mcpErr.Code, mcpErr.Message = ErrCodeRateLimited, Msg(MsgRateLimited)
 	case http.StatusBadRequest:
-		return &MCPError{
-			Code:    ErrCodeBadRequest,
-			Message: fmt.Sprintf("リクエストが無効です: %v", err),
-		}
+		mcpErr.Code, mcpErr.Message = ErrCodeBadRequest, Msg(MsgBadRequest, detail)
+	case http.StatusConflict:
+		mcpErr.Code, mcpErr.Message = ErrCodeConflict, Msg(MsgConflict, detail)
+	case http.StatusMethodNotAllowed:
+		mcpErr.Code, mcpErr.Message = ErrCodeMethodNotAllowed, Msg(MsgMethodNotAllowed, detail)
+	case http.StatusUnprocessableEntity:
+		mcpErr.Code, mcpErr.Message = ErrCodeUnprocessable, Msg(MsgUnprocessable, detail)
 	default:
 		if resp.StatusCode >= 500 {
-			return &MCPError{
-				Code:    ErrCodeServerError,
-				Message: "GitLab サーバーでエラーが発生しました。しばらく待ってから再試行してください",
-			}
+			mcpErr.Code, mcpErr.Message = ErrCodeServerError, Msg(MsgServerError)
+		} else {
+			mcpErr.Code, mcpErr.Message = ErrCodeServerError, Msg(MsgUnexpectedError, detail)
 		}
-		return &MCPError{
-			Code:    ErrCodeServerError,
-			Message: fmt.Sprintf("予期しないエラーが発生しました: %v", err),
+	}
+	return mcpErr
+}
+
+// FromError は GitLab の HTTP レスポンスを伴わないエラーから MCPError を作成する
+// MCPError の場合はそのまま返し、キャンセル・タイムアウト・ネットワークエラーはそれぞれのコードに分類する
+func FromError(err error) *MCPError {
+	var mcpErr *MCPError
+	if errors.As(err, &mcpErr) {
+		return mcpErr
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return &MCPError{Code: ErrCodeCancelled, Message: Msg(MsgCancelled), Err: err}
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return &MCPError{Code: ErrCodeTimeout, Message: Msg(MsgTimeout), Err: err}
+	case errors.As(err, &dnsErr), errors.As(err, &opErr), errors.As(err, &urlErr):
+		return &MCPError{Code: ErrCodeNetworkError, Message: Msg(MsgNetworkError, err), Err: err}
+	default:
+		return &MCPError{Code: ErrCodeServerError, Message: Msg(MsgAPIError, err), Err: err}
+	}
+}
+
+// notMergeable は MR のマージ・リベースの API が返したエラーのうち、MR がマージ可能な状態でないことを示すものを not_mergeable に変換する
+// GitLab はドラフトやパイプライン未完了などの場合に 405、コンフリクトがある場合に 406 を返す
+func notMergeable(err *MCPError) *MCPError {
+	if err.HTTPStatus == http.StatusMethodNotAllowed || err.HTTPStatus == http.StatusNotAcceptable {
+		err.Code = ErrCodeNotMergeable
+		err.Message = Msg(MsgNotMergeable, err.GitLabMessage)
+	}
+	return err
+}
+
+// retryAfterSeconds は Retry-After ヘッダー（秒数）を返す。ヘッダーがない場合は 0 を返す
+func retryAfterSeconds(header http.Header) int {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header.Get("Retry-After")))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return seconds
+}
+
+// parseErrorBody は GitLab のエラーレスポンスのボディからメッセージとフィールドごとのエラーを取り出す
+// GitLab は {"message": "..."}、{"message": {"field": ["..."]}}、{"error": "..."} などの形式でエラーを返す
+func parseErrorBody(body []byte) (string, map[string][]string) {
+	var parsed struct {
+		Message          any    `json:"message"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &parsed) != nil {
+		return "", nil
+	}
+
+	switch msg := parsed.Message.(type) {
+	case string:
+		return msg, nil
+	case []any:
+		return strings.Join(errorStrings(msg), "; "), nil
+	case map[string]any:
+		fields := make(map[string][]string, len(msg))
+		keys := make([]string, 0, len(msg))
+		for k, v := range msg {
+			fields[k] = errorStrings(v)
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s %s", k, strings.Join(fields[k], ", ")))
 		}
+		return strings.Join(parts, "; "), fields
+	}
+
+	if parsed.ErrorDescription != "" {
+		return parsed.ErrorDescription, nil
+	}
+	return parsed.Error, nil
+}
+
+// errorStrings はエラーメッセージの値を文字列のリストに変換する
+func errorStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var out []string
+		for _, item := range v {
+			out = append(out, errorStrings(item)...)
+		}
+		return out
+	case nil:
+		return nil
+	default:
+		data, _ := json.Marshal(v)
+		return []string{string(data)}
+	}
+}
+
+// NewConfirmationRequiredError は確認が必要な操作が confirm なしで呼び出されたことを表すエラーを作成する
+// summary は実行しようとした操作の要約
+func NewConfirmationRequiredError(summary string) *MCPError {
+	return &MCPError{
+		Code:    ErrCodeConfirmationRequired,
+		Message: Msg(MsgConfirmationRequired, summary),
+	}
+}
+
+// NewDeclinedError はユーザーが操作の実行を断ったことを表すエラーを作成する
+func NewDeclinedError(summary string) *MCPError {
+	return &MCPError{
+		Code:    ErrCodeDeclined,
+		Message: Msg(MsgDeclined, summary),
 	}
 }
 
@@ -89,6 +307,6 @@ func FromGitLabResponse(err error, resp *gogitlab.Response) *MCPError {
 func NewToolDisabledError(toolName string) *MCPError {
 	return &MCPError{
 		Code:    ErrCodeToolDisabled,
-		Message: fmt.Sprintf("ツール '%s' は無効化されています", toolName),
+		Message: Msg(MsgToolDisabled, toolName),
 	}
 }
@@ -1,11 +1,16 @@
 package gitlab
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	gogitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
@@ -18,6 +23,15 @@ func TestErrorCode_Constants(t *testing.T) {
 	assert.Equal(t, ErrorCode("bad_request"), ErrCodeBadRequest)
 	assert.Equal(t, ErrorCode("server_error"), ErrCodeServerError)
 	assert.Equal(t, ErrorCode("tool_disabled"), ErrCodeToolDisabled)
+	assert.Equal(t, ErrorCode("conflict"), ErrCodeConflict)
+	assert.Equal(t, ErrorCode("method_not_allowed"), ErrCodeMethodNotAllowed)
+	assert.Equal(t, ErrorCode("not_mergeable"), ErrCodeNotMergeable)
+	assert.Equal(t, ErrorCode("unprocessable"), ErrCodeUnprocessable)
+	assert.Equal(t, ErrorCode("timeout"), ErrCodeTimeout)
+	assert.Equal(t, ErrorCode("network_error"), ErrCodeNetworkError)
+	assert.Equal(t, ErrorCode("cancelled"), ErrCodeCancelled)
+	assert.Equal(t, ErrorCode("confirmation_required"), ErrCodeConfirmationRequired)
+	assert.Equal(t, ErrorCode("declined"), ErrCodeDeclined)
 }
 
 func TestMCPError_Error(t *testing.T) {
@@ -41,6 +55,12 @@ func TestMCPError_IsRetryable(t *testing.T) {
 		{ErrCodeNotFound, false},
 		{ErrCodeBadRequest, false},
 		{ErrCodeToolDisabled, false},
+		{ErrCodeTimeout, true},
+		{ErrCodeNetworkError, true},
+		{ErrCodeConflict, false},
+		{ErrCodeNotMergeable, false},
+		{ErrCodeUnprocessable, false},
+		{ErrCodeCancelled, false},
 	}
 
 	for _, tt := range tests {
@@ -59,7 +79,7 @@ func TestFromGitLabResponse_401(t *testing.T) {
 	mcpErr := FromGitLabResponse(errors.New("unauthorized"), resp)
 
 	assert.Equal(t, ErrCodeUnauthorized, mcpErr.Code)
-	assert.Contains(t, mcpErr.Message, "認証")
+	assert.Contains(t, mcpErr.Message, "token")
 }
 
 func TestFromGitLabResponse_403(t *testing.T) {
@@ -70,7 +90,7 @@ func TestFromGitLabResponse_403(t *testing.T) {
 	mcpErr := FromGitLabResponse(errors.New("forbidden"), resp)
 
 	assert.Equal(t, ErrCodeForbidden, mcpErr.Code)
-	assert.Contains(t, mcpErr.Message, "権限")
+	assert.Contains(t, mcpErr.Message, "permission")
 }
 
 func TestFromGitLabResponse_404(t *testing.T) {
@@ -81,7 +101,7 @@ func TestFromGitLabResponse_404(t *testing.T) {
 	mcpErr := FromGitLabResponse(errors.New("not found"), resp)
 
 	assert.Equal(t, ErrCodeNotFound, mcpErr.Code)
-	assert.Contains(t, mcpErr.Message, "見つかりません")
+	assert.Contains(t, mcpErr.Message, "not found")
 }
 
 func TestFromGitLabResponse_429(t *testing.T) {
@@ -92,7 +112,7 @@ func TestFromGitLabResponse_429(t *testing.T) {
 	mcpErr := FromGitLabResponse(errors.New("rate limited"), resp)
 
 	assert.Equal(t, ErrCodeRateLimited, mcpErr.Code)
-	assert.Contains(t, mcpErr.Message, "レート制限")
+	assert.Contains(t, mcpErr.Message, "rate limit")
 	assert.True(t, mcpErr.IsRetryable())
 }
 
@@ -104,7 +124,7 @@ func TestFromGitLabResponse_500(t *testing.T) {
 	mcpErr := FromGitLabResponse(errors.New("server error"), resp)
 
 	assert.Equal(t, ErrCodeServerError, mcpErr.Code)
-	assert.Contains(t, mcpErr.Message, "サーバー")
+	assert.Contains(t, mcpErr.Message, "server")
 	assert.True(t, mcpErr.IsRetryable())
 }
 
@@ -115,10 +135,206 @@ func TestFromGitLabResponse_NilResponse(t *testing.T) {
 	assert.Contains(t, mcpErr.Message, "network error")
 }
 
+func TestFromGitLabResponse_Details(t *testing.T) {
+	httpResp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Header: http.Header{
+			"X-Request-Id": []string{"req-123"},
+			"Retry-After":  []string{"30"},
+		},
+	}
+	errResp := &gogitlab.ErrorResponse{
+		Body:     []byte(`{"message":{"title":["can't be blank"],"target_branch":["is invalid","does not exist"]}}`),
+		Response: httpResp,
+		Message:  "{target_branch: [is invalid, does not exist]}, {title: [can't be blank]}",
+	}
+
+	mcpErr := FromGitLabResponse(errResp, &gogitlab.Response{Response: httpResp})
+
+	assert.Equal(t, ErrCodeBadRequest, mcpErr.Code)
+	assert.Equal(t, http.StatusBadRequest, mcpErr.HTTPStatus)
+	assert.Equal(t, "req-123", mcpErr.RequestID)
+	assert.Equal(t, 30, mcpErr.RetryAfter)
+	assert.Equal(t, map[string][]string{
+		"title":         {"can't be blank"},
+		"target_branch": {"is invalid", "does not exist"},
+	}, mcpErr.FieldErrors)
+	assert.Equal(t, "target_branch is invalid, does not exist; title can't be blank", mcpErr.GitLabMessage)
+
+	detail := mcpErr.Detail()
+	assert.Equal(t, ErrCodeBadRequest, detail.Code)
+	assert.False(t, detail.Retryable)
+	assert.Equal(t, mcpErr.FieldErrors, detail.FieldErrors)
+}
+
+func TestFromGitLabResponse_PreservesGitLabMessage(t *testing.T) {
+	httpResp := &http.Response{StatusCode: http.StatusForbidden}
+	errResp := &gogitlab.ErrorResponse{
+		Body:     []byte(`{"message":"403 Forbidden - You are not allowed to push into this branch"}`),
+		Response: httpResp,
+	}
+
+	mcpErr := FromGitLabResponse(errResp, &gogitlab.Response{Response: httpResp})
+
+	assert.Equal(t, ErrCodeForbidden, mcpErr.Code)
+	assert.Equal(t, "403 Forbidden - You are not allowed to push into this branch", mcpErr.GitLabMessage)
+}
+
+func TestFromGitLabResponse_Japanese(t *testing.T) {
+	SetLanguage(LangJapanese)
+	defer SetLanguage(LangEnglish)
+
+	resp := &gogitlab.Response{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+	}
+
+	mcpErr := FromGitLabResponse(gogitlab.ErrNotFound, resp)
+
+	assert.Equal(t, ErrCodeNotFound, mcpErr.Code)
+	assert.Equal(t, "指定されたリソースが見つかりません", mcpErr.Message)
+	assert.Equal(t, gogitlab.ErrNotFound.Error(), mcpErr.GitLabMessage)
+}
+
+func TestParseErrorBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		message string
+		fields  map[string][]string
+	}{
+		{name: "string message", body: `{"message":"403 Forbidden"}`, message: "403 Forbidden"},
+		{name: "array message", body: `{"message":["a","b"]}`, message: "a; b"},
+		{name: "error", body: `{"error":"insufficient_scope","error_description":"The request requires higher privileges"}`, message: "The request requires higher privileges"},
+		{name: "not json", body: `<html></html>`},
+		{name: "empty", body: ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, fields := parseErrorBody([]byte(tt.body))
+			assert.Equal(t, tt.message, message)
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestFromGitLabResponse_StatusCodes(t *testing.T) {
+	tests := []struct {
+		status int
+		code   ErrorCode
+	}{
+		{http.StatusConflict, ErrCodeConflict},
+		{http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed},
+		{http.StatusUnprocessableEntity, ErrCodeUnprocessable},
+		{http.StatusNotAcceptable, ErrCodeServerError},
+		{http.StatusBadGateway, ErrCodeServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			httpResp := &http.Response{StatusCode: tt.status}
+			errResp := &gogitlab.ErrorResponse{Body: []byte(`{"message":"details"}`), Response: httpResp}
+
+			mcpErr := FromGitLabResponse(errResp, &gogitlab.Response{Response: httpResp})
+
+			assert.Equal(t, tt.code, mcpErr.Code)
+			assert.Equal(t, tt.status, mcpErr.HTTPStatus)
+			assert.Equal(t, "details", mcpErr.GitLabMessage)
+		})
+	}
+}
+
+func TestFromGitLabResponse_WrapsOriginalError(t *testing.T) {
+	httpResp := &http.Response{StatusCode: http.StatusConflict}
+	errResp := &gogitlab.ErrorResponse{Body: []byte(`{"message":"SHA does not match HEAD of source branch"}`), Response: httpResp}
+
+	var err error = FromGitLabResponse(errResp, &gogitlab.Response{Response: httpResp})
+
+	assert.True(t, errors.Is(err, ErrConflict))
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	var original *gogitlab.ErrorResponse
+	require.True(t, errors.As(err, &original))
+	assert.Same(t, errResp, original)
+
+	var mcpErr *MCPError
+	require.True(t, errors.As(err, &mcpErr))
+	assert.Equal(t, ErrCodeConflict, mcpErr.Code)
+}
+
+func TestFromError(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "gitlab.invalid"}
+
+	tests := []struct {
+		name      string
+		err       error
+		code      ErrorCode
+		retryable bool
+	}{
+		{name: "cancelled", err: context.Canceled, code: ErrCodeCancelled},
+		{name: "deadline", err: fmt.Errorf("get: %w", context.DeadlineExceeded), code: ErrCodeTimeout, retryable: true},
+		{name: "dns", err: &url.Error{Op: "Get", URL: "https://gitlab.invalid", Err: dnsErr}, code: ErrCodeNetworkError, retryable: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, code: ErrCodeNetworkError, retryable: true},
+		{name: "other", err: errors.New("boom"), code: ErrCodeServerError, retryable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcpErr := FromError(tt.err)
+
+			assert.Equal(t, tt.code, mcpErr.Code)
+			assert.Equal(t, tt.retryable, mcpErr.IsRetryable())
+			assert.True(t, errors.Is(mcpErr, tt.err), "original error should be wrapped")
+		})
+	}
+
+	// MCPError はそのまま返す
+	existing := &MCPError{Code: ErrCodeBadRequest, Message: "bad"}
+	assert.Same(t, existing, FromError(fmt.Errorf("wrapped: %w", existing)))
+
+	// HTTP レスポンスを伴わない場合も分類される
+	assert.Equal(t, ErrCodeTimeout, FromGitLabResponse(context.DeadlineExceeded, nil).Code)
+}
+
+func TestNotMergeable(t *testing.T) {
+	for _, status := range []int{http.StatusMethodNotAllowed, http.StatusNotAcceptable} {
+		httpResp := &http.Response{StatusCode: status}
+		errResp := &gogitlab.ErrorResponse{Body: []byte(`{"message":"Branch cannot be merged"}`), Response: httpResp}
+
+		mcpErr := notMergeable(FromGitLabResponse(errResp, &gogitlab.Response{Response: httpResp}))
+
+		assert.Equal(t, ErrCodeNotMergeable, mcpErr.Code)
+		assert.True(t, errors.Is(mcpErr, ErrNotMergeable))
+		assert.Contains(t, mcpErr.Message, "Branch cannot be merged")
+	}
+
+	// 他のステータスは変換しない
+	httpResp := &http.Response{StatusCode: http.StatusConflict}
+	mcpErr := notMergeable(FromGitLabResponse(errors.New("conflict"), &gogitlab.Response{Response: httpResp}))
+	assert.Equal(t, ErrCodeConflict, mcpErr.Code)
+}
+
 func TestNewToolDisabledError(t *testing.T) {
 	err := NewToolDisabledError("merge_merge_request")
 
 	assert.Equal(t, ErrCodeToolDisabled, err.Code)
 	assert.Contains(t, err.Message, "merge_merge_request")
-	assert.Contains(t, err.Message, "無効")
+	assert.Contains(t, err.Message, "disabled")
+}
+
+func TestNewConfirmationRequiredError(t *testing.T) {
+	err := NewConfirmationRequiredError("cancel pipeline #12")
+
+	assert.Equal(t, ErrCodeConfirmationRequired, err.Code)
+	assert.Contains(t, err.Message, "cancel pipeline #12")
+	assert.Contains(t, err.Message, "confirm")
+	assert.False(t, err.IsRetryable())
+}
+
+func TestNewDeclinedError(t *testing.T) {
+	err := NewDeclinedError("cancel pipeline #12")
+
+	assert.Equal(t, ErrCodeDeclined, err.Code)
+	assert.Contains(t, err.Message, "cancel pipeline #12")
+	assert.True(t, errors.Is(err, ErrDeclined))
 }
@@ -54,7 +54,7 @@ func (c *Client) ListProjectIssues(projectID string, opts *ListProjectIssuesOpti
 		}
 	}
 
-	issues, resp, err := c.client.Issues.ListProjectIssues(projectID, listOpts)
+	issues, resp, err := c.client.Issues.ListProjectIssues(projectID, listOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -63,7 +63,7 @@ func (c *Client) ListProjectIssues(projectID string, opts *ListProjectIssuesOpti
 
 // GetIssue はIssueの詳細を取得する
 func (c *Client) GetIssue(projectID string, issueIID int) (*gogitlab.Issue, error) {
-	issue, resp, err := c.client.Issues.GetIssue(projectID, int64(issueIID))
+	issue, resp, err := c.client.Issues.GetIssue(projectID, int64(issueIID), c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -107,7 +107,11 @@ func (c *Client) CreateIssue(projectID string, opts *CreateIssueOptions) (*gogit
 		createOpts.MilestoneID = &milestoneID
 	}
 
-	issue, resp, err := c.client.Issues.CreateIssue(projectID, createOpts)
+	if err := c.requireProject(projectID); err != nil {
+		return nil, err
+	}
+
+	issue, resp, err := c.client.Issues.CreateIssue(projectID, createOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -156,7 +160,11 @@ func (c *Client) UpdateIssue(projectID string, issueIID int, opts *UpdateIssueOp
 		updateOpts.MilestoneID = &milestoneID
 	}
 
-	issue, resp, err := c.client.Issues.UpdateIssue(projectID, int64(issueIID), updateOpts)
+	if err := c.requireIssue(projectID, issueIID); err != nil {
+		return nil, err
+	}
+
+	issue, resp, err := c.client.Issues.UpdateIssue(projectID, int64(issueIID), updateOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -165,7 +173,11 @@ func (c *Client) UpdateIssue(projectID string, issueIID int, opts *UpdateIssueOp
 
 // DeleteIssue はIssueを削除する
 func (c *Client) DeleteIssue(projectID string, issueIID int) error {
-	resp, err := c.client.Issues.DeleteIssue(projectID, int64(issueIID))
+	if err := c.requireIssue(projectID, issueIID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Issues.DeleteIssue(projectID, int64(issueIID), c.requestOptions()...)
 	if err != nil {
 		return FromGitLabResponse(err, resp)
 	}
@@ -190,20 +202,33 @@ func (c *Client) ListIssueNotes(projectID string, issueIID int, pagination *Pagi
 			PerPage: int64(perPage),
 		},
 	}
-	notes, resp, err := c.client.Notes.ListIssueNotes(projectID, int64(issueIID), opts)
+	notes, resp, err := c.client.Notes.ListIssueNotes(projectID, int64(issueIID), opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
 	return notes, nil
 }
 
+// GetIssueNote はIssueのコメントを取得する
+func (c *Client) GetIssueNote(projectID string, issueIID int, noteID int) (*gogitlab.Note, error) {
+	note, resp, err := c.client.Notes.GetIssueNote(projectID, int64(issueIID), int64(noteID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return note, nil
+}
+
 // CreateIssueNote はIssueにコメントを追加する
 func (c *Client) CreateIssueNote(projectID string, issueIID int, body string) (*gogitlab.Note, error) {
 	opts := &gogitlab.CreateIssueNoteOptions{
 		Body: &body,
 	}
 
-	note, resp, err := c.client.Notes.CreateIssueNote(projectID, int64(issueIID), opts)
+	if err := c.requireIssue(projectID, issueIID); err != nil {
+		return nil, err
+	}
+
+	note, resp, err := c.client.Notes.CreateIssueNote(projectID, int64(issueIID), opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -212,7 +237,11 @@ func (c *Client) CreateIssueNote(projectID string, issueIID int, body string) (*
 
 // DeleteIssueNote はIssueのコメントを削除する
 func (c *Client) DeleteIssueNote(projectID string, issueIID int, noteID int) error {
-	resp, err := c.client.Notes.DeleteIssueNote(projectID, int64(issueIID), int64(noteID))
+	if err := c.requireIssueNote(projectID, issueIID, noteID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Notes.DeleteIssueNote(projectID, int64(issueIID), int64(noteID), c.requestOptions()...)
 	if err != nil {
 		return FromGitLabResponse(err, resp)
 	}
@@ -237,7 +266,7 @@ func (c *Client) ListIssueDiscussions(projectID string, issueIID int, pagination
 			PerPage: int64(perPage),
 		},
 	}
-	discussions, resp, err := c.client.Discussions.ListIssueDiscussions(projectID, int64(issueIID), opts)
+	discussions, resp, err := c.client.Discussions.ListIssueDiscussions(projectID, int64(issueIID), opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -250,7 +279,11 @@ func (c *Client) CreateIssueDiscussion(projectID string, issueIID int, body stri
 		Body: &body,
 	}
 
-	discussion, resp, err := c.client.Discussions.CreateIssueDiscussion(projectID, int64(issueIID), opts)
+	if err := c.requireIssue(projectID, issueIID); err != nil {
+		return nil, err
+	}
+
+	discussion, resp, err := c.client.Discussions.CreateIssueDiscussion(projectID, int64(issueIID), opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -263,7 +296,11 @@ func (c *Client) AddIssueDiscussionNote(projectID string, issueIID int, discussi
 		Body: &body,
 	}
 
-	note, resp, err := c.client.Discussions.AddIssueDiscussionNote(projectID, int64(issueIID), discussionID, opts)
+	if err := c.requireIssueDiscussion(projectID, issueIID, discussionID); err != nil {
+		return nil, err
+	}
+
+	note, resp, err := c.client.Discussions.AddIssueDiscussionNote(projectID, int64(issueIID), discussionID, opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
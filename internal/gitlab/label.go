@@ -0,0 +1,40 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// SearchLabels はプロジェクトで使えるラベル（上位グループのラベルを含む）を名前で検索する
+func (c *Client) SearchLabels(projectID, search string, limit int) ([]*gogitlab.Label, error) {
+	opts := &gogitlab.ListLabelsOptions{
+		ListOptions:           gogitlab.ListOptions{PerPage: int64(limit)},
+		IncludeAncestorGroups: gogitlab.Ptr(true),
+	}
+	if search != "" {
+		opts.Search = &search
+	}
+
+	labels, resp, err := c.client.Labels.ListLabels(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return labels, nil
+}
+
+// SearchMilestones はプロジェクトの有効なマイルストーン（上位グループのものを含む）をタイトルで検索する
+func (c *Client) SearchMilestones(projectID, search string, limit int) ([]*gogitlab.Milestone, error) {
+	opts := &gogitlab.ListMilestonesOptions{
+		ListOptions:      gogitlab.ListOptions{PerPage: int64(limit)},
+		State:            gogitlab.Ptr("active"),
+		IncludeAncestors: gogitlab.Ptr(true),
+	}
+	if search != "" {
+		opts.Search = &search
+	}
+
+	milestones, resp, err := c.client.Milestones.ListMilestones(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return milestones, nil
+}
@@ -0,0 +1,55 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/labels", r.URL.Path)
+		assert.Equal(t, "bug", r.URL.Query().Get("search"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_ancestor_groups"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 1, "name": "bug"},
+			{"id": 2, "name": "bug::critical"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	labels, err := client.SearchLabels("test-project", "bug", 20)
+
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+	assert.Equal(t, "bug::critical", labels[1].Name)
+}
+
+func TestSearchMilestones(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/milestones", r.URL.Path)
+		assert.Equal(t, "2026", r.URL.Query().Get("search"))
+		assert.Equal(t, "active", r.URL.Query().Get("state"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_ancestors"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 3, "iid": 1, "title": "2026 Q4"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	milestones, err := client.SearchMilestones("test-project", "2026", 20)
+
+	require.NoError(t, err)
+	require.Len(t, milestones, 1)
+	assert.Equal(t, "2026 Q4", milestones[0].Title)
+}
@@ -0,0 +1,96 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// CILintJob は CI 設定の検証で得られたジョブ情報
+type CILintJob struct {
+	Name         string   `json:"name"`
+	Stage        string   `json:"stage"`
+	When         string   `json:"when"`
+	AllowFailure bool     `json:"allow_failure"`
+	Environment  string   `json:"environment"`
+	TagList      []string `json:"tag_list"`
+}
+
+// CILintResult は CI 設定の検証結果
+// GitLab SDK の ProjectLintResult は jobs を含まないため独自に定義している
+type CILintResult struct {
+	Valid      bool               `json:"valid"`
+	Errors     []string           `json:"errors"`
+	Warnings   []string           `json:"warnings"`
+	MergedYaml string             `json:"merged_yaml"`
+	Includes   []gogitlab.Include `json:"includes"`
+	Jobs       []CILintJob        `json:"jobs"`
+}
+
+// LintCIConfigOptions は CI 設定検証のオプション
+type LintCIConfigOptions struct {
+	// Content が指定された場合はその YAML を検証し、省略時は Ref の .gitlab-ci.yml を検証する
+	Content *string
+	Ref     *string
+	DryRun  bool
+}
+
+// projectLintContentOptions は YAML 内容を検証する際のリクエストボディ
+type projectLintContentOptions struct {
+	Content     *string `json:"content"`
+	DryRun      *bool   `json:"dry_run,omitempty"`
+	IncludeJobs *bool   `json:"include_jobs,omitempty"`
+	Ref         *string `json:"ref,omitempty"`
+}
+
+// projectLintRefOptions はリポジトリ内の設定を検証する際のクエリパラメータ
+type projectLintRefOptions struct {
+	ContentRef  *string `url:"content_ref,omitempty"`
+	DryRun      *bool   `url:"dry_run,omitempty"`
+	DryRunRef   *string `url:"dry_run_ref,omitempty"`
+	IncludeJobs *bool   `url:"include_jobs,omitempty"`
+}
+
+// LintCIConfig はプロジェクトの CI 設定を検証する
+func (c *Client) LintCIConfig(projectID string, opts *LintCIConfigOptions) (*CILintResult, error) {
+	path := fmt.Sprintf("projects/%s/ci/lint", gogitlab.PathEscape(projectID))
+	includeJobs := true
+	dryRun := opts.DryRun
+
+	var method string
+	var reqOpts any
+	if opts.Content != nil {
+		method = http.MethodPost
+		reqOpts = &projectLintContentOptions{
+			Content:     opts.Content,
+			DryRun:      &dryRun,
+			IncludeJobs: &includeJobs,
+			Ref:         opts.Ref,
+		}
+	} else {
+		method = http.MethodGet
+		refOpts := &projectLintRefOptions{
+			ContentRef:  opts.Ref,
+			DryRun:      &dryRun,
+			IncludeJobs: &includeJobs,
+		}
+		if dryRun {
+			refOpts.DryRunRef = opts.Ref
+		}
+		reqOpts = refOpts
+	}
+
+	// 検証のみの POST はドライランでも送信する
+	req, err := c.client.NewRequest(method, path, reqOpts, append(c.requestOptions(), readOnlyRequest()))
+	if err != nil {
+		return nil, FromGitLabResponse(err, nil)
+	}
+
+	result := new(CILintResult)
+	resp, err := c.client.Do(req, result)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintCIConfig_Content(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/ci/lint", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "build:\n  script: make\n", body["content"])
+		assert.Equal(t, true, body["dry_run"])
+		assert.Equal(t, true, body["include_jobs"])
+		assert.Equal(t, "main", body["ref"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"valid":       true,
+			"errors":      []string{},
+			"warnings":    []string{"jobs:build uses `only`"},
+			"merged_yaml": "---\nbuild:\n  script:\n  - make\n",
+			"jobs": []map[string]any{
+				{"name": "build", "stage": "test", "when": "on_success", "allow_failure": false},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	content := "build:\n  script: make\n"
+	ref := "main"
+	result, err := client.LintCIConfig("test-project", &LintCIConfigOptions{
+		Content: &content,
+		Ref:     &ref,
+		DryRun:  true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.MergedYaml, "make")
+	require.Len(t, result.Jobs, 1)
+	assert.Equal(t, "build", result.Jobs[0].Name)
+	assert.Equal(t, "on_success", result.Jobs[0].When)
+}
+
+func TestLintCIConfig_Ref(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/ci/lint", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "feature", r.URL.Query().Get("content_ref"))
+		assert.Equal(t, "feature", r.URL.Query().Get("dry_run_ref"))
+		assert.Equal(t, "true", r.URL.Query().Get("dry_run"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"valid":  false,
+			"errors": []string{"jobs:test config should implement a script: or a trigger: keyword"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	ref := "feature"
+	result, err := client.LintCIConfig("test-project", &LintCIConfigOptions{
+		Ref:    &ref,
+		DryRun: true,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Errors, 1)
+}
+
+func TestLintCIConfig_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "404 Project Not Found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	result, err := client.LintCIConfig("unknown-project", &LintCIConfigOptions{})
+
+	assert.Nil(t, result)
+	mcpErr, ok := err.(*MCPError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeNotFound, mcpErr.Code)
+}
@@ -1,6 +1,9 @@
 package gitlab
 
 import (
+	"net/http"
+	"time"
+
 	gogitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
@@ -45,7 +48,71 @@ func (c *Client) ListMergeRequests(projectID string, opts *ListMergeRequestsOpti
 		}
 	}
 
-	mrs, resp, err := c.client.MergeRequests.ListProjectMergeRequests(projectID, listOpts)
+	mrs, resp, err := c.client.MergeRequests.ListProjectMergeRequests(projectID, listOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return mrs, nil
+}
+
+// SearchMergeRequestsOptions はプロジェクトをまたいだMR一覧取得のオプション
+type SearchMergeRequestsOptions struct {
+	State            *string
+	Scope            *string
+	AuthorUsername   *string
+	ReviewerUsername *string
+	Labels           []string
+	Draft            *bool
+	UpdatedAfter     *time.Time
+	OrderBy          *string
+	Sort             *string
+	Pagination       *PaginationOptions
+}
+
+// ListGlobalMergeRequests は認証ユーザーがアクセスできる全プロジェクトのMR一覧を取得する
+func (c *Client) ListGlobalMergeRequests(opts *SearchMergeRequestsOptions) ([]*gogitlab.BasicMergeRequest, error) {
+	listOpts := &gogitlab.ListMergeRequestsOptions{
+		ListOptions:      listOptions(opts.Pagination),
+		State:            opts.State,
+		Scope:            opts.Scope,
+		AuthorUsername:   opts.AuthorUsername,
+		ReviewerUsername: opts.ReviewerUsername,
+		Draft:            opts.Draft,
+		UpdatedAfter:     opts.UpdatedAfter,
+		OrderBy:          opts.OrderBy,
+		Sort:             opts.Sort,
+	}
+	if len(opts.Labels) > 0 {
+		labels := gogitlab.LabelOptions(opts.Labels)
+		listOpts.Labels = &labels
+	}
+
+	mrs, resp, err := c.client.MergeRequests.ListMergeRequests(listOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return mrs, nil
+}
+
+// ListGroupMergeRequests はグループ（サブグループを含む）のMR一覧を取得する
+func (c *Client) ListGroupMergeRequests(groupID string, opts *SearchMergeRequestsOptions) ([]*gogitlab.BasicMergeRequest, error) {
+	listOpts := &gogitlab.ListGroupMergeRequestsOptions{
+		ListOptions:      listOptions(opts.Pagination),
+		State:            opts.State,
+		Scope:            opts.Scope,
+		AuthorUsername:   opts.AuthorUsername,
+		ReviewerUsername: opts.ReviewerUsername,
+		Draft:            opts.Draft,
+		UpdatedAfter:     opts.UpdatedAfter,
+		OrderBy:          opts.OrderBy,
+		Sort:             opts.Sort,
+	}
+	if len(opts.Labels) > 0 {
+		labels := gogitlab.LabelOptions(opts.Labels)
+		listOpts.Labels = &labels
+	}
+
+	mrs, resp, err := c.client.MergeRequests.ListGroupMergeRequests(groupID, listOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -54,7 +121,7 @@ func (c *Client) ListMergeRequests(projectID string, opts *ListMergeRequestsOpti
 
 // GetMergeRequest はMRの詳細を取得する
 func (c *Client) GetMergeRequest(projectID string, mrIID int) (*gogitlab.MergeRequest, error) {
-	mr, resp, err := c.client.MergeRequests.GetMergeRequest(projectID, int64(mrIID), nil)
+	mr, resp, err := c.client.MergeRequests.GetMergeRequest(projectID, int64(mrIID), nil, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -105,7 +172,11 @@ func (c *Client) CreateMergeRequest(projectID string, opts *CreateMergeRequestOp
 		createOpts.Labels = &labels
 	}
 
-	mr, resp, err := c.client.MergeRequests.CreateMergeRequest(projectID, createOpts)
+	if err := c.requireProject(projectID); err != nil {
+		return nil, err
+	}
+
+	mr, resp, err := c.client.MergeRequests.CreateMergeRequest(projectID, createOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -120,6 +191,8 @@ type UpdateMergeRequestOptions struct {
 	ReviewerIDs  []int
 	Labels       []string
 	TargetBranch *string
+	// StateEvent は状態の変更（"close" または "reopen"）
+	StateEvent *string
 }
 
 // UpdateMergeRequest は既存のMRを更新する
@@ -135,6 +208,9 @@ func (c *Client) UpdateMergeRequest(projectID string, mrIID int, opts *UpdateMer
 	if opts.TargetBranch != nil {
 		updateOpts.TargetBranch = opts.TargetBranch
 	}
+	if opts.StateEvent != nil {
+		updateOpts.StateEvent = opts.StateEvent
+	}
 
 	if len(opts.AssigneeIDs) > 0 {
 		assigneeIDs := make([]int64, len(opts.AssigneeIDs))
@@ -157,7 +233,11 @@ func (c *Client) UpdateMergeRequest(projectID string, mrIID int, opts *UpdateMer
 		updateOpts.Labels = &labels
 	}
 
-	mr, resp, err := c.client.MergeRequests.UpdateMergeRequest(projectID, int64(mrIID), updateOpts)
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
+	}
+
+	mr, resp, err := c.client.MergeRequests.UpdateMergeRequest(projectID, int64(mrIID), updateOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -170,6 +250,10 @@ type MergeMergeRequestOptions struct {
 	ShouldRemoveSourceBranch *bool
 	MergeCommitMessage       *string
 	SquashCommitMessage      *string
+	// SHA が指定された場合、ソースブランチの HEAD が一致しなければマージしない
+	SHA *string
+	// AutoMerge が true の場合、パイプラインの成功後に自動でマージする
+	AutoMerge *bool
 }
 
 // MergeMergeRequest はMRをマージする
@@ -189,11 +273,23 @@ func (c *Client) MergeMergeRequest(projectID string, mrIID int, opts *MergeMerge
 		if opts.SquashCommitMessage != nil {
 			mergeOpts.SquashCommitMessage = opts.SquashCommitMessage
 		}
+		if opts.SHA != nil {
+			mergeOpts.SHA = opts.SHA
+		}
+		if opts.AutoMerge != nil {
+			// auto_merge 未対応の古い GitLab のため merge_when_pipeline_succeeds も送る
+			mergeOpts.AutoMerge = opts.AutoMerge
+			mergeOpts.MergeWhenPipelineSucceeds = opts.AutoMerge
+		}
+	}
+
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
 	}
 
-	mr, resp, err := c.client.MergeRequests.AcceptMergeRequest(projectID, int64(mrIID), mergeOpts)
+	mr, resp, err := c.client.MergeRequests.AcceptMergeRequest(projectID, int64(mrIID), mergeOpts, c.requestOptions()...)
 	if err != nil {
-		return nil, FromGitLabResponse(err, resp)
+		return nil, notMergeable(FromGitLabResponse(err, resp))
 	}
 	return mr, nil
 }
@@ -216,9 +312,74 @@ func (c *Client) GetMergeRequestChanges(projectID string, mrIID int, pagination
 			PerPage: int64(perPage),
 		},
 	}
-	diffs, resp, err := c.client.MergeRequests.ListMergeRequestDiffs(projectID, int64(mrIID), opts)
+	diffs, resp, err := c.client.MergeRequests.ListMergeRequestDiffs(projectID, int64(mrIID), opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
 	return diffs, nil
 }
+
+// RebaseMergeRequest は MR のソースブランチをターゲットブランチにリベースする
+// リベースは非同期に行われるため、完了は GetMergeRequestRebaseStatus で確認する
+func (c *Client) RebaseMergeRequest(projectID string, mrIID int, skipCI bool) error {
+	opts := &gogitlab.RebaseMergeRequestOptions{}
+	if skipCI {
+		opts.SkipCI = &skipCI
+	}
+
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.MergeRequests.RebaseMergeRequest(projectID, int64(mrIID), opts, c.requestOptions()...)
+	if err != nil {
+		return notMergeable(FromGitLabResponse(err, resp))
+	}
+	return nil
+}
+
+// GetMergeRequestRebaseStatus は rebase_in_progress を含めて MR を取得する
+func (c *Client) GetMergeRequestRebaseStatus(projectID string, mrIID int) (*gogitlab.MergeRequest, error) {
+	includeRebase := true
+	opts := &gogitlab.GetMergeRequestsOptions{IncludeRebaseInProgress: &includeRebase}
+
+	mr, resp, err := c.client.MergeRequests.GetMergeRequest(projectID, int64(mrIID), opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return mr, nil
+}
+
+// SubscribeToMergeRequest は MR の通知を購読する
+// すでに購読している場合（304）は現在の MR を返す
+func (c *Client) SubscribeToMergeRequest(projectID string, mrIID int) (*gogitlab.MergeRequest, error) {
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
+	}
+
+	mr, resp, err := c.client.MergeRequests.SubscribeToMergeRequest(projectID, int64(mrIID), c.requestOptions()...)
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return c.GetMergeRequest(projectID, mrIID)
+	}
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return mr, nil
+}
+
+// UnsubscribeFromMergeRequest は MR の通知の購読を解除する
+// すでに購読していない場合（304）は現在の MR を返す
+func (c *Client) UnsubscribeFromMergeRequest(projectID string, mrIID int) (*gogitlab.MergeRequest, error) {
+	if err := c.requireMergeRequest(projectID, mrIID); err != nil {
+		return nil, err
+	}
+
+	mr, resp, err := c.client.MergeRequests.UnsubscribeFromMergeRequest(projectID, int64(mrIID), c.requestOptions()...)
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return c.GetMergeRequest(projectID, mrIID)
+	}
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return mr, nil
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -209,6 +210,40 @@ func TestMergeMergeRequest_Success(t *testing.T) {
 	assert.Equal(t, "merged", mr.State)
 }
 
+func TestMergeMergeRequest_SHAAndAutoMerge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "abc123", body["sha"])
+		assert.Equal(t, true, body["auto_merge"])
+		assert.Equal(t, true, body["merge_when_pipeline_succeeds"])
+		assert.Equal(t, "Squashed", body["squash_commit_message"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"iid":                          1,
+			"state":                        "opened",
+			"merge_when_pipeline_succeeds": true,
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	sha := "abc123"
+	autoMerge := true
+	message := "Squashed"
+	mr, err := client.MergeMergeRequest("test-project", 1, &MergeMergeRequestOptions{
+		SHA:                 &sha,
+		AutoMerge:           &autoMerge,
+		SquashCommitMessage: &message,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, mr.MergeWhenPipelineSucceeds)
+}
+
 func TestMergeMergeRequest_Conflict(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -222,7 +257,7 @@ func TestMergeMergeRequest_Conflict(t *testing.T) {
 	mr, err := client.MergeMergeRequest("test-project", 1, nil)
 
 	assert.Nil(t, mr)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotMergeable)
 }
 
 func TestGetMergeRequestChanges_Success(t *testing.T) {
@@ -264,3 +299,142 @@ func TestGetMergeRequestChanges_Success(t *testing.T) {
 	assert.Equal(t, "new_file.go", diffs[1].NewPath)
 	assert.True(t, diffs[1].NewFile)
 }
+
+func TestRebaseMergeRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/rebase", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, true, body["skip_ci"])
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]any{"rebase_in_progress": true})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	err = client.RebaseMergeRequest("test-project", 1, true)
+
+	require.NoError(t, err)
+}
+
+func TestGetMergeRequestRebaseStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("include_rebase_in_progress"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"iid": 1, "rebase_in_progress": true})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	mr, err := client.GetMergeRequestRebaseStatus("test-project", 1)
+
+	require.NoError(t, err)
+	assert.True(t, mr.RebaseInProgress)
+}
+
+func TestSubscribeToMergeRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/subscribe", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"iid": 1, "subscribed": true})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	mr, err := client.SubscribeToMergeRequest("test-project", 1)
+
+	require.NoError(t, err)
+	assert.True(t, mr.Subscribed)
+}
+
+func TestUnsubscribeFromMergeRequest_NotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/unsubscribe", r.URL.Path)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"iid": 1, "subscribed": false})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	mr, err := client.UnsubscribeFromMergeRequest("test-project", 1)
+
+	require.NoError(t, err)
+	assert.False(t, mr.Subscribed)
+}
+
+func TestListGlobalMergeRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/merge_requests", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "all", q.Get("scope"))
+		assert.Equal(t, "alice", q.Get("reviewer_username"))
+		assert.Equal(t, "bug,backend", q.Get("labels"))
+		assert.Equal(t, "false", q.Get("draft"))
+		assert.Equal(t, "2026-10-01T00:00:00Z", q.Get("updated_after"))
+		assert.Equal(t, "updated_at", q.Get("order_by"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{{"iid": 1, "project_id": 5}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	scope := "all"
+	reviewer := "alice"
+	draft := false
+	updatedAfter := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	orderBy := "updated_at"
+	mrs, err := client.ListGlobalMergeRequests(&SearchMergeRequestsOptions{
+		Scope:            &scope,
+		ReviewerUsername: &reviewer,
+		Labels:           []string{"bug", "backend"},
+		Draft:            &draft,
+		UpdatedAfter:     &updatedAfter,
+		OrderBy:          &orderBy,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, mrs, 1)
+	assert.Equal(t, int64(5), mrs[0].ProjectID)
+}
+
+func TestListGroupMergeRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/groups/my-group/merge_requests", r.URL.Path)
+		assert.Equal(t, "opened", r.URL.Query().Get("state"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{{"iid": 2}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	state := "opened"
+	mrs, err := client.ListGroupMergeRequests("my-group", &SearchMergeRequestsOptions{State: &state})
+
+	require.NoError(t, err)
+	assert.Len(t, mrs, 1)
+}
@@ -0,0 +1,219 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Language はエラーメッセージの言語
+type Language string
+
+const (
+	LangEnglish  Language = "en"
+	LangJapanese Language = "ja"
+)
+
+// MessageKey はメッセージカタログのキー
+type MessageKey string
+
+const (
+	MsgAPIError                     MessageKey = "api_error"
+	MsgUnauthorized                 MessageKey = "unauthorized"
+	MsgForbidden                    MessageKey = "forbidden"
+	MsgNotFound                     MessageKey = "not_found"
+	MsgRateLimited                  MessageKey = "rate_limited"
+	MsgBadRequest                   MessageKey = "bad_request"
+	MsgServerError                  MessageKey = "server_error"
+	MsgUnexpectedError              MessageKey = "unexpected_error"
+	MsgToolDisabled                 MessageKey = "tool_disabled"
+	MsgInvalidArguments             MessageKey = "invalid_arguments"
+	MsgJobLogReadFailed             MessageKey = "job_log_read_failed"
+	MsgFileNotInDiff                MessageKey = "file_not_in_diff"
+	MsgLineRequired                 MessageKey = "line_required"
+	MsgLineRangeOrder               MessageKey = "line_range_order"
+	MsgLineNotInDiff                MessageKey = "line_not_in_diff"
+	MsgLineOldNew                   MessageKey = "line_old_new"
+	MsgLineNew                      MessageKey = "line_new"
+	MsgLineOld                      MessageKey = "line_old"
+	MsgDiffVersionNotFound          MessageKey = "diff_version_not_found"
+	MsgStartLineAfterEnd            MessageKey = "start_line_after_end"
+	MsgInvalidTimeFilter            MessageKey = "invalid_time_filter"
+	MsgUnknownSection               MessageKey = "unknown_section"
+	MsgConflict                     MessageKey = "conflict"
+	MsgMethodNotAllowed             MessageKey = "method_not_allowed"
+	MsgNotMergeable                 MessageKey = "not_mergeable"
+	MsgUnprocessable                MessageKey = "unprocessable"
+	MsgTimeout                      MessageKey = "timeout"
+	MsgNetworkError                 MessageKey = "network_error"
+	MsgCancelled                    MessageKey = "cancelled"
+	MsgInvalidResourceParam         MessageKey = "invalid_resource_param"
+	MsgSubscriptionNotSupported     MessageKey = "subscription_not_supported"
+	MsgMissingPromptArgument        MessageKey = "missing_prompt_argument"
+	MsgInvalidPromptArgument        MessageKey = "invalid_prompt_argument"
+	MsgConfirmOperation             MessageKey = "confirm_operation"
+	MsgConfirmationRequired         MessageKey = "confirmation_required"
+	MsgDeclined                     MessageKey = "declined"
+	MsgDryRun                       MessageKey = "dry_run"
+	MsgScheduleRolledBack           MessageKey = "schedule_rolled_back"
+	MsgScheduleOrphaned             MessageKey = "schedule_orphaned"
+	MsgBlockerNotOpen               MessageKey = "blocker_not_open"
+	MsgBlockerDraft                 MessageKey = "blocker_draft"
+	MsgBlockerConflicts             MessageKey = "blocker_conflicts"
+	MsgBlockerSHAMismatch           MessageKey = "blocker_sha_mismatch"
+	MsgBlockerPipelineRunning       MessageKey = "blocker_pipeline_running"
+	MsgBlockerPipelineFailed        MessageKey = "blocker_pipeline_failed"
+	MsgBlockerPipelineManual        MessageKey = "blocker_pipeline_manual"
+	MsgBlockerDiscussionsUnresolved MessageKey = "blocker_discussions_unresolved"
+	MsgBlockerApprovalsMissing      MessageKey = "blocker_approvals_missing"
+	MsgBlockerMergeStatus           MessageKey = "blocker_merge_status"
+)
+
+// catalog は言語ごとのメッセージ（fmt の書式）
+var catalog = map[Language]map[MessageKey]string{
+	LangEnglish: {
+		MsgAPIError:                     "GitLab API error: %v",
+		MsgUnauthorized:                 "The access token is invalid or has expired",
+		MsgForbidden:                    "You do not have permission to perform this operation",
+		MsgNotFound:                     "The requested resource was not found",
+		MsgRateLimited:                  "The API rate limit was reached. Wait a while and try again",
+		MsgBadRequest:                   "The request is invalid: %v",
+		MsgServerError:                  "An error occurred on the GitLab server. Wait a while and try again",
+		MsgUnexpectedError:              "An unexpected error occurred: %v",
+		MsgToolDisabled:                 "Tool '%s' is disabled",
+		MsgInvalidArguments:             "Invalid arguments: %v",
+		MsgJobLogReadFailed:             "Failed to read the job log",
+		MsgFileNotInDiff:                "File '%s' is not part of the merge request diff",
+		MsgLineRequired:                 "Specify either new_line or old_line",
+		MsgLineRangeOrder:               "The start line of the range must come before the end line",
+		MsgLineNotInDiff:                "%s is not part of the diff of '%s'. Only added, removed and context lines in the diff can be commented on",
+		MsgLineOldNew:                   "Line (old: %d, new: %d)",
+		MsgLineNew:                      "New line %d",
+		MsgLineOld:                      "Old line %d",
+		MsgDiffVersionNotFound:          "No diff version was found for the merge request",
+		MsgStartLineAfterEnd:            "start_line must be less than or equal to end_line",
+		MsgInvalidTimeFilter:            "%s must be in RFC 3339 (e.g. 2026-10-01T00:00:00Z) or YYYY-MM-DD format: %q",
+		MsgUnknownSection:               "Unknown section %q in include (available: %s)",
+		MsgConflict:                     "The request conflicts with the current state of the resource: %v",
+		MsgMethodNotAllowed:             "This operation is not allowed for the resource in its current state: %v",
+		MsgNotMergeable:                 "The merge request cannot be merged in its current state: %v",
+		MsgUnprocessable:                "GitLab could not process the request: %v",
+		MsgTimeout:                      "The request to GitLab timed out",
+		MsgNetworkError:                 "Could not connect to GitLab: %v",
+		MsgCancelled:                    "The request was cancelled",
+		MsgInvalidResourceParam:         "Invalid value for %s in the resource URI: %q",
+		MsgSubscriptionNotSupported:     "Subscriptions are not supported for resource %q",
+		MsgMissingPromptArgument:        "Missing required prompt argument: %s",
+		MsgInvalidPromptArgument:        "Invalid value for prompt argument %s: %q",
+		MsgConfirmOperation:             "Allow the assistant to %s?",
+		MsgConfirmationRequired:         "This operation needs the user's confirmation: %s. Ask the user, and call the tool again with confirm set to true only if they agree",
+		MsgDeclined:                     "The user declined the operation: %s",
+		MsgDryRun:                       "Dry run: the input and the target were validated, but nothing was sent to GitLab. dry_run_requests lists the %d write requests that would have been sent",
+		MsgScheduleRolledBack:           "Could not add the variable %s, so the new pipeline schedule was deleted again: %s",
+		MsgScheduleOrphaned:             "Could not add the variable %s, and deleting the new pipeline schedule %d failed. Delete it or add the variables yourself: %s",
+		MsgBlockerNotOpen:               "The merge request is %s and cannot be merged",
+		MsgBlockerDraft:                 "The merge request is a draft",
+		MsgBlockerConflicts:             "The merge request has conflicts with the target branch",
+		MsgBlockerSHAMismatch:           "The source branch HEAD (%s) does not match the given SHA (%s)",
+		MsgBlockerPipelineRunning:       "Pipeline %d has not finished (%s)",
+		MsgBlockerPipelineFailed:        "Pipeline %d did not succeed (%s)",
+		MsgBlockerPipelineManual:        "Pipeline %d is waiting for a manual job to be started",
+		MsgBlockerDiscussionsUnresolved: "There are unresolved discussions",
+		MsgBlockerApprovalsMissing:      "%d more approvals are required",
+		MsgBlockerMergeStatus:           "GitLab reports the merge request as not mergeable (detailed_merge_status: %s)",
+	},
+	LangJapanese: {
+		MsgAPIError:                     "GitLab API エラー: %v",
+		MsgUnauthorized:                 "認証トークンが無効または期限切れです",
+		MsgForbidden:                    "この操作を実行する権限がありません",
+		MsgNotFound:                     "指定されたリソースが見つかりません",
+		MsgRateLimited:                  "API レート制限に達しました。しばらく待ってから再試行してください",
+		MsgBadRequest:                   "リクエストが無効です: %v",
+		MsgServerError:                  "GitLab サーバーでエラーが発生しました。しばらく待ってから再試行してください",
+		MsgUnexpectedError:              "予期しないエラーが発生しました: %v",
+		MsgToolDisabled:                 "ツール '%s' は無効化されています",
+		MsgInvalidArguments:             "引数が無効です: %v",
+		MsgJobLogReadFailed:             "ジョブログの読み取りに失敗しました",
+		MsgFileNotInDiff:                "ファイル '%s' は Merge Request の差分に含まれていません",
+		MsgLineRequired:                 "new_line または old_line のいずれかを指定してください",
+		MsgLineRangeOrder:               "範囲の開始行は終了行より前にある必要があります",
+		MsgLineNotInDiff:                "%s は '%s' の差分に含まれていません。差分内の追加行・削除行・前後の行のみコメントできます",
+		MsgLineOldNew:                   "行 (old: %d, new: %d)",
+		MsgLineNew:                      "変更後の %d 行目",
+		MsgLineOld:                      "変更前の %d 行目",
+		MsgDiffVersionNotFound:          "Merge Request の差分バージョンが見つかりません",
+		MsgStartLineAfterEnd:            "start_line は end_line 以下である必要があります",
+		MsgInvalidTimeFilter:            "%s は RFC 3339 (例: 2026-10-01T00:00:00Z) または YYYY-MM-DD 形式で指定してください: %q",
+		MsgUnknownSection:               "include に不明なセクション %q が指定されました（指定可能: %s）",
+		MsgConflict:                     "リソースの現在の状態と競合しています: %v",
+		MsgMethodNotAllowed:             "リソースの現在の状態ではこの操作は許可されていません: %v",
+		MsgNotMergeable:                 "Merge Request は現在の状態ではマージできません: %v",
+		MsgUnprocessable:                "GitLab がリクエストを処理できませんでした: %v",
+		MsgTimeout:                      "GitLab へのリクエストがタイムアウトしました",
+		MsgNetworkError:                 "GitLab に接続できませんでした: %v",
+		MsgCancelled:                    "リクエストがキャンセルされました",
+		MsgInvalidResourceParam:         "リソース URI の %s の値が無効です: %q",
+		MsgSubscriptionNotSupported:     "リソース %q は購読できません",
+		MsgMissingPromptArgument:        "プロンプトの必須の引数 %s が指定されていません",
+		MsgInvalidPromptArgument:        "プロンプトの引数 %s の値が無効です: %q",
+		MsgConfirmOperation:             "アシスタントに次の操作を許可しますか: %s",
+		MsgConfirmationRequired:         "この操作にはユーザーの確認が必要です: %s。ユーザーに確認し、同意を得た場合のみ confirm を true にして再度呼び出してください",
+		MsgDeclined:                     "ユーザーが操作を拒否しました: %s",
+		MsgDryRun:                       "ドライランのため、入力と対象を検証しましたが GitLab には何も送信していません。送信するはずだった %d 件の書き込みのリクエストは dry_run_requests にあります",
+		MsgScheduleRolledBack:           "変数 %s を追加できなかったため、作成したパイプラインスケジュールを削除しました: %s",
+		MsgScheduleOrphaned:             "変数 %s を追加できず、作成したパイプラインスケジュール %d の削除にも失敗しました。スケジュールを削除するか変数を追加してください: %s",
+		MsgBlockerNotOpen:               "MR の状態が %s のためマージできません",
+		MsgBlockerDraft:                 "MR がドラフトです",
+		MsgBlockerConflicts:             "ターゲットブランチとのコンフリクトがあります",
+		MsgBlockerSHAMismatch:           "ソースブランチの HEAD (%s) が指定された SHA (%s) と一致しません",
+		MsgBlockerPipelineRunning:       "パイプライン %d が完了していません（%s）",
+		MsgBlockerPipelineFailed:        "パイプライン %d が成功していません（%s）",
+		MsgBlockerPipelineManual:        "パイプライン %d は手動ジョブの開始を待っています",
+		MsgBlockerDiscussionsUnresolved: "未解決のディスカッションがあります",
+		MsgBlockerApprovalsMissing:      "承認があと %d 件必要です",
+		MsgBlockerMergeStatus:           "GitLab がマージ不可と判定しています（detailed_merge_status: %s）",
+	},
+}
+
+// currentLanguage はメッセージに使用する言語
+var currentLanguage atomic.Value
+
+func init() {
+	currentLanguage.Store(LangEnglish)
+}
+
+// ParseLanguage は言語の指定（"ja"、"ja_JP.UTF-8" など）を Language に変換する
+// 対応していない言語の場合は英語を返す
+func ParseLanguage(value string) Language {
+	lang := strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(lang, "_-."); i >= 0 {
+		lang = lang[:i]
+	}
+	if _, ok := catalog[Language(lang)]; ok {
+		return Language(lang)
+	}
+	return LangEnglish
+}
+
+// SetLanguage はエラーメッセージの言語を設定する
+func SetLanguage(lang Language) {
+	if _, ok := catalog[lang]; !ok {
+		lang = LangEnglish
+	}
+	currentLanguage.Store(lang)
+}
+
+// CurrentLanguage は現在のエラーメッセージの言語を返す
+func CurrentLanguage() Language {
+	return currentLanguage.Load().(Language)
+}
+
+// Msg はメッセージカタログから現在の言語のメッセージを作成する
+// 現在の言語にメッセージがない場合は英語のメッセージを使用する
+func Msg(key MessageKey, args ...any) string {
+	format, ok := catalog[CurrentLanguage()][key]
+	if !ok {
+		format = catalog[LangEnglish][key]
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,53 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_AllKeysTranslated(t *testing.T) {
+	for key := range catalog[LangEnglish] {
+		_, ok := catalog[LangJapanese][key]
+		assert.True(t, ok, "message %q has no Japanese translation", key)
+	}
+	assert.Len(t, catalog[LangJapanese], len(catalog[LangEnglish]))
+}
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Language
+	}{
+		{"", LangEnglish},
+		{"en", LangEnglish},
+		{"ja", LangJapanese},
+		{"JA", LangJapanese},
+		{"ja_JP.UTF-8", LangJapanese},
+		{"ja-JP", LangJapanese},
+		{"fr", LangEnglish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseLanguage(tt.value))
+		})
+	}
+}
+
+func TestMsg(t *testing.T) {
+	assert.Equal(t, "Tool 'delete_issue' is disabled", Msg(MsgToolDisabled, "delete_issue"))
+
+	SetLanguage(LangJapanese)
+	defer SetLanguage(LangEnglish)
+
+	assert.Equal(t, LangJapanese, CurrentLanguage())
+	assert.Equal(t, "ツール 'delete_issue' は無効化されています", Msg(MsgToolDisabled, "delete_issue"))
+}
+
+func TestSetLanguage_Unsupported(t *testing.T) {
+	SetLanguage(Language("fr"))
+	defer SetLanguage(LangEnglish)
+
+	assert.Equal(t, LangEnglish, CurrentLanguage())
+}
@@ -0,0 +1,57 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ListMergeRequestDiffVersions は MR の差分バージョン一覧を新しい順に取得する
+func (c *Client) ListMergeRequestDiffVersions(projectID string, mrIID int, pagination *PaginationOptions) ([]*gogitlab.MergeRequestDiffVersion, error) {
+	opts := &gogitlab.GetMergeRequestDiffVersionsOptions{ListOptions: listOptions(pagination)}
+
+	versions, resp, err := c.client.MergeRequests.GetMergeRequestDiffVersions(projectID, int64(mrIID), opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return versions, nil
+}
+
+// GetMergeRequestDiffVersion は MR の差分バージョンをコミットと差分付きで取得する
+func (c *Client) GetMergeRequestDiffVersion(projectID string, mrIID, versionID int) (*gogitlab.MergeRequestDiffVersion, error) {
+	version, resp, err := c.client.MergeRequests.GetSingleMergeRequestDiffVersion(projectID, int64(mrIID), int64(versionID), nil, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return version, nil
+}
+
+// CompareCommits は2つのコミット間の差分を取得する
+// マージベースを使わず from と to を直接比較する
+func (c *Client) CompareCommits(projectID, from, to string) (*gogitlab.Compare, error) {
+	straight := true
+	opts := &gogitlab.CompareOptions{
+		From:     &from,
+		To:       &to,
+		Straight: &straight,
+	}
+
+	compare, resp, err := c.client.Repositories.Compare(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return compare, nil
+}
+
+// CompareRefs は from と to のマージベースから to までのコミットと差分を取得する
+// ブランチを Merge Request にした場合や、タグ以降の変更と同じ範囲になる
+func (c *Client) CompareRefs(projectID, from, to string) (*gogitlab.Compare, error) {
+	opts := &gogitlab.CompareOptions{
+		From: &from,
+		To:   &to,
+	}
+
+	compare, resp, err := c.client.Repositories.Compare(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return compare, nil
+}
@@ -0,0 +1,84 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListMergeRequestDiffVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/versions", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 2, "head_commit_sha": "bbb", "state": "collected"},
+			{"id": 1, "head_commit_sha": "aaa", "state": "collected"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	versions, err := client.ListMergeRequestDiffVersions("test-project", 1, nil)
+
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "bbb", versions[0].HeadCommitSHA)
+}
+
+func TestCompareCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/repository/compare", r.URL.Path)
+		assert.Equal(t, "aaa", r.URL.Query().Get("from"))
+		assert.Equal(t, "bbb", r.URL.Query().Get("to"))
+		assert.Equal(t, "true", r.URL.Query().Get("straight"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"commits": []map[string]any{{"id": "bbb", "title": "Fix review comments"}},
+			"diffs":   []map[string]any{{"old_path": "a.go", "new_path": "a.go", "diff": "@@ -1 +1 @@\n-a\n+b\n"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	compare, err := client.CompareCommits("test-project", "aaa", "bbb")
+
+	require.NoError(t, err)
+	assert.Len(t, compare.Commits, 1)
+	assert.Len(t, compare.Diffs, 1)
+}
+
+func TestCompareRefs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/repository/compare", r.URL.Path)
+		assert.Equal(t, "main", r.URL.Query().Get("from"))
+		assert.Equal(t, "feature", r.URL.Query().Get("to"))
+		assert.Empty(t, r.URL.Query().Get("straight"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"commits": []map[string]any{{"id": "bbb", "title": "Add feature"}},
+			"diffs":   []map[string]any{{"old_path": "a.go", "new_path": "a.go", "diff": "@@ -1 +1 @@\n-a\n+b\n"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	compare, err := client.CompareRefs("test-project", "main", "feature")
+
+	require.NoError(t, err)
+	assert.Len(t, compare.Commits, 1)
+	assert.Len(t, compare.Diffs, 1)
+}
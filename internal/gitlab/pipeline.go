@@ -11,7 +11,7 @@ const maxJobLogSize = 100 * 1024
 
 // ListMergeRequestPipelines はMRに関連するパイプライン一覧を取得する
 func (c *Client) ListMergeRequestPipelines(projectID string, mrIID int) ([]*gogitlab.PipelineInfo, error) {
-	pipelines, resp, err := c.client.MergeRequests.ListMergeRequestPipelines(projectID, int64(mrIID))
+	pipelines, resp, err := c.client.MergeRequests.ListMergeRequestPipelines(projectID, int64(mrIID), c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -36,13 +36,31 @@ func (c *Client) ListPipelineJobs(projectID string, pipelineID int, pagination *
 			PerPage: int64(perPage),
 		},
 	}
-	jobs, resp, err := c.client.Jobs.ListPipelineJobs(projectID, int64(pipelineID), opts)
+	jobs, resp, err := c.client.Jobs.ListPipelineJobs(projectID, int64(pipelineID), opts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
 	return jobs, nil
 }
 
+// ListAllPipelineJobs はパイプラインのジョブをすべてのページにわたって取得する
+func (c *Client) ListAllPipelineJobs(projectID string, pipelineID int) ([]*gogitlab.Job, error) {
+	opts := &gogitlab.ListJobsOptions{ListOptions: gogitlab.ListOptions{Page: 1, PerPage: 100}}
+
+	var all []*gogitlab.Job
+	for {
+		jobs, resp, err := c.client.Jobs.ListPipelineJobs(projectID, int64(pipelineID), opts, c.requestOptions()...)
+		if err != nil {
+			return nil, FromGitLabResponse(err, resp)
+		}
+		all = append(all, jobs...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
 // ListProjectPipelinesOptions はパイプライン一覧取得のオプション
 type ListProjectPipelinesOptions struct {
 	Status  *string
@@ -88,7 +106,7 @@ func (c *Client) ListProjectPipelines(projectID string, opts *ListProjectPipelin
 		}
 	}
 
-	pipelines, resp, err := c.client.Pipelines.ListProjectPipelines(projectID, listOpts)
+	pipelines, resp, err := c.client.Pipelines.ListProjectPipelines(projectID, listOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -97,7 +115,7 @@ func (c *Client) ListProjectPipelines(projectID string, opts *ListProjectPipelin
 
 // GetPipeline はパイプラインの詳細を取得する
 func (c *Client) GetPipeline(projectID string, pipelineID int) (*gogitlab.Pipeline, error) {
-	pipeline, resp, err := c.client.Pipelines.GetPipeline(projectID, int64(pipelineID))
+	pipeline, resp, err := c.client.Pipelines.GetPipeline(projectID, int64(pipelineID), c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -135,7 +153,11 @@ func (c *Client) CreatePipeline(projectID string, opts *CreatePipelineOptions) (
 		createOpts.Variables = &vars
 	}
 
-	pipeline, resp, err := c.client.Pipelines.CreatePipeline(projectID, createOpts)
+	if err := c.requireProject(projectID); err != nil {
+		return nil, err
+	}
+
+	pipeline, resp, err := c.client.Pipelines.CreatePipeline(projectID, createOpts, c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -144,7 +166,11 @@ func (c *Client) CreatePipeline(projectID string, opts *CreatePipelineOptions) (
 
 // RetryPipeline はパイプラインの失敗ジョブを再試行する
 func (c *Client) RetryPipeline(projectID string, pipelineID int) (*gogitlab.Pipeline, error) {
-	pipeline, resp, err := c.client.Pipelines.RetryPipelineBuild(projectID, int64(pipelineID))
+	if err := c.requirePipeline(projectID, pipelineID); err != nil {
+		return nil, err
+	}
+
+	pipeline, resp, err := c.client.Pipelines.RetryPipelineBuild(projectID, int64(pipelineID), c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -153,7 +179,11 @@ func (c *Client) RetryPipeline(projectID string, pipelineID int) (*gogitlab.Pipe
 
 // CancelPipeline はパイプラインをキャンセルする
 func (c *Client) CancelPipeline(projectID string, pipelineID int) (*gogitlab.Pipeline, error) {
-	pipeline, resp, err := c.client.Pipelines.CancelPipelineBuild(projectID, int64(pipelineID))
+	if err := c.requirePipeline(projectID, pipelineID); err != nil {
+		return nil, err
+	}
+
+	pipeline, resp, err := c.client.Pipelines.CancelPipelineBuild(projectID, int64(pipelineID), c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -162,7 +192,7 @@ func (c *Client) CancelPipeline(projectID string, pipelineID int) (*gogitlab.Pip
 
 // GetJob はジョブの詳細を取得する
 func (c *Client) GetJob(projectID string, jobID int) (*gogitlab.Job, error) {
-	job, resp, err := c.client.Jobs.GetJob(projectID, int64(jobID))
+	job, resp, err := c.client.Jobs.GetJob(projectID, int64(jobID), c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -171,7 +201,7 @@ func (c *Client) GetJob(projectID string, jobID int) (*gogitlab.Job, error) {
 
 // GetJobTrace はジョブのログを取得する
 func (c *Client) GetJobTrace(projectID string, jobID int) (string, error) {
-	reader, resp, err := c.client.Jobs.GetTraceFile(projectID, int64(jobID))
+	reader, resp, err := c.client.Jobs.GetTraceFile(projectID, int64(jobID), c.requestOptions()...)
 	if err != nil {
 		return "", FromGitLabResponse(err, resp)
 	}
@@ -182,7 +212,7 @@ func (c *Client) GetJobTrace(projectID string, jobID int) (string, error) {
 	if err != nil {
 		return "", &MCPError{
 			Code:    ErrCodeServerError,
-			Message: "ジョブログの読み取りに失敗しました",
+			Message: Msg(MsgJobLogReadFailed),
 		}
 	}
 
@@ -191,7 +221,65 @@ func (c *Client) GetJobTrace(projectID string, jobID int) (string, error) {
 
 // RetryJob はジョブを再試行する
 func (c *Client) RetryJob(projectID string, jobID int) (*gogitlab.Job, error) {
-	job, resp, err := c.client.Jobs.RetryJob(projectID, int64(jobID))
+	if err := c.requireJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+
+	job, resp, err := c.client.Jobs.RetryJob(projectID, int64(jobID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return job, nil
+}
+
+// PlayJob は手動ジョブを実行する
+func (c *Client) PlayJob(projectID string, jobID int, variables []PipelineVariable) (*gogitlab.Job, error) {
+	playOpts := &gogitlab.PlayJobOptions{}
+
+	if len(variables) > 0 {
+		vars := make([]*gogitlab.JobVariableOptions, len(variables))
+		for i, v := range variables {
+			key := v.Key
+			value := v.Value
+			vars[i] = &gogitlab.JobVariableOptions{
+				Key:   &key,
+				Value: &value,
+			}
+		}
+		playOpts.JobVariablesAttributes = &vars
+	}
+
+	if err := c.requireJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+
+	job, resp, err := c.client.Jobs.PlayJob(projectID, int64(jobID), playOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return job, nil
+}
+
+// CancelJob はジョブをキャンセルする
+func (c *Client) CancelJob(projectID string, jobID int) (*gogitlab.Job, error) {
+	if err := c.requireJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+
+	job, resp, err := c.client.Jobs.CancelJob(projectID, int64(jobID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return job, nil
+}
+
+// EraseJob はジョブのログとアーティファクトを削除する
+func (c *Client) EraseJob(projectID string, jobID int) (*gogitlab.Job, error) {
+	if err := c.requireJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+
+	job, resp, err := c.client.Jobs.EraseJob(projectID, int64(jobID), c.requestOptions()...)
 	if err != nil {
 		return nil, FromGitLabResponse(err, resp)
 	}
@@ -0,0 +1,231 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ListPipelineSchedulesOptions はパイプラインスケジュール一覧取得のオプション
+type ListPipelineSchedulesOptions struct {
+	Scope   *string
+	Page    int
+	PerPage int
+}
+
+// ListPipelineSchedules はプロジェクトのパイプラインスケジュール一覧を取得する
+func (c *Client) ListPipelineSchedules(projectID string, opts *ListPipelineSchedulesOptions) ([]*gogitlab.PipelineSchedule, error) {
+	page, perPage := 1, 100
+	if opts != nil {
+		if opts.Page > 0 {
+			page = opts.Page
+		}
+		if opts.PerPage > 0 {
+			perPage = opts.PerPage
+		}
+	}
+
+	listOpts := &gogitlab.ListPipelineSchedulesOptions{
+		ListOptions: gogitlab.ListOptions{
+			Page:    int64(page),
+			PerPage: int64(perPage),
+		},
+	}
+
+	if opts != nil && opts.Scope != nil {
+		scope := gogitlab.PipelineScheduleScopeValue(*opts.Scope)
+		listOpts.Scope = &scope
+	}
+
+	schedules, resp, err := c.client.PipelineSchedules.ListPipelineSchedules(projectID, listOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return schedules, nil
+}
+
+// GetPipelineSchedule はパイプラインスケジュールの詳細を取得する
+func (c *Client) GetPipelineSchedule(projectID string, scheduleID int) (*gogitlab.PipelineSchedule, error) {
+	schedule, resp, err := c.client.PipelineSchedules.GetPipelineSchedule(projectID, int64(scheduleID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return schedule, nil
+}
+
+// CreatePipelineScheduleOptions はパイプラインスケジュール作成のオプション
+type CreatePipelineScheduleOptions struct {
+	Description  string
+	Ref          string
+	Cron         string
+	CronTimezone *string
+	Active       *bool
+}
+
+// CreatePipelineSchedule は新しいパイプラインスケジュールを作成する
+func (c *Client) CreatePipelineSchedule(projectID string, opts *CreatePipelineScheduleOptions) (*gogitlab.PipelineSchedule, error) {
+	createOpts := &gogitlab.CreatePipelineScheduleOptions{
+		Description: &opts.Description,
+		Ref:         &opts.Ref,
+		Cron:        &opts.Cron,
+	}
+
+	if opts.CronTimezone != nil {
+		createOpts.CronTimezone = opts.CronTimezone
+	}
+	if opts.Active != nil {
+		createOpts.Active = opts.Active
+	}
+
+	if err := c.requireProject(projectID); err != nil {
+		return nil, err
+	}
+
+	schedule, resp, err := c.client.PipelineSchedules.CreatePipelineSchedule(projectID, createOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return schedule, nil
+}
+
+// UpdatePipelineScheduleOptions はパイプラインスケジュール更新のオプション
+type UpdatePipelineScheduleOptions struct {
+	Description  *string
+	Ref          *string
+	Cron         *string
+	CronTimezone *string
+	Active       *bool
+}
+
+// UpdatePipelineSchedule は既存のパイプラインスケジュールを更新する
+func (c *Client) UpdatePipelineSchedule(projectID string, scheduleID int, opts *UpdatePipelineScheduleOptions) (*gogitlab.PipelineSchedule, error) {
+	editOpts := &gogitlab.EditPipelineScheduleOptions{}
+
+	if opts.Description != nil {
+		editOpts.Description = opts.Description
+	}
+	if opts.Ref != nil {
+		editOpts.Ref = opts.Ref
+	}
+	if opts.Cron != nil {
+		editOpts.Cron = opts.Cron
+	}
+	if opts.CronTimezone != nil {
+		editOpts.CronTimezone = opts.CronTimezone
+	}
+	if opts.Active != nil {
+		editOpts.Active = opts.Active
+	}
+
+	if err := c.requirePipelineSchedule(projectID, scheduleID); err != nil {
+		return nil, err
+	}
+
+	schedule, resp, err := c.client.PipelineSchedules.EditPipelineSchedule(projectID, int64(scheduleID), editOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return schedule, nil
+}
+
+// TakeOwnershipOfPipelineSchedule はパイプラインスケジュールの所有権を取得する
+func (c *Client) TakeOwnershipOfPipelineSchedule(projectID string, scheduleID int) (*gogitlab.PipelineSchedule, error) {
+	if err := c.requirePipelineSchedule(projectID, scheduleID); err != nil {
+		return nil, err
+	}
+
+	schedule, resp, err := c.client.PipelineSchedules.TakeOwnershipOfPipelineSchedule(projectID, int64(scheduleID), c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return schedule, nil
+}
+
+// RunPipelineSchedule はパイプラインスケジュールを即時実行する
+func (c *Client) RunPipelineSchedule(projectID string, scheduleID int) error {
+	if err := c.requirePipelineSchedule(projectID, scheduleID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.PipelineSchedules.RunPipelineSchedule(projectID, int64(scheduleID), c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+// DeletePipelineSchedule はパイプラインスケジュールを削除する
+func (c *Client) DeletePipelineSchedule(projectID string, scheduleID int) error {
+	if err := c.requirePipelineSchedule(projectID, scheduleID); err != nil {
+		return err
+	}
+
+	resp, err := c.client.PipelineSchedules.DeletePipelineSchedule(projectID, int64(scheduleID), c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+// PipelineScheduleVariableOptions はパイプラインスケジュール変数のオプション
+type PipelineScheduleVariableOptions struct {
+	Key          string
+	Value        string
+	VariableType *string
+}
+
+// CreatePipelineScheduleVariable はパイプラインスケジュールに変数を追加する
+func (c *Client) CreatePipelineScheduleVariable(projectID string, scheduleID int, opts *PipelineScheduleVariableOptions) (*gogitlab.PipelineVariable, error) {
+	createOpts := &gogitlab.CreatePipelineScheduleVariableOptions{
+		Key:   &opts.Key,
+		Value: &opts.Value,
+	}
+
+	if opts.VariableType != nil {
+		variableType := gogitlab.VariableTypeValue(*opts.VariableType)
+		createOpts.VariableType = &variableType
+	}
+
+	if err := c.requirePipelineSchedule(projectID, scheduleID); err != nil {
+		return nil, err
+	}
+
+	variable, resp, err := c.client.PipelineSchedules.CreatePipelineScheduleVariable(projectID, int64(scheduleID), createOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variable, nil
+}
+
+// UpdatePipelineScheduleVariable はパイプラインスケジュールの変数を更新する
+func (c *Client) UpdatePipelineScheduleVariable(projectID string, scheduleID int, opts *PipelineScheduleVariableOptions) (*gogitlab.PipelineVariable, error) {
+	editOpts := &gogitlab.EditPipelineScheduleVariableOptions{
+		Value: &opts.Value,
+	}
+
+	if opts.VariableType != nil {
+		variableType := gogitlab.VariableTypeValue(*opts.VariableType)
+		editOpts.VariableType = &variableType
+	}
+
+	if err := c.requirePipelineScheduleVariable(projectID, scheduleID, opts.Key); err != nil {
+		return nil, err
+	}
+
+	variable, resp, err := c.client.PipelineSchedules.EditPipelineScheduleVariable(projectID, int64(scheduleID), opts.Key, editOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variable, nil
+}
+
+// DeletePipelineScheduleVariable はパイプラインスケジュールの変数を削除する
+func (c *Client) DeletePipelineScheduleVariable(projectID string, scheduleID int, key string) error {
+	if err := c.requirePipelineScheduleVariable(projectID, scheduleID, key); err != nil {
+		return err
+	}
+
+	_, resp, err := c.client.PipelineSchedules.DeletePipelineScheduleVariable(projectID, int64(scheduleID), key, c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
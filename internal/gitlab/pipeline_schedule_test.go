@@ -0,0 +1,228 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPipelineSchedules_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "active", r.URL.Query().Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{
+				"id":            1,
+				"description":   "Nightly build",
+				"ref":           "main",
+				"cron":          "0 2 * * *",
+				"cron_timezone": "UTC",
+				"active":        true,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	scope := "active"
+	schedules, err := client.ListPipelineSchedules("test-project", &ListPipelineSchedulesOptions{Scope: &scope})
+
+	require.NoError(t, err)
+	assert.Len(t, schedules, 1)
+	assert.Equal(t, int64(1), schedules[0].ID)
+	assert.Equal(t, "Nightly build", schedules[0].Description)
+	assert.Equal(t, "0 2 * * *", schedules[0].Cron)
+}
+
+func TestGetPipelineSchedule_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "404 Not found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	schedule, err := client.GetPipelineSchedule("test-project", 999)
+
+	assert.Nil(t, schedule)
+	assert.Error(t, err)
+	mcpErr, ok := err.(*MCPError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeNotFound, mcpErr.Code)
+}
+
+func TestCreatePipelineSchedule_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "Nightly build", body["description"])
+		assert.Equal(t, "main", body["ref"])
+		assert.Equal(t, "0 2 * * *", body["cron"])
+		assert.Equal(t, "Asia/Tokyo", body["cron_timezone"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":            2,
+			"description":   "Nightly build",
+			"ref":           "main",
+			"cron":          "0 2 * * *",
+			"cron_timezone": "Asia/Tokyo",
+			"active":        true,
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	timezone := "Asia/Tokyo"
+	schedule, err := client.CreatePipelineSchedule("test-project", &CreatePipelineScheduleOptions{
+		Description:  "Nightly build",
+		Ref:          "main",
+		Cron:         "0 2 * * *",
+		CronTimezone: &timezone,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), schedule.ID)
+	assert.Equal(t, "Asia/Tokyo", schedule.CronTimezone)
+}
+
+func TestUpdatePipelineSchedule_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/2", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":     2,
+			"cron":   "0 3 * * *",
+			"active": false,
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	cron := "0 3 * * *"
+	active := false
+	schedule, err := client.UpdatePipelineSchedule("test-project", 2, &UpdatePipelineScheduleOptions{
+		Cron:   &cron,
+		Active: &active,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "0 3 * * *", schedule.Cron)
+	assert.False(t, schedule.Active)
+}
+
+func TestTakeOwnershipOfPipelineSchedule_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/2/take_ownership", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":    2,
+			"owner": map[string]any{"id": 5, "username": "bot"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	schedule, err := client.TakeOwnershipOfPipelineSchedule("test-project", 2)
+
+	require.NoError(t, err)
+	require.NotNil(t, schedule.Owner)
+	assert.Equal(t, "bot", schedule.Owner.Username)
+}
+
+func TestRunPipelineSchedule_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/2/play", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"message": "201 Created"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	err = client.RunPipelineSchedule("test-project", 2)
+
+	assert.NoError(t, err)
+}
+
+func TestDeletePipelineSchedule_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/2", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	err = client.DeletePipelineSchedule("test-project", 2)
+
+	assert.NoError(t, err)
+}
+
+func TestPipelineScheduleVariables_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/2/variables" && r.Method == "POST":
+			json.NewEncoder(w).Encode(map[string]any{"key": "DEPLOY_ENV", "value": "staging", "variable_type": "env_var"})
+		case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/2/variables/DEPLOY_ENV" && r.Method == "PUT":
+			json.NewEncoder(w).Encode(map[string]any{"key": "DEPLOY_ENV", "value": "production", "variable_type": "env_var"})
+		case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/2/variables/DEPLOY_ENV" && r.Method == "DELETE":
+			json.NewEncoder(w).Encode(map[string]any{"key": "DEPLOY_ENV", "value": "production"})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	created, err := client.CreatePipelineScheduleVariable("test-project", 2, &PipelineScheduleVariableOptions{
+		Key:   "DEPLOY_ENV",
+		Value: "staging",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "staging", created.Value)
+
+	updated, err := client.UpdatePipelineScheduleVariable("test-project", 2, &PipelineScheduleVariableOptions{
+		Key:   "DEPLOY_ENV",
+		Value: "production",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "production", updated.Value)
+
+	err = client.DeletePipelineScheduleVariable("test-project", 2, "DEPLOY_ENV")
+	assert.NoError(t, err)
+}
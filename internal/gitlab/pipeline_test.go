@@ -111,6 +111,32 @@ func TestListPipelineJobs_Success(t *testing.T) {
 	assert.Equal(t, "running", jobs[2].Status)
 }
 
+func TestListAllPipelineJobs_Paginates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipelines/100/jobs", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			json.NewEncoder(w).Encode([]map[string]any{{"id": 1, "name": "build"}, {"id": 2, "name": "test"}})
+			return
+		}
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		json.NewEncoder(w).Encode([]map[string]any{{"id": 3, "name": "deploy"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	jobs, err := client.ListAllPipelineJobs("test-project", 100)
+
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+	assert.Equal(t, "deploy", jobs[2].Name)
+}
+
 func TestListPipelineJobs_NotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -356,3 +382,85 @@ func TestRetryJob_Success(t *testing.T) {
 	assert.Equal(t, int64(11), job.ID)
 	assert.Equal(t, "pending", job.Status)
 }
+
+func TestPlayJob_WithVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/jobs/10/play", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		vars, ok := body["job_variables_attributes"].([]any)
+		require.True(t, ok)
+		require.Len(t, vars, 1)
+		assert.Equal(t, "DEPLOY_TARGET", vars[0].(map[string]any)["key"])
+		assert.Equal(t, "production", vars[0].(map[string]any)["value"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":      10,
+			"name":    "deploy",
+			"status":  "pending",
+			"web_url": "https://gitlab.example.com/project/-/jobs/10",
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	job, err := client.PlayJob("test-project", 10, []PipelineVariable{
+		{Key: "DEPLOY_TARGET", Value: "production"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), job.ID)
+	assert.Equal(t, "pending", job.Status)
+}
+
+func TestCancelJob_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/jobs/10/cancel", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":     10,
+			"name":   "build",
+			"status": "canceled",
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	job, err := client.CancelJob("test-project", 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, "canceled", job.Status)
+}
+
+func TestEraseJob_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/jobs/10/erase", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":        10,
+			"name":      "build",
+			"status":    "failed",
+			"erased_at": "2024-01-01T10:00:00Z",
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	job, err := client.EraseJob("test-project", 10)
+
+	require.NoError(t, err)
+	assert.NotNil(t, job.ErasedAt)
+}
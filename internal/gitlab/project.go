@@ -0,0 +1,41 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// SearchProjects はユーザーがメンバーのプロジェクトを名前・パスで検索する
+// 最近更新されたプロジェクトから limit 件を返す
+func (c *Client) SearchProjects(search string, limit int) ([]*gogitlab.Project, error) {
+	opts := &gogitlab.ListProjectsOptions{
+		ListOptions: gogitlab.ListOptions{PerPage: int64(limit)},
+		Membership:  gogitlab.Ptr(true),
+		Simple:      gogitlab.Ptr(true),
+		OrderBy:     gogitlab.Ptr("last_activity_at"),
+	}
+	if search != "" {
+		opts.Search = &search
+	}
+
+	projects, resp, err := c.client.Projects.ListProjects(opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return projects, nil
+}
+
+// SearchProjectUsers はプロジェクトのメンバーを名前・ユーザー名で検索する
+func (c *Client) SearchProjectUsers(projectID, search string, limit int) ([]*gogitlab.ProjectUser, error) {
+	opts := &gogitlab.ListProjectUserOptions{
+		ListOptions: gogitlab.ListOptions{PerPage: int64(limit)},
+	}
+	if search != "" {
+		opts.Search = &search
+	}
+
+	users, resp, err := c.client.Projects.ListProjectsUsers(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return users, nil
+}
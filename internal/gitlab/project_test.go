@@ -0,0 +1,88 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchProjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects", r.URL.Path)
+		assert.Equal(t, "gitlab", r.URL.Query().Get("search"))
+		assert.Equal(t, "true", r.URL.Query().Get("membership"))
+		assert.Equal(t, "true", r.URL.Query().Get("simple"))
+		assert.Equal(t, "last_activity_at", r.URL.Query().Get("order_by"))
+		assert.Equal(t, "20", r.URL.Query().Get("per_page"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 1, "path_with_namespace": "group/gitlab-mcp"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	projects, err := client.SearchProjects("gitlab", 20)
+
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, "group/gitlab-mcp", projects[0].PathWithNamespace)
+}
+
+func TestSearchProjects_EmptySearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, r.URL.Query().Has("search"))
+		json.NewEncoder(w).Encode([]map[string]any{})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	projects, err := client.SearchProjects("", 20)
+
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+}
+
+func TestSearchProjectUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/users", r.URL.Path)
+		assert.Equal(t, "ali", r.URL.Query().Get("search"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 7, "username": "alice", "name": "Alice"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	users, err := client.SearchProjectUsers("test-project", "ali", 20)
+
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "alice", users[0].Username)
+}
+
+func TestSearchProjectUsers_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "404 Project Not Found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	_, err = client.SearchProjectUsers("missing", "", 20)
+
+	mcpErr, ok := err.(*MCPError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeNotFound, mcpErr.Code)
+}
@@ -0,0 +1,30 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// GetRawFile はリポジトリ内のファイルの内容を取得する
+// ref が空の場合はデフォルトブランチのファイルを取得する
+func (c *Client) GetRawFile(projectID, filePath, ref string) ([]byte, error) {
+	opts := &gogitlab.GetRawFileOptions{}
+	if ref != "" {
+		opts.Ref = &ref
+	}
+
+	content, resp, err := c.client.RepositoryFiles.GetRawFile(projectID, filePath, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return content, nil
+}
+
+// GetFileBlobID はリポジトリ内のファイルの blob ID を取得する
+// HEAD リクエストでヘッダーのみを取得するため、ファイルの内容が変わったかを安価に確認できる
+func (c *Client) GetFileBlobID(projectID, filePath, ref string) (string, error) {
+	file, resp, err := c.client.RepositoryFiles.GetFileMetaData(projectID, filePath, &gogitlab.GetFileMetaDataOptions{Ref: &ref}, c.requestOptions()...)
+	if err != nil {
+		return "", FromGitLabResponse(err, resp)
+	}
+	return file.BlobID, nil
+}
@@ -0,0 +1,65 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRawFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/repository/files/.gitattributes/raw", r.URL.Path)
+		assert.Equal(t, "abc123", r.URL.Query().Get("ref"))
+		w.Write([]byte("*.pb.go linguist-generated\n"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	content, err := client.GetRawFile("test-project", ".gitattributes", "abc123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "*.pb.go linguist-generated\n", string(content))
+}
+
+func TestGetRawFile_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "404 File Not Found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	content, err := client.GetRawFile("test-project", ".gitattributes", "")
+
+	assert.Nil(t, content)
+	mcpErr, ok := err.(*MCPError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeNotFound, mcpErr.Code)
+}
+
+func TestGetFileBlobID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		assert.Equal(t, "/api/v4/projects/test-project/repository/files/docs/README.md", r.URL.Path)
+		assert.Equal(t, "main", r.URL.Query().Get("ref"))
+		w.Header().Set("X-Gitlab-Blob-Id", "79f7bbd25901e8334750839545a9bd021f0e4c83")
+		w.Header().Set("X-Gitlab-File-Path", "docs/README.md")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	blobID, err := client.GetFileBlobID("test-project", "docs/README.md", "main")
+
+	require.NoError(t, err)
+	assert.Equal(t, "79f7bbd25901e8334750839545a9bd021f0e4c83", blobID)
+}
@@ -0,0 +1,207 @@
+package gitlab
+
+import (
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// CIVariableOptions は CI/CD 変数の作成・更新のオプション
+type CIVariableOptions struct {
+	Value            *string
+	Description      *string
+	EnvironmentScope *string
+	VariableType     *string
+	Protected        *bool
+	Masked           *bool
+	Raw              *bool
+}
+
+// variableFilter は環境スコープ指定時のフィルタを作成する
+func variableFilter(environmentScope string) *gogitlab.VariableFilter {
+	if environmentScope == "" {
+		return nil
+	}
+	return &gogitlab.VariableFilter{EnvironmentScope: environmentScope}
+}
+
+// variableType は変数タイプの文字列を SDK の型に変換する
+func variableType(s *string) *gogitlab.VariableTypeValue {
+	if s == nil {
+		return nil
+	}
+	v := gogitlab.VariableTypeValue(*s)
+	return &v
+}
+
+// ListProjectVariables はプロジェクトの CI/CD 変数一覧を取得する
+func (c *Client) ListProjectVariables(projectID string, pagination *PaginationOptions) ([]*gogitlab.ProjectVariable, error) {
+	opts := &gogitlab.ListProjectVariablesOptions{ListOptions: listOptions(pagination)}
+
+	variables, resp, err := c.client.ProjectVariables.ListVariables(projectID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variables, nil
+}
+
+// GetProjectVariable はプロジェクトの CI/CD 変数を取得する
+// environmentScope が空の場合はスコープで絞り込まない
+func (c *Client) GetProjectVariable(projectID, key, environmentScope string) (*gogitlab.ProjectVariable, error) {
+	opts := &gogitlab.GetProjectVariableOptions{Filter: variableFilter(environmentScope)}
+
+	variable, resp, err := c.client.ProjectVariables.GetVariable(projectID, key, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variable, nil
+}
+
+// CreateProjectVariable はプロジェクトに CI/CD 変数を作成する
+func (c *Client) CreateProjectVariable(projectID, key string, opts *CIVariableOptions) (*gogitlab.ProjectVariable, error) {
+	createOpts := &gogitlab.CreateProjectVariableOptions{
+		Key:              &key,
+		Value:            opts.Value,
+		Description:      opts.Description,
+		EnvironmentScope: opts.EnvironmentScope,
+		VariableType:     variableType(opts.VariableType),
+		Protected:        opts.Protected,
+		Masked:           opts.Masked,
+		Raw:              opts.Raw,
+	}
+
+	if err := c.requireProject(projectID); err != nil {
+		return nil, err
+	}
+
+	variable, resp, err := c.client.ProjectVariables.CreateVariable(projectID, createOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variable, nil
+}
+
+// UpdateProjectVariable はプロジェクトの CI/CD 変数を更新する
+// environmentScope は更新対象の変数を特定するためのフィルタとして使う
+func (c *Client) UpdateProjectVariable(projectID, key, environmentScope string, opts *CIVariableOptions) (*gogitlab.ProjectVariable, error) {
+	updateOpts := &gogitlab.UpdateProjectVariableOptions{
+		Value:            opts.Value,
+		Description:      opts.Description,
+		EnvironmentScope: opts.EnvironmentScope,
+		Filter:           variableFilter(environmentScope),
+		VariableType:     variableType(opts.VariableType),
+		Protected:        opts.Protected,
+		Masked:           opts.Masked,
+		Raw:              opts.Raw,
+	}
+
+	if err := c.requireProjectVariable(projectID, key, environmentScope); err != nil {
+		return nil, err
+	}
+
+	variable, resp, err := c.client.ProjectVariables.UpdateVariable(projectID, key, updateOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variable, nil
+}
+
+// DeleteProjectVariable はプロジェクトの CI/CD 変数を削除する
+func (c *Client) DeleteProjectVariable(projectID, key, environmentScope string) error {
+	opts := &gogitlab.RemoveProjectVariableOptions{Filter: variableFilter(environmentScope)}
+
+	if err := c.requireProjectVariable(projectID, key, environmentScope); err != nil {
+		return err
+	}
+
+	resp, err := c.client.ProjectVariables.RemoveVariable(projectID, key, opts, c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
+
+// ListGroupVariables はグループの CI/CD 変数一覧を取得する
+func (c *Client) ListGroupVariables(groupID string, pagination *PaginationOptions) ([]*gogitlab.GroupVariable, error) {
+	opts := &gogitlab.ListGroupVariablesOptions{ListOptions: listOptions(pagination)}
+
+	variables, resp, err := c.client.GroupVariables.ListVariables(groupID, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variables, nil
+}
+
+// GetGroupVariable はグループの CI/CD 変数を取得する
+// environmentScope が空の場合はスコープで絞り込まない
+func (c *Client) GetGroupVariable(groupID, key, environmentScope string) (*gogitlab.GroupVariable, error) {
+	opts := &gogitlab.GetGroupVariableOptions{Filter: variableFilter(environmentScope)}
+
+	variable, resp, err := c.client.GroupVariables.GetVariable(groupID, key, opts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variable, nil
+}
+
+// CreateGroupVariable はグループに CI/CD 変数を作成する
+func (c *Client) CreateGroupVariable(groupID, key string, opts *CIVariableOptions) (*gogitlab.GroupVariable, error) {
+	createOpts := &gogitlab.CreateGroupVariableOptions{
+		Key:              &key,
+		Value:            opts.Value,
+		Description:      opts.Description,
+		EnvironmentScope: opts.EnvironmentScope,
+		VariableType:     variableType(opts.VariableType),
+		Protected:        opts.Protected,
+		Masked:           opts.Masked,
+		Raw:              opts.Raw,
+	}
+
+	if err := c.requireGroup(groupID); err != nil {
+		return nil, err
+	}
+
+	variable, resp, err := c.client.GroupVariables.CreateVariable(groupID, createOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variable, nil
+}
+
+// UpdateGroupVariable はグループの CI/CD 変数を更新する
+// environmentScope は更新対象の変数を特定するためのフィルタとして使う
+func (c *Client) UpdateGroupVariable(groupID, key, environmentScope string, opts *CIVariableOptions) (*gogitlab.GroupVariable, error) {
+	updateOpts := &gogitlab.UpdateGroupVariableOptions{
+		Value:            opts.Value,
+		Description:      opts.Description,
+		EnvironmentScope: opts.EnvironmentScope,
+		Filter:           variableFilter(environmentScope),
+		VariableType:     variableType(opts.VariableType),
+		Protected:        opts.Protected,
+		Masked:           opts.Masked,
+		Raw:              opts.Raw,
+	}
+
+	if err := c.requireGroupVariable(groupID, key, environmentScope); err != nil {
+		return nil, err
+	}
+
+	variable, resp, err := c.client.GroupVariables.UpdateVariable(groupID, key, updateOpts, c.requestOptions()...)
+	if err != nil {
+		return nil, FromGitLabResponse(err, resp)
+	}
+	return variable, nil
+}
+
+// DeleteGroupVariable はグループの CI/CD 変数を削除する
+func (c *Client) DeleteGroupVariable(groupID, key, environmentScope string) error {
+	opts := &gogitlab.RemoveGroupVariableOptions{Filter: variableFilter(environmentScope)}
+
+	if err := c.requireGroupVariable(groupID, key, environmentScope); err != nil {
+		return err
+	}
+
+	resp, err := c.client.GroupVariables.RemoveVariable(groupID, key, opts, c.requestOptions()...)
+	if err != nil {
+		return FromGitLabResponse(err, resp)
+	}
+	return nil
+}
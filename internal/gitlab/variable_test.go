@@ -0,0 +1,104 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListProjectVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/variables", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"key": "TOKEN", "value": "secret", "masked": true, "environment_scope": "*"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	variables, err := client.ListProjectVariables("test-project", nil)
+
+	require.NoError(t, err)
+	require.Len(t, variables, 1)
+	assert.Equal(t, "TOKEN", variables[0].Key)
+	assert.True(t, variables[0].Masked)
+}
+
+func TestGetProjectVariable_EnvironmentScope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/variables/DB_URL", r.URL.Path)
+		assert.Equal(t, "production", r.URL.Query().Get("filter[environment_scope]"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"key": "DB_URL", "value": "postgres://", "environment_scope": "production"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	variable, err := client.GetProjectVariable("test-project", "DB_URL", "production")
+
+	require.NoError(t, err)
+	assert.Equal(t, "production", variable.EnvironmentScope)
+}
+
+func TestCreateGroupVariable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/groups/test-group/variables", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "SHARED", body["key"])
+		assert.Equal(t, "file", body["variable_type"])
+		assert.Equal(t, true, body["protected"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"key": "SHARED", "variable_type": "file", "protected": true})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	value := "content"
+	variableType := "file"
+	protected := true
+	variable, err := client.CreateGroupVariable("test-group", "SHARED", &CIVariableOptions{
+		Value:        &value,
+		VariableType: &variableType,
+		Protected:    &protected,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, variable.Protected)
+}
+
+func TestDeleteGroupVariable_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "404 Variable Not Found"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	err = client.DeleteGroupVariable("test-group", "MISSING", "")
+
+	mcpErr, ok := err.(*MCPError)
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeNotFound, mcpErr.Code)
+}
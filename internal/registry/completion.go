@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxCompletionValues は 1 回の補完で返せる候補の最大数（MCP の仕様による上限）
+const maxCompletionValues = 100
+
+// CompletionFor は引数の補完候補を返す関数
+// value は入力途中の値、args はプロンプトやリソーステンプレートで入力済みの他の引数
+// どちらも前後の空白を取り除いて渡される
+type CompletionFor func(ctx context.Context, value string, args map[string]string) ([]string, error)
+
+// RegisterCompletion は names のいずれかの名前を持つ引数の補完を登録する
+// プロンプトの引数とリソーステンプレートの変数を区別せず、名前だけで補完する
+func RegisterCompletion(r *Registry, names []string, complete CompletionFor) {
+	for _, name := range names {
+		r.completions[name] = complete
+	}
+}
+
+// complete は completion/complete を処理する
+// 補完が登録されていない引数や、入力済みのプロジェクトが見つからない場合は候補なしを返す
+func (r *Registry) complete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	empty := &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: []string{}}}
+
+	complete, ok := r.completions[req.Params.Argument.Name]
+	if !ok {
+		return empty, nil
+	}
+
+	args := make(map[string]string)
+	if req.Params.Context != nil {
+		for name, value := range req.Params.Context.Arguments {
+			args[name] = strings.TrimSpace(value)
+		}
+	}
+
+	values, err := complete(ctx, strings.TrimSpace(req.Params.Argument.Value), args)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return empty, nil
+	}
+	if err != nil {
+		return nil, gitlab.FromError(err)
+	}
+	if values == nil {
+		values = []string{}
+	}
+
+	res := &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: values}}
+	if len(values) > maxCompletionValues {
+		res.Completion.Values = values[:maxCompletionValues]
+		res.Completion.Total = len(values)
+		res.Completion.HasMore = true
+	}
+	return res, nil
+}
@@ -0,0 +1,102 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func completeParams(name, value string, args map[string]string) *mcp.CompleteParams {
+	return &mcp.CompleteParams{
+		Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "review_merge_request"},
+		Argument: mcp.CompleteParamsArgument{Name: name, Value: value},
+		Context:  &mcp.CompleteContext{Arguments: args},
+	}
+}
+
+func TestRegisterCompletion(t *testing.T) {
+	reg := New(&config.Config{})
+
+	var gotValue string
+	var gotArgs map[string]string
+	RegisterCompletion(reg, []string{"source_branch", "target_branch"}, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		gotValue, gotArgs = value, args
+		return []string{"main", "feature/login"}, nil
+	})
+
+	session := connectTestClient(t, reg, nil)
+	assert.NotNil(t, session.InitializeResult().Capabilities.Completions)
+
+	res, err := session.Complete(context.Background(), completeParams("target_branch", " ma ", map[string]string{"project_id": " group/project "}))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main", "feature/login"}, res.Completion.Values)
+	assert.False(t, res.Completion.HasMore)
+	assert.Equal(t, "ma", gotValue)
+	assert.Equal(t, map[string]string{"project_id": "group/project"}, gotArgs)
+}
+
+func TestRegisterCompletion_UnknownArgument(t *testing.T) {
+	reg := New(&config.Config{})
+	session := connectTestClient(t, reg, nil)
+
+	res, err := session.Complete(context.Background(), completeParams("focus", "sec", nil))
+
+	require.NoError(t, err)
+	assert.Empty(t, res.Completion.Values)
+}
+
+func TestRegisterCompletion_TooManyValues(t *testing.T) {
+	reg := New(&config.Config{})
+	RegisterCompletion(reg, []string{"label"}, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		values := make([]string, 150)
+		for i := range values {
+			values[i] = fmt.Sprintf("label-%d", i)
+		}
+		return values, nil
+	})
+	session := connectTestClient(t, reg, nil)
+
+	res, err := session.Complete(context.Background(), completeParams("label", "", nil))
+
+	require.NoError(t, err)
+	assert.Len(t, res.Completion.Values, maxCompletionValues)
+	assert.Equal(t, 150, res.Completion.Total)
+	assert.True(t, res.Completion.HasMore)
+}
+
+func TestRegisterCompletion_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "not found returns no values", err: &gitlab.MCPError{Code: gitlab.ErrCodeNotFound, Message: "404 Project Not Found"}},
+		{name: "other errors are returned", err: &gitlab.MCPError{Code: gitlab.ErrCodeForbidden, Message: "403 Forbidden"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := New(&config.Config{})
+			RegisterCompletion(reg, []string{"source_branch"}, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+				return nil, tt.err
+			})
+			session := connectTestClient(t, reg, nil)
+
+			res, err := session.Complete(context.Background(), completeParams("source_branch", "", map[string]string{"project_id": "missing"}))
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, res.Completion.Values)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Confirmation は実行前にユーザーの確認を求めるツールの入力に埋め込む
+// クライアントがエリシテーションに対応していない場合は、モデルがユーザーの同意を得たうえで confirm を true にする
+type Confirmation struct {
+	Confirm bool `json:"confirm,omitempty" jsonschema:"description:Set to true only after the user has explicitly agreed to this operation. Needed when the client cannot ask the user for confirmation itself"`
+}
+
+func (c Confirmation) confirmed() bool {
+	return c.Confirm
+}
+
+// confirmable は Confirmation を埋め込んだ入力
+type confirmable interface {
+	confirmed() bool
+}
+
+// ConfirmationFor は確認のためにユーザーに示す操作の要約を返す
+// 例: "merge !42 'Add billing' into main, squash, delete branch"
+type ConfirmationFor[In any] func(ctx context.Context, input In) (string, error)
+
+// WithConfirmation はツールの実行前にユーザーの確認を求める
+// 確認するかどうかはツールごとに設定の ConfirmTools で決まる
+// クライアントがエリシテーションに対応していれば summary の要約を示して確認し、
+// 対応していなければ入力の confirm が true の場合のみ実行する
+func WithConfirmation[In confirmable](summary ConfirmationFor[In]) ToolOption {
+	return func(s *toolSettings) {
+		s.confirmation = summary
+	}
+}
+
+// confirm はツールの実行前にユーザーの確認を得る
+// エリシテーションで確認できる場合は入力の confirm より優先し、モデルだけの判断では実行しない
+func confirm[In any](ctx context.Context, req *mcp.CallToolRequest, input In, summarize ConfirmationFor[In]) error {
+	summary, err := summarize(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if !canElicit(req) {
+		if c, ok := any(input).(confirmable); ok && c.confirmed() {
+			return nil
+		}
+		return gitlab.NewConfirmationRequiredError(summary)
+	}
+
+	res, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+		Message:         gitlab.Msg(gitlab.MsgConfirmOperation, summary),
+		RequestedSchema: &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{}},
+	})
+	if err != nil {
+		return gitlab.FromError(err)
+	}
+	if res.Action != "accept" {
+		return gitlab.NewDeclinedError(summary)
+	}
+	return nil
+}
+
+// canElicit はクライアントがエリシテーションに対応しているかを返す
+func canElicit(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
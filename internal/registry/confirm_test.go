@@ -0,0 +1,168 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type confirmTestInput struct {
+	PipelineID int `json:"pipeline_id"`
+	Confirmation
+}
+
+type confirmTestOutput struct {
+	Cancelled bool `json:"cancelled"`
+}
+
+// registerConfirmTestTool は cancel_pipeline を模したツールを登録し、ハンドラーが呼ばれたかを返す関数を返す
+func registerConfirmTestTool(reg *Registry) func() bool {
+	called := false
+	RegisterTool(reg, "cancel_pipeline", "cancel",
+		func(ctx context.Context, req *mcp.CallToolRequest, input confirmTestInput) (*mcp.CallToolResult, confirmTestOutput, error) {
+			called = true
+			return nil, confirmTestOutput{Cancelled: true}, nil
+		}, WithConfirmation(func(ctx context.Context, input confirmTestInput) (string, error) {
+			return "cancel pipeline #12 on main (running)", nil
+		}))
+	return func() bool { return called }
+}
+
+// toolErrorCode はツールの結果のエラーコードを返す
+func toolErrorCode(t *testing.T, res *mcp.CallToolResult) gitlab.ErrorCode {
+	t.Helper()
+
+	require.True(t, res.IsError)
+	require.Len(t, res.Content, 1)
+	var content toolErrorContent
+	require.NoError(t, json.Unmarshal([]byte(res.Content[0].(*mcp.TextContent).Text), &content))
+	return content.Error.Code
+}
+
+func TestWithConfirmation_WithoutElicitation(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       map[string]any
+		wantCalled bool
+		wantCode   gitlab.ErrorCode
+	}{
+		{name: "requires confirm", args: map[string]any{"pipeline_id": 12}, wantCode: gitlab.ErrCodeConfirmationRequired},
+		{name: "confirmed by the model", args: map[string]any{"pipeline_id": 12, "confirm": true}, wantCalled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := New(&config.Config{})
+			called := registerConfirmTestTool(reg)
+			session := connectTestClient(t, reg, nil)
+
+			res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "cancel_pipeline", Arguments: tt.args})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCalled, called())
+			if tt.wantCode != "" {
+				assert.Equal(t, tt.wantCode, toolErrorCode(t, res))
+				assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "cancel pipeline #12 on main (running)")
+			} else {
+				assert.False(t, res.IsError)
+			}
+		})
+	}
+}
+
+func TestWithConfirmation_Elicitation(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     string
+		args       map[string]any
+		wantCalled bool
+		wantCode   gitlab.ErrorCode
+	}{
+		{name: "accepted", action: "accept", args: map[string]any{"pipeline_id": 12}, wantCalled: true},
+		{name: "declined", action: "decline", args: map[string]any{"pipeline_id": 12}, wantCode: gitlab.ErrCodeDeclined},
+		{name: "cancelled", action: "cancel", args: map[string]any{"pipeline_id": 12}, wantCode: gitlab.ErrCodeDeclined},
+		{name: "confirm does not skip the question", action: "decline", args: map[string]any{"pipeline_id": 12, "confirm": true}, wantCode: gitlab.ErrCodeDeclined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := New(&config.Config{})
+			called := registerConfirmTestTool(reg)
+
+			var message string
+			session := connectTestClient(t, reg, &mcp.ClientOptions{
+				ElicitationHandler: func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+					message = req.Params.Message
+					return &mcp.ElicitResult{Action: tt.action}, nil
+				},
+			})
+
+			res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "cancel_pipeline", Arguments: tt.args})
+
+			require.NoError(t, err)
+			assert.Contains(t, message, "cancel pipeline #12 on main (running)")
+			assert.Equal(t, tt.wantCalled, called())
+			if tt.wantCode != "" {
+				assert.Equal(t, tt.wantCode, toolErrorCode(t, res))
+			} else {
+				assert.False(t, res.IsError)
+			}
+		})
+	}
+}
+
+func TestWithConfirmation_NotConfigured(t *testing.T) {
+	reg := New(&config.Config{ConfirmTools: []string{"delete_issue"}})
+	called := registerConfirmTestTool(reg)
+
+	elicited := false
+	session := connectTestClient(t, reg, &mcp.ClientOptions{
+		ElicitationHandler: func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			elicited = true
+			return &mcp.ElicitResult{Action: "decline"}, nil
+		},
+	})
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "cancel_pipeline", Arguments: map[string]any{"pipeline_id": 12}})
+
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+	assert.True(t, called())
+	assert.False(t, elicited)
+}
+
+func TestWithConfirmation_SummaryError(t *testing.T) {
+	reg := New(&config.Config{})
+	called := false
+	RegisterTool(reg, "delete_issue", "delete",
+		func(ctx context.Context, req *mcp.CallToolRequest, input confirmTestInput) (*mcp.CallToolResult, confirmTestOutput, error) {
+			called = true
+			return nil, confirmTestOutput{}, nil
+		}, WithConfirmation(func(ctx context.Context, input confirmTestInput) (string, error) {
+			return "", &gitlab.MCPError{Code: gitlab.ErrCodeNotFound, Message: "404 Issue Not Found"}
+		}))
+	session := connectTestClient(t, reg, nil)
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "delete_issue", Arguments: map[string]any{"pipeline_id": 1, "confirm": true}})
+
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.ErrCodeNotFound, toolErrorCode(t, res))
+	assert.False(t, called)
+}
+
+func TestUnconfirmableTools(t *testing.T) {
+	reg := New(&config.Config{ConfirmTools: []string{"cancel_pipeline", "list_issues", "no_such_tool"}})
+	registerConfirmTestTool(reg)
+	RegisterTool(reg, "list_issues", "list",
+		func(ctx context.Context, req *mcp.CallToolRequest, input confirmTestInput) (*mcp.CallToolResult, confirmTestOutput, error) {
+			return nil, confirmTestOutput{}, nil
+		})
+
+	assert.Equal(t, []string{"list_issues", "no_such_tool"}, reg.UnconfirmableTools())
+}
@@ -0,0 +1,69 @@
+package registry
+
+import (
+	"encoding/json"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DryRunOption は書き込みを行うツールの入力に埋め込む
+// dry_run が true の場合は GitLab を変更せず、入力と対象の存在を検証して送信するはずだったリクエストを返す
+type DryRunOption struct {
+	DryRun bool `json:"dry_run,omitempty" jsonschema:"description:Validate the input and that the target exists, then return the requests that would be sent without sending them"`
+}
+
+func (d DryRunOption) dryRunRequested() bool {
+	return d.DryRun
+}
+
+// dryRunnable は DryRunOption を埋め込んだ入力
+type dryRunnable interface {
+	dryRunRequested() bool
+}
+
+// isDryRun はツールの呼び出しがドライランかどうかを返す
+// DryRunOption を埋め込んだ書き込みのツールは、GITLAB_MCP_DRY_RUN が有効な場合は入力によらずドライランになる
+func (r *Registry) isDryRun(input any) bool {
+	d, ok := input.(dryRunnable)
+	return ok && (r.config.DryRun || d.dryRunRequested())
+}
+
+// dryRunOutput はドライランの結果の構造化された内容
+// ドライランではハンドラーの出力は空のレスポンスから模擬したもので意味がないため、ツールの出力の代わりに返す
+type dryRunOutput struct {
+	DryRun   bool                   `json:"dry_run" jsonschema:"description:Always true. Nothing was sent to GitLab"`
+	Message  string                 `json:"message"`
+	Requests []gitlab.DryRunRequest `json:"dry_run_requests" jsonschema:"description:Write requests that would have been sent, in order"`
+}
+
+// withDryRunOutput は DryRunOption を埋め込んだ入力を受け付けるツールの出力スキーマに、ドライランの結果を加える
+// MCP の出力スキーマは object である必要があるため、anyOf を object のスキーマで包む
+func withDryRunOutput(schema *jsonschema.Schema) (*jsonschema.Schema, error) {
+	dryRun, err := schemaFor[dryRunOutput]()
+	if err != nil {
+		return nil, err
+	}
+	dryRun.Properties["dry_run"].Const = jsonschema.Ptr[any](true)
+	return &jsonschema.Schema{Type: "object", AnyOf: []*jsonschema.Schema{schema, dryRun}}, nil
+}
+
+// dryRunResult はドライランで記録した書き込みのリクエストをすべて含む成功の結果を返す
+func dryRunResult(plan *gitlab.DryRunPlan) *mcp.CallToolResult {
+	requests := plan.Requests()
+	if requests == nil {
+		requests = []gitlab.DryRunRequest{}
+	}
+	output := dryRunOutput{DryRun: true, Message: gitlab.Msg(gitlab.MsgDryRun, len(requests)), Requests: requests}
+	data, err := json.Marshal(output)
+	if err != nil {
+		data = []byte(output.Message)
+	}
+
+	return &mcp.CallToolResult{
+		Meta:              mcp.Meta{"dry_run": true},
+		Content:           []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		StructuredContent: output,
+	}
+}
@@ -0,0 +1,149 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dryRunTestInput struct {
+	PipelineID int `json:"pipeline_id"`
+	Confirmation
+	DryRunOption
+}
+
+func TestDryRun_SkipsConfirmation(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     *config.Config
+		args       map[string]any
+		wantCalled bool
+	}{
+		{name: "asks without dry run", config: &config.Config{}, args: map[string]any{"pipeline_id": 12}},
+		{name: "dry_run input", config: &config.Config{}, args: map[string]any{"pipeline_id": 12, "dry_run": true}, wantCalled: true},
+		{name: "GITLAB_MCP_DRY_RUN", config: &config.Config{DryRun: true}, args: map[string]any{"pipeline_id": 12}, wantCalled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := New(tt.config)
+			var got dryRunTestInput
+			called := false
+			RegisterTool(reg, "cancel_pipeline", "cancel",
+				func(ctx context.Context, req *mcp.CallToolRequest, input dryRunTestInput) (*mcp.CallToolResult, confirmTestOutput, error) {
+					called, got = true, input
+					return nil, confirmTestOutput{}, nil
+				}, WithConfirmation(func(ctx context.Context, input dryRunTestInput) (string, error) {
+					return "cancel pipeline #12", nil
+				}))
+
+			elicited := false
+			session := connectTestClient(t, reg, &mcp.ClientOptions{
+				ElicitationHandler: func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+					elicited = true
+					return &mcp.ElicitResult{Action: "decline"}, nil
+				},
+			})
+
+			_, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "cancel_pipeline", Arguments: tt.args})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCalled, called)
+			assert.Equal(t, !tt.wantCalled, elicited)
+			if tt.wantCalled {
+				assert.Equal(t, tt.args["dry_run"] == true, got.DryRun)
+			}
+		})
+	}
+}
+
+func TestDryRun_ReturnsPlannedRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 1, "path_with_namespace": "test-project"})
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	reg := New(&config.Config{})
+	RegisterTool(reg, "create_issue", "create",
+		func(ctx context.Context, req *mcp.CallToolRequest, input dryRunTestInput) (*mcp.CallToolResult, confirmTestOutput, error) {
+			dryRun := client.WithDryRun(ctx)
+			issue, err := dryRun.CreateIssue("test-project", &gitlab.CreateIssueOptions{Title: "Broken build"})
+			if err != nil {
+				return nil, confirmTestOutput{}, err
+			}
+			if _, err := dryRun.CreateIssueNote("test-project", int(issue.IID), "Reproduced on main"); err != nil {
+				return nil, confirmTestOutput{}, err
+			}
+			return nil, confirmTestOutput{}, nil
+		})
+
+	session := connectTestClient(t, reg, nil)
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "create_issue",
+		Arguments: map[string]any{"pipeline_id": 12, "dry_run": true},
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, true, result.Meta["dry_run"])
+
+	// 構造化された内容はツールの出力ではなくドライランの結果で、出力スキーマに従う
+	data, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	var output dryRunOutput
+	require.NoError(t, json.Unmarshal(data, &output))
+	assert.True(t, output.DryRun)
+	assert.Contains(t, output.Message, "2")
+	require.Len(t, output.Requests, 2)
+	assert.Equal(t, server.URL+"/api/v4/projects/test-project/issues", output.Requests[0].URL)
+	assert.Equal(t, server.URL+"/api/v4/projects/test-project/issues/0/notes", output.Requests[1].URL)
+	assert.JSONEq(t, string(data), result.Content[0].(*mcp.TextContent).Text)
+
+	tools, err := session.ListTools(context.Background(), nil)
+	require.NoError(t, err)
+	schemaData, err := json.Marshal(tools.Tools[0].OutputSchema)
+	require.NoError(t, err)
+	var schema jsonschema.Schema
+	require.NoError(t, json.Unmarshal(schemaData, &schema))
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	var structured map[string]any
+	require.NoError(t, json.Unmarshal(data, &structured))
+	assert.NoError(t, resolved.Validate(structured))
+	assert.Error(t, resolved.Validate(map[string]any{"dry_run": false, "message": "", "dry_run_requests": []any{}}))
+}
+
+func TestDryRun_ReadOnlyToolsRunAsUsual(t *testing.T) {
+	client, err := gitlab.NewClient("https://gitlab.example.com", "test-token")
+	require.NoError(t, err)
+
+	reg := New(&config.Config{DryRun: true})
+	dryRun := true
+	RegisterTool(reg, "get_pipeline", "get",
+		func(ctx context.Context, req *mcp.CallToolRequest, input confirmTestInput) (*mcp.CallToolResult, confirmTestOutput, error) {
+			dryRun = client.WithDryRun(ctx).IsDryRun()
+			return nil, confirmTestOutput{}, nil
+		}, WithReadOnly())
+
+	session := connectTestClient(t, reg, nil)
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "get_pipeline", Arguments: map[string]any{"pipeline_id": 12}})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.False(t, dryRun)
+	assert.Nil(t, result.Meta["dry_run"])
+}
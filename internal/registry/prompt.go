@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PromptArgs はプロンプトの引数
+type PromptArgs map[string]string
+
+// Int は整数の引数の値を返す
+func (a PromptArgs) Int(name string) (int, error) {
+	v, err := strconv.Atoi(a[name])
+	if err != nil || v <= 0 {
+		return 0, &gitlab.MCPError{
+			Code:    gitlab.ErrCodeBadRequest,
+			Message: gitlab.Msg(gitlab.MsgInvalidPromptArgument, name, a[name]),
+		}
+	}
+	return v, nil
+}
+
+// PromptHandlerFor はプロンプトのハンドラー
+// args には前後の空白を取り除いた引数が渡される
+type PromptHandlerFor func(ctx context.Context, req *mcp.GetPromptRequest, args PromptArgs) (*mcp.GetPromptResult, error)
+
+// RegisterPrompt はプロンプトを登録する
+// 必須の引数が指定されていない場合はハンドラーを呼び出さずにエラーを返す
+func RegisterPrompt(r *Registry, prompt *mcp.Prompt, handler PromptHandlerFor) {
+	r.server.AddPrompt(prompt, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := make(PromptArgs, len(req.Params.Arguments))
+		for name, value := range req.Params.Arguments {
+			args[name] = strings.TrimSpace(value)
+		}
+		for _, arg := range prompt.Arguments {
+			if arg.Required && args[arg.Name] == "" {
+				return nil, &gitlab.MCPError{
+					Code:    gitlab.ErrCodeBadRequest,
+					Message: gitlab.Msg(gitlab.MsgMissingPromptArgument, arg.Name),
+				}
+			}
+		}
+
+		res, err := handler(ctx, req, args)
+		if err != nil {
+			return nil, gitlab.FromError(err)
+		}
+		return res, nil
+	})
+}
+
+// UserPrompt は text をユーザーのメッセージとするプロンプトの結果を作成する
+func UserPrompt(description, text string) *mcp.GetPromptResult {
+	return &mcp.GetPromptResult{
+		Description: description,
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: text}},
+		},
+	}
+}
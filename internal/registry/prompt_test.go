@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterPrompt(t *testing.T) {
+	reg := New(&config.Config{})
+
+	var got PromptArgs
+	RegisterPrompt(reg, &mcp.Prompt{
+		Name: "summarize",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "project_id", Required: true},
+			{Name: "issue_iid", Required: true},
+			{Name: "style"},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest, args PromptArgs) (*mcp.GetPromptResult, error) {
+		got = args
+		if args["issue_iid"] == "999" {
+			return nil, &gitlab.MCPError{Code: gitlab.ErrCodeNotFound, Message: "The requested resource was not found"}
+		}
+		return UserPrompt("Summary", "Summarize the issue"), nil
+	})
+
+	session := connectTestClient(t, reg, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("returns user message", func(t *testing.T) {
+		res, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+			Name:      "summarize",
+			Arguments: map[string]string{"project_id": " group/project ", "issue_iid": "1"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, PromptArgs{"project_id": "group/project", "issue_iid": "1"}, got)
+		assert.Equal(t, "Summary", res.Description)
+		require.Len(t, res.Messages, 1)
+		assert.Equal(t, mcp.Role("user"), res.Messages[0].Role)
+		text, ok := res.Messages[0].Content.(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "Summarize the issue", text.Text)
+	})
+
+	t.Run("missing required argument", func(t *testing.T) {
+		got = nil
+		_, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+			Name:      "summarize",
+			Arguments: map[string]string{"project_id": "1", "issue_iid": " "},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Missing required prompt argument: issue_iid")
+		assert.Nil(t, got)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		_, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+			Name:      "summarize",
+			Arguments: map[string]string{"project_id": "1", "issue_iid": "999"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestPromptArgs_Int(t *testing.T) {
+	args := PromptArgs{"iid": "7", "bad": "seven"}
+
+	v, err := args.Int("iid")
+	require.NoError(t, err)
+	assert.Equal(t, 7, v)
+
+	_, err = args.Int("bad")
+	assert.ErrorIs(t, err, gitlab.ErrBadRequest)
+
+	_, err = args.Int("missing")
+	assert.ErrorIs(t, err, gitlab.ErrBadRequest)
+}
@@ -2,6 +2,8 @@ package registry
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
 	"github.com/kqns91/gitlab-mcp/internal/config"
 	"github.com/kqns91/gitlab-mcp/internal/gitlab"
@@ -10,26 +12,49 @@ import (
 
 // Registry はツールの登録と管理を行う
 type Registry struct {
-	server          *mcp.Server
-	config          *config.Config
-	registeredTools map[string]bool
+	server            *mcp.Server
+	config            *config.Config
+	registeredTools   map[string]bool
+	confirmableTools  map[string]bool
+	resourceTemplates []*resourceTemplate
+	poller            *poller
+	completions       map[string]CompletionFor
 }
 
 // New は新しい Registry を作成する
+// 購読されたリソースは設定の PollInterval ごとに PollRateLimit の範囲で変更を確認する
 func New(cfg *config.Config) *Registry {
-	server := mcp.NewServer(
+	r := &Registry{
+		config:           cfg,
+		registeredTools:  make(map[string]bool),
+		confirmableTools: make(map[string]bool),
+		completions:      make(map[string]CompletionFor),
+	}
+
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = config.DefaultPollInterval
+	}
+	rateLimit := cfg.PollRateLimit
+	if rateLimit <= 0 {
+		rateLimit = config.DefaultPollRateLimit
+	}
+	r.poller = newPoller(interval, rateLimit, func() []*resourceTemplate { return r.resourceTemplates })
+
+	r.server = mcp.NewServer(
 		&mcp.Implementation{
 			Name:    "gitlab-mcp",
 			Version: "1.0.0",
 		},
-		nil,
+		&mcp.ServerOptions{
+			SubscribeHandler:   r.poller.subscribe,
+			UnsubscribeHandler: r.poller.unsubscribe,
+			CompletionHandler:  r.complete,
+		},
 	)
+	r.poller.server = r.server
 
-	return &Registry{
-		server:          server,
-		config:          cfg,
-		registeredTools: make(map[string]bool),
-	}
+	return r
 }
 
 // Server は MCP サーバーを返す
@@ -37,6 +62,11 @@ func (r *Registry) Server() *mcp.Server {
 	return r.server
 }
 
+// Config はサーバーの設定を返す
+func (r *Registry) Config() *config.Config {
+	return r.config
+}
+
 // IsRegistered はツールが登録されているかを返す
 func (r *Registry) IsRegistered(toolName string) bool {
 	return r.registeredTools[toolName]
@@ -55,6 +85,18 @@ func (r *Registry) CheckToolEnabled(toolName string) error {
 	return nil
 }
 
+// UnconfirmableTools は設定の ConfirmTools のうち、確認を求められないツールの名前を返す
+// 存在しないツールや WithConfirmation なしで登録されたツールは、設定しても確認されない
+func (r *Registry) UnconfirmableTools() []string {
+	var names []string
+	for _, name := range r.config.ConfirmToolNames() {
+		if !r.confirmableTools[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // GetEnabledTools は有効なツールの名前リストを返す
 func (r *Registry) GetEnabledTools() []string {
 	var enabled []string
@@ -69,25 +111,110 @@ func (r *Registry) GetEnabledTools() []string {
 // ToolHandlerFor is a type alias for MCP tool handlers
 type ToolHandlerFor[In, Out any] func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error)
 
+// toolSettings はツールの登録時の設定
+type toolSettings struct {
+	tool *mcp.Tool
+	// confirmation は実行前の確認で示す操作の要約を返す関数（ConfirmationFor[In]）
+	confirmation any
+}
+
+// ToolOption はツール定義を変更するオプション
+type ToolOption func(*toolSettings)
+
+// WithReadOnly はツールが読み取り専用であることを示すアノテーションを付与する
+func WithReadOnly() ToolOption {
+	return func(s *toolSettings) {
+		if s.tool.Annotations == nil {
+			s.tool.Annotations = &mcp.ToolAnnotations{}
+		}
+		s.tool.Annotations.ReadOnlyHint = true
+	}
+}
+
+// WithDestructive はツールが破壊的な操作を行うことを示すアノテーションを付与する
+func WithDestructive() ToolOption {
+	return func(s *toolSettings) {
+		if s.tool.Annotations == nil {
+			s.tool.Annotations = &mcp.ToolAnnotations{}
+		}
+		destructive := true
+		s.tool.Annotations.DestructiveHint = &destructive
+	}
+}
+
 // RegisterTool は新しいツールを登録する
 // ツールが無効化されている場合でも登録はするが、呼び出し時にチェックされる
-func RegisterTool[In, Out any](r *Registry, name, description string, handler ToolHandlerFor[In, Out]) {
+// 入出力のスキーマは In・Out の型と jsonschema タグから生成する（タグの形式は schemaTagKeys を参照）
+// ハンドラーが返したエラーは構造化された詳細を含む IsError の結果としてクライアントに返す
+func RegisterTool[In, Out any](r *Registry, name, description string, handler ToolHandlerFor[In, Out], opts ...ToolOption) {
 	r.registeredTools[name] = true
 
-	// Wrap handler to check if tool is enabled
+	settings := &toolSettings{
+		tool: &mcp.Tool{
+			Name:        name,
+			Description: description,
+		},
+	}
+	for _, opt := range opts {
+		opt(settings)
+	}
+	var summarize ConfirmationFor[In]
+	if settings.confirmation != nil {
+		var ok bool
+		if summarize, ok = settings.confirmation.(ConfirmationFor[In]); !ok {
+			panic(fmt.Errorf("RegisterTool %q: confirmation does not take %v", name, reflect.TypeFor[In]()))
+		}
+		r.confirmableTools[name] = true
+	}
+
+	// Wrap handler to check if tool is enabled and, if configured, ask the user to confirm
+	// A dry run changes nothing, so it does not ask. It records the writes in a plan and returns them
 	wrappedHandler := func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		var zero Out
 		if err := r.CheckToolEnabled(name); err != nil {
-			var zero Out
 			return nil, zero, err
 		}
-		return handler(ctx, req, input)
+		if !r.isDryRun(input) {
+			if summarize != nil && r.config.RequiresConfirmation(name) {
+				if err := confirm(ctx, req, input, summarize); err != nil {
+					return nil, zero, err
+				}
+			}
+			return handler(ctx, req, input)
+		}
+
+		plan := gitlab.NewDryRunPlan()
+		if _, _, err := handler(gitlab.ContextWithDryRunPlan(ctx, plan), req, input); err != nil {
+			return nil, zero, err
+		}
+		return dryRunResult(plan), zero, nil
 	}
 
 	// Only add to server if enabled (to exclude from tools/list)
 	if r.config.IsToolEnabled(name) {
-		mcp.AddTool(r.server, &mcp.Tool{
-			Name:        name,
-			Description: description,
-		}, wrappedHandler)
+		tool := settings.tool
+		inputSchema, err := schemaFor[In]()
+		if err != nil {
+			panic(fmt.Errorf("RegisterTool %q: input schema: %w", name, err))
+		}
+		tool.InputSchema = inputSchema
+		hasOutput := reflect.TypeFor[Out]() != reflect.TypeFor[any]()
+		if hasOutput {
+			outputSchema, err := schemaFor[Out]()
+			if err != nil {
+				panic(fmt.Errorf("RegisterTool %q: output schema: %w", name, err))
+			}
+			if _, ok := any(*new(In)).(dryRunnable); ok {
+				if outputSchema, err = withDryRunOutput(outputSchema); err != nil {
+					panic(fmt.Errorf("RegisterTool %q: output schema: %w", name, err))
+				}
+			}
+			tool.OutputSchema = outputSchema
+		}
+		toolHandler, err := newToolHandler(inputSchema, hasOutput, wrappedHandler)
+		if err != nil {
+			panic(fmt.Errorf("RegisterTool %q: %w", name, err))
+		}
+		r.server.AddTool(tool, toolHandler)
 	}
 }
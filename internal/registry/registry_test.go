@@ -126,7 +126,7 @@ func TestRegistry_CheckToolEnabled_Error(t *testing.T) {
 	err := reg.CheckToolEnabled("disabled_tool")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "disabled_tool")
-	assert.Contains(t, err.Error(), "無効")
+	assert.Contains(t, err.Error(), "disabled")
 
 	// Should return nil for enabled tool
 	RegisterTool(reg, "enabled_tool", "Enabled tool", dummyHandler)
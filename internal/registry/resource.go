@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+// ResourceParams は URI テンプレートの変数の値
+type ResourceParams map[string]string
+
+// Int は整数の変数の値を返す
+func (p ResourceParams) Int(name string) (int, error) {
+	v, err := strconv.Atoi(p[name])
+	if err != nil || v <= 0 {
+		return 0, &gitlab.MCPError{
+			Code:    gitlab.ErrCodeBadRequest,
+			Message: gitlab.Msg(gitlab.MsgInvalidResourceParam, name, p[name]),
+		}
+	}
+	return v, nil
+}
+
+// ResourceHandlerFor はリソーステンプレートのハンドラー
+// params には読み取る URI から取り出したテンプレートの変数が渡される
+type ResourceHandlerFor func(ctx context.Context, req *mcp.ReadResourceRequest, params ResourceParams) (*mcp.ReadResourceResult, error)
+
+// ResourceVersionFor はリソースの変更を検出するためのバージョン（updated_at や blob ID など）を返す
+// 前回と異なる値を返した場合にリソースが更新されたとみなす
+type ResourceVersionFor func(ctx context.Context, params ResourceParams) (string, error)
+
+// ResourceOption はリソーステンプレートの登録を変更するオプション
+type ResourceOption func(*resourceTemplate)
+
+// WithSubscription はリソースの購読を可能にする
+// 購読中のリソースは version でポーリングし、変更があればクライアントに通知する
+func WithSubscription(version ResourceVersionFor) ResourceOption {
+	return func(t *resourceTemplate) {
+		t.version = version
+	}
+}
+
+// resourceTemplate は登録済みのリソーステンプレート
+type resourceTemplate struct {
+	tmpl    *uritemplate.Template
+	version ResourceVersionFor
+}
+
+// match は uri がテンプレートに一致する場合に変数の値を返す
+func (t *resourceTemplate) match(uri string) (ResourceParams, bool) {
+	values := t.tmpl.Match(uri)
+	if values == nil {
+		return nil, false
+	}
+
+	params := make(ResourceParams, len(t.tmpl.Varnames()))
+	for _, name := range t.tmpl.Varnames() {
+		params[name] = values.Get(name).String()
+	}
+	return params, true
+}
+
+// RegisterResourceTemplate はリソーステンプレートを登録する
+// GitLab で見つからないリソースはリソースが存在しないエラーとしてクライアントに返す
+func RegisterResourceTemplate(r *Registry, template *mcp.ResourceTemplate, handler ResourceHandlerFor, opts ...ResourceOption) {
+	tmpl, err := uritemplate.New(template.URITemplate)
+	if err != nil {
+		panic(fmt.Errorf("RegisterResourceTemplate %q: %w", template.URITemplate, err))
+	}
+
+	rt := &resourceTemplate{tmpl: tmpl}
+	for _, opt := range opts {
+		opt(rt)
+	}
+	r.resourceTemplates = append(r.resourceTemplates, rt)
+
+	r.server.AddResourceTemplate(template, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		params, ok := rt.match(uri)
+		if !ok {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+
+		res, err := handler(ctx, req, params)
+		if errors.Is(err, gitlab.ErrNotFound) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		if err != nil {
+			return nil, gitlab.FromError(err)
+		}
+		return res, nil
+	})
+}
+
+// JSONResource は v を JSON にしたリソースの内容を作成する
+func JSONResource(uri string, v any) (*mcp.ReadResourceResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: "application/json", Text: string(data)}},
+	}, nil
+}
@@ -0,0 +1,107 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func connectTestClient(t *testing.T, reg *Registry, opts *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- reg.Server().Run(ctx, serverTransport)
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, opts)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		session.Close()
+		cancel()
+		<-done
+	})
+	return session
+}
+
+func TestRegisterResourceTemplate(t *testing.T) {
+	reg := New(&config.Config{})
+
+	var got ResourceParams
+	RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "file",
+		URITemplate: "gitlab://{project}/files/{ref}/{+path}",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params ResourceParams) (*mcp.ReadResourceResult, error) {
+		got = params
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: req.Params.URI, Text: "ok"}},
+		}, nil
+	})
+
+	session := connectTestClient(t, reg, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{
+		URI: "gitlab://group%2Fproject/files/feature%2Fx/docs/README.md",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	assert.Equal(t, "ok", res.Contents[0].Text)
+	assert.Equal(t, ResourceParams{
+		"project": "group/project",
+		"ref":     "feature/x",
+		"path":    "docs/README.md",
+	}, got)
+}
+
+func TestRegisterResourceTemplate_NotFound(t *testing.T) {
+	reg := New(&config.Config{})
+
+	RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "issue",
+		URITemplate: "gitlab://{project}/issues/{iid}",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params ResourceParams) (*mcp.ReadResourceResult, error) {
+		return nil, &gitlab.MCPError{Code: gitlab.ErrCodeNotFound, Message: "not found"}
+	})
+
+	session := connectTestClient(t, reg, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gitlab://1/issues/999"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestResourceParams_Int(t *testing.T) {
+	params := ResourceParams{"iid": "42", "bad": "abc", "zero": "0"}
+
+	v, err := params.Int("iid")
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	_, err = params.Int("bad")
+	assert.ErrorIs(t, err, gitlab.ErrBadRequest)
+
+	_, err = params.Int("zero")
+	assert.ErrorIs(t, err, gitlab.ErrBadRequest)
+}
+
+func TestJSONResource(t *testing.T) {
+	res, err := JSONResource("gitlab://1/issues/1", map[string]int{"iid": 1})
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	assert.Equal(t, "application/json", res.Contents[0].MIMEType)
+	assert.JSONEq(t, `{"iid":1}`, res.Contents[0].Text)
+}
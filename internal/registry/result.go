@@ -0,0 +1,106 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolErrorContent はエラー時の CallToolResult の TextContent に JSON で入る内容
+type toolErrorContent struct {
+	Error gitlab.ErrorDetail `json:"error"`
+}
+
+// newToolHandler は型付きのハンドラーを MCP サーバーに登録するハンドラーに変換する
+// 入力はスキーマで検証してから In にデコードし、出力は StructuredContent と TextContent に設定する
+// ハンドラーのエラーは JSON-RPC のエラーではなく、構造化された詳細を含む IsError の結果として返す
+func newToolHandler[In, Out any](inputSchema *jsonschema.Schema, hasOutput bool, handler ToolHandlerFor[In, Out]) (mcp.ToolHandler, error) {
+	resolved, err := inputSchema.Resolve(&jsonschema.ResolveOptions{ValidateDefaults: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		in, err := decodeInput[In](req.Params.Arguments, resolved)
+		if err != nil {
+			return errorResult(err), nil
+		}
+
+		res, out, err := handler(ctx, req, in)
+		if err != nil {
+			return errorResult(err), nil
+		}
+		if res == nil {
+			res = &mcp.CallToolResult{}
+		}
+		// ドライランの結果など、ハンドラーが構造化された内容を設定した場合はそのまま返す
+		if !hasOutput || res.StructuredContent != nil {
+			return res, nil
+		}
+
+		data, err := json.Marshal(out)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling output: %w", err)
+		}
+		res.StructuredContent = json.RawMessage(data)
+		if res.Content == nil {
+			res.Content = []mcp.Content{&mcp.TextContent{Text: string(data)}}
+		}
+		return res, nil
+	}, nil
+}
+
+// decodeInput はツールの引数をスキーマで検証して In にデコードする
+func decodeInput[In any](args json.RawMessage, resolved *jsonschema.Resolved) (In, error) {
+	var in In
+	invalid := func(err error) (In, error) {
+		return in, &gitlab.MCPError{
+			Code:    gitlab.ErrCodeBadRequest,
+			Message: gitlab.Msg(gitlab.MsgInvalidArguments, err),
+		}
+	}
+
+	v := make(map[string]any)
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &v); err != nil {
+			return invalid(err)
+		}
+	}
+	if err := resolved.ApplyDefaults(&v); err != nil {
+		return invalid(err)
+	}
+	if err := resolved.Validate(&v); err != nil {
+		return invalid(err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return invalid(err)
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return invalid(err)
+	}
+	return in, nil
+}
+
+// errorResult はエラーを IsError の CallToolResult に変換する
+// MCPError 以外のエラーは gitlab.FromError でキャンセル・タイムアウトなどに分類する
+// StructuredContent はツールの出力スキーマに従う必要があるため設定せず、詳細は TextContent の JSON で返す
+func errorResult(err error) *mcp.CallToolResult {
+	mcpErr := gitlab.FromError(err)
+
+	content := toolErrorContent{Error: mcpErr.Detail()}
+	data, marshalErr := json.Marshal(content)
+	if marshalErr != nil {
+		data = []byte(mcpErr.Error())
+	}
+
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}
+}
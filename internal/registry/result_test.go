@@ -0,0 +1,144 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resultTestInput struct {
+	ProjectID string `json:"project_id" jsonschema:"description:Project ID"`
+	PerPage   int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Items per page"`
+}
+
+type resultTestOutput struct {
+	ProjectID string `json:"project_id"`
+}
+
+func callToolHandler(t *testing.T, handler ToolHandlerFor[resultTestInput, resultTestOutput], args string) *mcp.CallToolResult {
+	t.Helper()
+
+	schema, err := schemaFor[resultTestInput]()
+	require.NoError(t, err)
+	toolHandler, err := newToolHandler(schema, true, handler)
+	require.NoError(t, err)
+
+	res, err := toolHandler(context.Background(), &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Arguments: json.RawMessage(args)},
+	})
+	require.NoError(t, err)
+	return res
+}
+
+func errorDetail(t *testing.T, res *mcp.CallToolResult) gitlab.ErrorDetail {
+	t.Helper()
+
+	require.True(t, res.IsError)
+	// 出力スキーマに従わないため StructuredContent には入れず、TextContent の JSON だけで返す
+	assert.Nil(t, res.StructuredContent)
+
+	require.Len(t, res.Content, 1)
+	text, ok := res.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	var content toolErrorContent
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &content))
+
+	return content.Error
+}
+
+func TestNewToolHandler_Success(t *testing.T) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input resultTestInput) (*mcp.CallToolResult, resultTestOutput, error) {
+		return nil, resultTestOutput{ProjectID: input.ProjectID}, nil
+	}
+
+	res := callToolHandler(t, handler, `{"project_id":"group/project"}`)
+
+	assert.False(t, res.IsError)
+	assert.JSONEq(t, `{"project_id":"group/project"}`, string(res.StructuredContent.(json.RawMessage)))
+	require.Len(t, res.Content, 1)
+	assert.JSONEq(t, `{"project_id":"group/project"}`, res.Content[0].(*mcp.TextContent).Text)
+}
+
+func TestNewToolHandler_InvalidInput(t *testing.T) {
+	called := false
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input resultTestInput) (*mcp.CallToolResult, resultTestOutput, error) {
+		called = true
+		return nil, resultTestOutput{}, nil
+	}
+
+	tests := []struct {
+		name string
+		args string
+	}{
+		{name: "missing required", args: `{}`},
+		{name: "above maximum", args: `{"project_id":"p","per_page":500}`},
+		{name: "wrong type", args: `{"project_id":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := callToolHandler(t, handler, tt.args)
+
+			detail := errorDetail(t, res)
+			assert.Equal(t, gitlab.ErrCodeBadRequest, detail.Code)
+			assert.False(t, detail.Retryable)
+		})
+	}
+	assert.False(t, called)
+}
+
+func TestNewToolHandler_GitLabError(t *testing.T) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input resultTestInput) (*mcp.CallToolResult, resultTestOutput, error) {
+		return nil, resultTestOutput{}, &gitlab.MCPError{
+			Code:          gitlab.ErrCodeRateLimited,
+			Message:       "rate limited",
+			HTTPStatus:    http.StatusTooManyRequests,
+			GitLabMessage: "Retry later",
+			RetryAfter:    60,
+			RequestID:     "req-1",
+		}
+	}
+
+	res := callToolHandler(t, handler, `{"project_id":"p"}`)
+
+	assert.Equal(t, gitlab.ErrorDetail{
+		Code:          gitlab.ErrCodeRateLimited,
+		Message:       "rate limited",
+		HTTPStatus:    http.StatusTooManyRequests,
+		GitLabMessage: "Retry later",
+		Retryable:     true,
+		RetryAfter:    60,
+		RequestID:     "req-1",
+	}, errorDetail(t, res))
+}
+
+func TestNewToolHandler_OtherError(t *testing.T) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input resultTestInput) (*mcp.CallToolResult, resultTestOutput, error) {
+		return nil, resultTestOutput{}, errors.New("boom")
+	}
+
+	res := callToolHandler(t, handler, `{"project_id":"p"}`)
+
+	detail := errorDetail(t, res)
+	assert.Equal(t, gitlab.ErrCodeServerError, detail.Code)
+	assert.Contains(t, detail.Message, "boom")
+}
+
+func TestNewToolHandler_Cancelled(t *testing.T) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input resultTestInput) (*mcp.CallToolResult, resultTestOutput, error) {
+		return nil, resultTestOutput{}, context.Canceled
+	}
+
+	res := callToolHandler(t, handler, `{"project_id":"p"}`)
+
+	detail := errorDetail(t, res)
+	assert.Equal(t, gitlab.ErrCodeCancelled, detail.Code)
+	assert.False(t, detail.Retryable)
+}
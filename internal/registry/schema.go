@@ -0,0 +1,201 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// schemaTagKeys は jsonschema タグで指定できるキー
+// タグは "key:value" をカンマで区切った形式（例: "enum:opened,enum:closed,description:..."）
+// 既知のキーで始まらない要素は直前の値の続きとして扱うため、description にはカンマを含めてよい
+var schemaTagKeys = []string{
+	"description", "enum", "minimum", "maximum", "format", "pattern", "minLength", "maxLength", "minItems", "maxItems",
+}
+
+// schemaTagKeyPattern は jsonschema タグの要素が新しいキーで始まるかを判定する
+var schemaTagKeyPattern = regexp.MustCompile(`^(` + strings.Join(schemaTagKeys, "|") + `):`)
+
+// schemaTagEntry は jsonschema タグの 1 要素
+type schemaTagEntry struct {
+	key   string
+	value string
+}
+
+// parseSchemaTag は jsonschema タグを要素に分解する
+func parseSchemaTag(tag string) ([]schemaTagEntry, error) {
+	var entries []schemaTagEntry
+	for _, part := range strings.Split(tag, ",") {
+		if m := schemaTagKeyPattern.FindStringSubmatch(part); m != nil {
+			entries = append(entries, schemaTagEntry{key: m[1], value: part[len(m[0]):]})
+			continue
+		}
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("jsonschema tag %q must start with one of %v", tag, schemaTagKeys)
+		}
+		entries[len(entries)-1].value += "," + part
+	}
+	return entries, nil
+}
+
+// rawSchemas は推論せずにスキーマを指定する型
+// json.RawMessage はバイト列ではなく任意の JSON の値を表す
+var rawSchemas = map[reflect.Type]*jsonschema.Schema{
+	reflect.TypeFor[json.RawMessage](): {},
+}
+
+// schemaFor は型 T から JSON Schema を生成する
+// google/jsonschema-go の推論結果に、jsonschema タグの enum・minimum・maximum・format などの制約を反映する
+func schemaFor[T any]() (*jsonschema.Schema, error) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema, err := jsonschema.ForType(t, &jsonschema.ForOptions{TypeSchemas: rawSchemas})
+	if err != nil {
+		return nil, err
+	}
+	if err := applySchemaTags(schema, t); err != nil {
+		return nil, fmt.Errorf("%v: %w", t, err)
+	}
+	return schema, nil
+}
+
+// applySchemaTags は型 t の構造体フィールドの jsonschema タグを schema に再帰的に反映する
+func applySchemaTags(schema *jsonschema.Schema, t reflect.Type) error {
+	if schema == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return applySchemaTags(schema.Items, t.Elem())
+	case reflect.Map:
+		return applySchemaTags(schema.AdditionalProperties, t.Elem())
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		prop := schema.Properties[jsonFieldName(field)]
+		if prop == nil {
+			continue
+		}
+		if tag, ok := field.Tag.Lookup("jsonschema"); ok {
+			if err := applySchemaTag(prop, tag); err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+		if err := applySchemaTags(prop, field.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonFieldName は構造体フィールドの JSON のプロパティ名を返す
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// applySchemaTag は 1 つのフィールドの jsonschema タグをプロパティのスキーマに反映する
+// 配列のフィールドに指定された enum・format・pattern は要素のスキーマに適用する
+func applySchemaTag(prop *jsonschema.Schema, tag string) error {
+	entries, err := parseSchemaTag(tag)
+	if err != nil {
+		return err
+	}
+
+	prop.Description = ""
+	target := prop
+	if schemaType(prop) == "array" && prop.Items != nil {
+		target = prop.Items
+	}
+
+	for _, e := range entries {
+		switch e.key {
+		case "description":
+			prop.Description = e.value
+		case "enum":
+			v, err := enumValue(schemaType(target), e.value)
+			if err != nil {
+				return err
+			}
+			target.Enum = append(target.Enum, v)
+		case "format":
+			target.Format = e.value
+		case "pattern":
+			target.Pattern = e.value
+		case "minimum", "maximum":
+			n, err := strconv.ParseFloat(e.value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", e.key, e.value, err)
+			}
+			if e.key == "minimum" {
+				prop.Minimum = &n
+			} else {
+				prop.Maximum = &n
+			}
+		case "minLength", "maxLength", "minItems", "maxItems":
+			n, err := strconv.Atoi(e.value)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", e.key, e.value, err)
+			}
+			switch e.key {
+			case "minLength":
+				prop.MinLength = &n
+			case "maxLength":
+				prop.MaxLength = &n
+			case "minItems":
+				prop.MinItems = &n
+			case "maxItems":
+				prop.MaxItems = &n
+			}
+		}
+	}
+	return nil
+}
+
+// schemaType はスキーマの型を返す（ポインタ由来の ["null", T] の場合は T を返す）
+func schemaType(s *jsonschema.Schema) string {
+	if s.Type != "" {
+		return s.Type
+	}
+	for _, t := range s.Types {
+		if t != "null" {
+			return t
+		}
+	}
+	return ""
+}
+
+// enumValue は enum の値をスキーマの型に合わせて変換する
+func enumValue(typ, value string) (any, error) {
+	switch typ {
+	case "integer", "number", "boolean":
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return nil, fmt.Errorf("invalid %s enum value %q: %w", typ, value, err)
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaTestItem struct {
+	Kind string `json:"kind" jsonschema:"enum:a,enum:b,description:Item kind"`
+}
+
+type schemaTestInput struct {
+	schemaTestEmbedded
+	ProjectID string           `json:"project_id" jsonschema:"description:Project ID, or path"`
+	State     *string          `json:"state,omitempty" jsonschema:"enum:opened,enum:closed,description:State filter"`
+	Level     int              `json:"level,omitempty" jsonschema:"enum:10,enum:20,description:Access level"`
+	PerPage   int              `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Items per page (default: 20, max: 100)"`
+	Since     string           `json:"since,omitempty" jsonschema:"format:date-time,description:Lower bound"`
+	Labels    []string         `json:"labels,omitempty" jsonschema:"enum:bug,enum:feature,maxItems:3,description:Labels"`
+	Items     []schemaTestItem `json:"items,omitempty"`
+	NoTag     string           `json:"no_tag,omitempty"`
+}
+
+type schemaTestEmbedded struct {
+	Name string `json:"name,omitempty" jsonschema:"minLength:1,description:Name"`
+}
+
+func TestParseSchemaTag(t *testing.T) {
+	entries, err := parseSchemaTag("enum:a,enum:b,description:One, two: three")
+	require.NoError(t, err)
+	assert.Equal(t, []schemaTagEntry{
+		{key: "enum", value: "a"}, {key: "enum", value: "b"}, {key: "description", value: "One, two: three"},
+	}, entries)
+
+	_, err = parseSchemaTag("Plain description")
+	assert.Error(t, err)
+}
+
+func TestSchemaFor(t *testing.T) {
+	schema, err := schemaFor[schemaTestInput]()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"project_id"}, schema.Required)
+
+	assert.Equal(t, "Project ID, or path", schema.Properties["project_id"].Description)
+
+	state := schema.Properties["state"]
+	assert.Equal(t, "State filter", state.Description)
+	assert.Equal(t, []any{"opened", "closed"}, state.Enum)
+
+	assert.Equal(t, []any{float64(10), float64(20)}, schema.Properties["level"].Enum)
+
+	perPage := schema.Properties["per_page"]
+	require.NotNil(t, perPage.Minimum)
+	require.NotNil(t, perPage.Maximum)
+	assert.Equal(t, 1.0, *perPage.Minimum)
+	assert.Equal(t, 100.0, *perPage.Maximum)
+	assert.Equal(t, "Items per page (default: 20, max: 100)", perPage.Description)
+
+	assert.Equal(t, "date-time", schema.Properties["since"].Format)
+
+	labels := schema.Properties["labels"]
+	assert.Equal(t, []any{"bug", "feature"}, labels.Items.Enum)
+	require.NotNil(t, labels.MaxItems)
+	assert.Equal(t, 3, *labels.MaxItems)
+
+	assert.Equal(t, []any{"a", "b"}, schema.Properties["items"].Items.Properties["kind"].Enum)
+
+	require.NotNil(t, schema.Properties["name"].MinLength)
+	assert.Equal(t, 1, *schema.Properties["name"].MinLength)
+
+	assert.Empty(t, schema.Properties["no_tag"].Description)
+}
+
+func TestSchemaFor_InvalidTag(t *testing.T) {
+	type invalidInput struct {
+		PerPage int `json:"per_page" jsonschema:"maximum:many"`
+	}
+
+	_, err := schemaFor[invalidInput]()
+	assert.Error(t, err)
+}
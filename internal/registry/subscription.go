@@ -0,0 +1,242 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// notFoundVersion は購読中のリソースが削除されたことを表すバージョン
+const notFoundVersion = "\x00not_found"
+
+// subscription は購読中のリソース
+type subscription struct {
+	sessions map[*mcp.ServerSession]bool
+	params   ResourceParams
+	version  ResourceVersionFor
+	// current は最後に確認したバージョン
+	current string
+	// retryAt はレート制限に達した後、次に確認してよい時刻
+	retryAt time.Time
+}
+
+// poller は購読中のリソースを定期的に確認し、変更があれば notifications/resources/updated を送る
+// バージョンの確認は updated_at や blob ID など軽量な API で行い、リクエストの頻度は limiter で制限する
+// ポーリングのゴルーチンは最初の購読で開始し、購読がなくなると終了する
+type poller struct {
+	server    *mcp.Server
+	templates func() []*resourceTemplate
+	interval  time.Duration
+	limiter   *rateLimiter
+
+	mu      sync.Mutex
+	subs    map[string]*subscription
+	running bool
+}
+
+func newPoller(interval time.Duration, requestsPerMinute int, templates func() []*resourceTemplate) *poller {
+	return &poller{
+		templates: templates,
+		interval:  interval,
+		limiter:   &rateLimiter{gap: time.Minute / time.Duration(requestsPerMinute)},
+		subs:      make(map[string]*subscription),
+	}
+}
+
+// subscribe は resources/subscribe を処理する
+// 購読の開始時にリソースが存在することを確認し、その時点のバージョンを記録する
+func (p *poller) subscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+
+	var (
+		params  ResourceParams
+		version ResourceVersionFor
+	)
+	for _, t := range p.templates() {
+		if t.version == nil {
+			continue
+		}
+		if m, ok := t.match(uri); ok {
+			params, version = m, t.version
+			break
+		}
+	}
+	if version == nil {
+		return &gitlab.MCPError{
+			Code:    gitlab.ErrCodeBadRequest,
+			Message: gitlab.Msg(gitlab.MsgSubscriptionNotSupported, uri),
+		}
+	}
+
+	current, err := version(ctx, params)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return mcp.ResourceNotFoundError(uri)
+	}
+	if err != nil {
+		return gitlab.FromError(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sub, ok := p.subs[uri]
+	if !ok {
+		sub = &subscription{
+			sessions: make(map[*mcp.ServerSession]bool),
+			params:   params,
+			version:  version,
+			current:  current,
+		}
+		p.subs[uri] = sub
+	}
+	sub.sessions[req.Session] = true
+
+	if !p.running {
+		p.running = true
+		go p.run()
+	}
+	return nil
+}
+
+// unsubscribe は resources/unsubscribe を処理する
+func (p *poller) unsubscribe(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if sub, ok := p.subs[req.Params.URI]; ok {
+		delete(sub.sessions, req.Session)
+		if len(sub.sessions) == 0 {
+			delete(p.subs, req.Params.URI)
+		}
+	}
+	return nil
+}
+
+// run は購読がなくなるまで interval ごとにリソースを確認する
+func (p *poller) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !p.poll(context.Background()) {
+			return
+		}
+	}
+}
+
+// poll は購読中のリソースを 1 回ずつ確認する
+// 購読がなくなった場合は false を返す
+func (p *poller) poll(ctx context.Context) bool {
+	uris := p.activeURIs()
+	if len(uris) == 0 {
+		return false
+	}
+
+	for _, uri := range uris {
+		p.mu.Lock()
+		sub, ok := p.subs[uri]
+		skip := !ok || time.Now().Before(sub.retryAt)
+		p.mu.Unlock()
+		if skip {
+			continue
+		}
+
+		if err := p.limiter.wait(ctx); err != nil {
+			return true
+		}
+		p.check(ctx, uri, sub)
+	}
+	return true
+}
+
+// activeURIs は切断されたセッションの購読を取り除き、購読中の URI を返す
+// 購読がなくなった場合はポーリングを停止する
+func (p *poller) activeURIs() []string {
+	live := make(map[*mcp.ServerSession]bool)
+	if p.server != nil {
+		for ss := range p.server.Sessions() {
+			live[ss] = true
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	uris := make([]string, 0, len(p.subs))
+	for uri, sub := range p.subs {
+		for ss := range sub.sessions {
+			if !live[ss] {
+				delete(sub.sessions, ss)
+			}
+		}
+		if len(sub.sessions) == 0 {
+			delete(p.subs, uri)
+			continue
+		}
+		uris = append(uris, uri)
+	}
+	if len(uris) == 0 {
+		p.running = false
+	}
+	slices.Sort(uris)
+	return uris
+}
+
+// check はリソースのバージョンを確認し、変わっていれば購読中のクライアントに通知する
+// リソースが削除された場合も 1 回だけ通知する
+func (p *poller) check(ctx context.Context, uri string, sub *subscription) {
+	version, err := sub.version(ctx, sub.params)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		version, err = notFoundVersion, nil
+	}
+	if err != nil {
+		var mcpErr *gitlab.MCPError
+		if errors.As(err, &mcpErr) && mcpErr.Code == gitlab.ErrCodeRateLimited {
+			backoff := time.Duration(mcpErr.RetryAfter) * time.Second
+			if backoff <= 0 {
+				backoff = p.interval
+			}
+			p.mu.Lock()
+			sub.retryAt = time.Now().Add(backoff)
+			p.mu.Unlock()
+		}
+		return
+	}
+
+	p.mu.Lock()
+	changed := version != sub.current
+	sub.current = version
+	p.mu.Unlock()
+
+	if changed {
+		p.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri})
+	}
+}
+
+// rateLimiter は GitLab へのリクエストの間隔を gap 以上に保つ
+type rateLimiter struct {
+	gap  time.Duration
+	next time.Time
+}
+
+// wait は前回のリクエストから gap が経過するまで待つ
+func (l *rateLimiter) wait(ctx context.Context) error {
+	now := time.Now()
+	if d := l.next.Sub(now); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		now = l.next
+	}
+	l.next = now.Add(l.gap)
+	return nil
+}
@@ -0,0 +1,182 @@
+package registry
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSubscriptionTest は version を購読の確認に使うリソーステンプレートを登録し、
+// 更新通知を updated に送るクライアントを接続する
+func setupSubscriptionTest(t *testing.T, version ResourceVersionFor) (*Registry, *mcp.ClientSession, chan string) {
+	t.Helper()
+
+	reg := New(&config.Config{PollInterval: 10 * time.Millisecond, PollRateLimit: 60000})
+	RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "issue",
+		URITemplate: "gitlab://{project}/issues/{iid}",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params ResourceParams) (*mcp.ReadResourceResult, error) {
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: req.Params.URI, Text: "{}"}}}, nil
+	}, WithSubscription(version))
+	RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "log",
+		URITemplate: "gitlab://{project}/jobs/{id}/log",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params ResourceParams) (*mcp.ReadResourceResult, error) {
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: req.Params.URI, Text: ""}}}, nil
+	})
+
+	updated := make(chan string, 10)
+	session := connectTestClient(t, reg, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(ctx context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	return reg, session, updated
+}
+
+func TestSubscription_NotifiesOnChange(t *testing.T) {
+	var current atomic.Value
+	current.Store("2026-10-01T00:00:00Z")
+
+	var gotParams atomic.Value
+	_, session, updated := setupSubscriptionTest(t, func(ctx context.Context, params ResourceParams) (string, error) {
+		gotParams.Store(params)
+		return current.Load().(string), nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	uri := "gitlab://group%2Fproject/issues/1"
+	require.NoError(t, session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}))
+	assert.Equal(t, ResourceParams{"project": "group/project", "iid": "1"}, gotParams.Load())
+
+	// 変更がなければ通知しない
+	select {
+	case got := <-updated:
+		t.Fatalf("unexpected notification for %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	current.Store("2026-10-02T00:00:00Z")
+	select {
+	case got := <-updated:
+		assert.Equal(t, uri, got)
+	case <-ctx.Done():
+		t.Fatal("resource update notification was not received")
+	}
+
+	// 通知は変更ごとに 1 回だけ
+	select {
+	case got := <-updated:
+		t.Fatalf("unexpected notification for %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscription_NotifiesOnDeletion(t *testing.T) {
+	var deleted atomic.Bool
+	_, session, updated := setupSubscriptionTest(t, func(ctx context.Context, params ResourceParams) (string, error) {
+		if deleted.Load() {
+			return "", &gitlab.MCPError{Code: gitlab.ErrCodeNotFound}
+		}
+		return "v1", nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	uri := "gitlab://1/issues/1"
+	require.NoError(t, session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}))
+
+	deleted.Store(true)
+	select {
+	case got := <-updated:
+		assert.Equal(t, uri, got)
+	case <-ctx.Done():
+		t.Fatal("resource update notification was not received")
+	}
+}
+
+func TestSubscription_Errors(t *testing.T) {
+	_, session, _ := setupSubscriptionTest(t, func(ctx context.Context, params ResourceParams) (string, error) {
+		if params["iid"] == "999" {
+			return "", &gitlab.MCPError{Code: gitlab.ErrCodeNotFound}
+		}
+		return "v1", nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("resource without subscription support", func(t *testing.T) {
+		err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: "gitlab://1/jobs/1/log"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Subscriptions are not supported")
+	})
+
+	t.Run("resource not found", func(t *testing.T) {
+		err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: "gitlab://1/issues/999"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestSubscription_StopsPollingAfterUnsubscribe(t *testing.T) {
+	var calls atomic.Int32
+	reg, session, _ := setupSubscriptionTest(t, func(ctx context.Context, params ResourceParams) (string, error) {
+		calls.Add(1)
+		return "v1", nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	uri := "gitlab://1/issues/1"
+	require.NoError(t, session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}))
+	require.Eventually(t, func() bool { return calls.Load() > 1 }, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, session.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: uri}))
+	require.Eventually(t, func() bool {
+		reg.poller.mu.Lock()
+		defer reg.poller.mu.Unlock()
+		return !reg.poller.running
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestPoller_RateLimitedBackoff(t *testing.T) {
+	p := newPoller(time.Minute, 60, func() []*resourceTemplate { return nil })
+	sub := &subscription{
+		current: "v1",
+		version: func(ctx context.Context, params ResourceParams) (string, error) {
+			return "", &gitlab.MCPError{Code: gitlab.ErrCodeRateLimited, RetryAfter: 120}
+		},
+	}
+
+	p.check(context.Background(), "gitlab://1/issues/1", sub)
+
+	assert.Equal(t, "v1", sub.current)
+	assert.WithinDuration(t, time.Now().Add(120*time.Second), sub.retryAt, 5*time.Second)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := &rateLimiter{gap: 30 * time.Millisecond}
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		require.NoError(t, l.wait(ctx))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, l.wait(cancelled), context.Canceled)
+}
@@ -0,0 +1,429 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// GetApprovalStateInput は get_merge_request_approval_state の入力パラメータ
+type GetApprovalStateInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+}
+
+// ApprovalGroup は承認ルールに設定されたグループ
+type ApprovalGroup struct {
+	ID       int64  `json:"id"`
+	FullPath string `json:"full_path"`
+}
+
+// ApprovalRuleInfo は承認ルールの情報
+type ApprovalRuleInfo struct {
+	ID                            int64           `json:"id"`
+	Name                          string          `json:"name"`
+	RuleType                      string          `json:"rule_type"`
+	ReportType                    string          `json:"report_type,omitempty"`
+	Section                       string          `json:"section,omitempty"`
+	ApprovalsRequired             int64           `json:"approvals_required"`
+	EligibleApprovers             []Approver      `json:"eligible_approvers"`
+	Users                         []Approver      `json:"users,omitempty"`
+	Groups                        []ApprovalGroup `json:"groups,omitempty"`
+	ProtectedBranches             []string        `json:"protected_branches,omitempty"`
+	AppliesToAllProtectedBranches bool            `json:"applies_to_all_protected_branches,omitempty"`
+}
+
+// ApprovalRuleState は MR の承認ルールごとの承認状態
+type ApprovalRuleState struct {
+	ApprovalRuleInfo
+	Approved      bool       `json:"approved"`
+	ApprovedBy    []Approver `json:"approved_by"`
+	ApprovalsLeft int64      `json:"approvals_left"`
+}
+
+// GetApprovalStateOutput は get_merge_request_approval_state の出力
+type GetApprovalStateOutput struct {
+	Approved                 bool                `json:"approved"`
+	ApprovalRulesOverwritten bool                `json:"approval_rules_overwritten"`
+	UnsatisfiedRules         []string            `json:"unsatisfied_rules"`
+	Rules                    []ApprovalRuleState `json:"rules"`
+}
+
+// ListMergeRequestApprovalRulesInput は list_merge_request_approval_rules の入力パラメータ
+type ListMergeRequestApprovalRulesInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+}
+
+// ListApprovalRulesOutput は承認ルール一覧の出力
+type ListApprovalRulesOutput struct {
+	Rules []ApprovalRuleInfo `json:"rules"`
+}
+
+// CreateMergeRequestApprovalRuleInput は create_merge_request_approval_rule の入力パラメータ
+type CreateMergeRequestApprovalRuleInput struct {
+	ProjectID             string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID       int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Name                  string `json:"name" jsonschema:"description:Rule name"`
+	ApprovalsRequired     int    `json:"approvals_required" jsonschema:"minimum:0,description:Number of approvals required (0 makes the rule optional)"`
+	UserIDs               []int  `json:"user_ids,omitempty" jsonschema:"description:Eligible approver user IDs"`
+	GroupIDs              []int  `json:"group_ids,omitempty" jsonschema:"description:Eligible approver group IDs"`
+	ApprovalProjectRuleID *int   `json:"approval_project_rule_id,omitempty" jsonschema:"description:Project rule ID to copy approvers from"`
+	registry.DryRunOption
+}
+
+// UpdateMergeRequestApprovalRuleInput は update_merge_request_approval_rule の入力パラメータ
+type UpdateMergeRequestApprovalRuleInput struct {
+	ProjectID         string  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID   int     `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	RuleID            int     `json:"rule_id" jsonschema:"minimum:1,description:Approval rule ID"`
+	Name              *string `json:"name,omitempty" jsonschema:"description:New rule name"`
+	ApprovalsRequired *int    `json:"approvals_required,omitempty" jsonschema:"minimum:0,description:New number of approvals required"`
+	UserIDs           []int   `json:"user_ids,omitempty" jsonschema:"description:Replace eligible approver user IDs"`
+	GroupIDs          []int   `json:"group_ids,omitempty" jsonschema:"description:Replace eligible approver group IDs"`
+	registry.DryRunOption
+}
+
+// DeleteMergeRequestApprovalRuleInput は delete_merge_request_approval_rule の入力パラメータ
+type DeleteMergeRequestApprovalRuleInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	RuleID          int    `json:"rule_id" jsonschema:"minimum:1,description:Approval rule ID"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// ListProjectApprovalRulesInput は list_project_approval_rules の入力パラメータ
+type ListProjectApprovalRulesInput struct {
+	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Page      int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage   int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
+}
+
+// ProjectApprovalRuleOptionsInput はプロジェクトの承認ルールの作成・更新で共通の入力パラメータ
+type ProjectApprovalRuleOptionsInput struct {
+	UserIDs                       []int    `json:"user_ids,omitempty" jsonschema:"description:Eligible approver user IDs"`
+	GroupIDs                      []int    `json:"group_ids,omitempty" jsonschema:"description:Eligible approver group IDs"`
+	Usernames                     []string `json:"usernames,omitempty" jsonschema:"description:Eligible approver usernames"`
+	ProtectedBranchIDs            []int    `json:"protected_branch_ids,omitempty" jsonschema:"description:Protected branch IDs the rule applies to"`
+	AppliesToAllProtectedBranches *bool    `json:"applies_to_all_protected_branches,omitempty" jsonschema:"description:Apply the rule to all protected branches"`
+}
+
+// CreateProjectApprovalRuleInput は create_project_approval_rule の入力パラメータ
+type CreateProjectApprovalRuleInput struct {
+	ProjectID         string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Name              string `json:"name" jsonschema:"description:Rule name"`
+	ApprovalsRequired int    `json:"approvals_required" jsonschema:"minimum:0,description:Number of approvals required (0 makes the rule optional)"`
+	ProjectApprovalRuleOptionsInput
+	registry.DryRunOption
+}
+
+// UpdateProjectApprovalRuleInput は update_project_approval_rule の入力パラメータ
+type UpdateProjectApprovalRuleInput struct {
+	ProjectID         string  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	RuleID            int     `json:"rule_id" jsonschema:"minimum:1,description:Approval rule ID"`
+	Name              *string `json:"name,omitempty" jsonschema:"description:New rule name"`
+	ApprovalsRequired *int    `json:"approvals_required,omitempty" jsonschema:"minimum:0,description:New number of approvals required"`
+	ProjectApprovalRuleOptionsInput
+	registry.DryRunOption
+}
+
+// DeleteProjectApprovalRuleInput は delete_project_approval_rule の入力パラメータ
+type DeleteProjectApprovalRuleInput struct {
+	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	RuleID    int    `json:"rule_id" jsonschema:"minimum:1,description:Approval rule ID"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// DeleteApprovalRuleOutput は承認ルール削除の出力
+type DeleteApprovalRuleOutput struct {
+	Success bool `json:"success"`
+}
+
+// registerRuleTools は承認ルール関連ツールを登録する
+func registerRuleTools(reg *registry.Registry) {
+	registry.RegisterTool(reg, "get_merge_request_approval_state",
+		"GitLab Merge Request の承認ルールごとの承認状態（未充足のルール、承認可能なユーザー、Code Owners のセクション）を取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input GetApprovalStateInput) (*mcp.CallToolResult, GetApprovalStateOutput, error) {
+			return getApprovalStateHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "list_merge_request_approval_rules",
+		"GitLab Merge Request の承認ルール一覧を取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input ListMergeRequestApprovalRulesInput) (*mcp.CallToolResult, ListApprovalRulesOutput, error) {
+			return listMergeRequestApprovalRulesHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "create_merge_request_approval_rule",
+		"GitLab Merge Request に承認ルールを作成します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input CreateMergeRequestApprovalRuleInput) (*mcp.CallToolResult, ApprovalRuleInfo, error) {
+			return createMergeRequestApprovalRuleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "update_merge_request_approval_rule",
+		"GitLab Merge Request の承認ルールを更新します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input UpdateMergeRequestApprovalRuleInput) (*mcp.CallToolResult, ApprovalRuleInfo, error) {
+			return updateMergeRequestApprovalRuleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "delete_merge_request_approval_rule",
+		"GitLab Merge Request の承認ルールを削除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input DeleteMergeRequestApprovalRuleInput) (*mcp.CallToolResult, DeleteApprovalRuleOutput, error) {
+			return deleteMergeRequestApprovalRuleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeleteMergeRequestApprovalRuleInput) (string, error) {
+			return deleteMergeRequestApprovalRuleSummary(holder.client, input)
+		}))
+
+	registry.RegisterTool(reg, "list_project_approval_rules",
+		"GitLab プロジェクトの承認ルール一覧を取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input ListProjectApprovalRulesInput) (*mcp.CallToolResult, ListApprovalRulesOutput, error) {
+			return listProjectApprovalRulesHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "create_project_approval_rule",
+		"GitLab プロジェクトに承認ルールを作成します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input CreateProjectApprovalRuleInput) (*mcp.CallToolResult, ApprovalRuleInfo, error) {
+			return createProjectApprovalRuleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "update_project_approval_rule",
+		"GitLab プロジェクトの承認ルールを更新します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input UpdateProjectApprovalRuleInput) (*mcp.CallToolResult, ApprovalRuleInfo, error) {
+			return updateProjectApprovalRuleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "delete_project_approval_rule",
+		"GitLab プロジェクトの承認ルールを削除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input DeleteProjectApprovalRuleInput) (*mcp.CallToolResult, DeleteApprovalRuleOutput, error) {
+			return deleteProjectApprovalRuleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeleteProjectApprovalRuleInput) (string, error) {
+			return deleteProjectApprovalRuleSummary(holder.client, input)
+		}))
+}
+
+// toApprovers は GitLab のユーザー一覧を承認者情報に変換する
+func toApprovers(users []*gogitlab.BasicUser) []Approver {
+	approvers := make([]Approver, 0, len(users))
+	for _, u := range users {
+		if u != nil {
+			approvers = append(approvers, Approver{ID: u.ID, Username: u.Username})
+		}
+	}
+	return approvers
+}
+
+// toApprovalGroups は GitLab のグループ一覧を変換する
+func toApprovalGroups(groups []*gogitlab.Group) []ApprovalGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	converted := make([]ApprovalGroup, 0, len(groups))
+	for _, g := range groups {
+		if g != nil {
+			converted = append(converted, ApprovalGroup{ID: g.ID, FullPath: g.FullPath})
+		}
+	}
+	return converted
+}
+
+// toMergeRequestRuleInfo は MR の承認ルールを変換する
+func toMergeRequestRuleInfo(rule *gogitlab.MergeRequestApprovalRule) ApprovalRuleInfo {
+	info := ApprovalRuleInfo{
+		ID:                rule.ID,
+		Name:              rule.Name,
+		RuleType:          rule.RuleType,
+		ReportType:        rule.ReportType,
+		Section:           rule.Section,
+		ApprovalsRequired: rule.ApprovalsRequired,
+		EligibleApprovers: toApprovers(rule.EligibleApprovers),
+		Groups:            toApprovalGroups(rule.Groups),
+	}
+	if len(rule.Users) > 0 {
+		info.Users = toApprovers(rule.Users)
+	}
+	return info
+}
+
+// toProjectRuleInfo はプロジェクトの承認ルールを変換する
+func toProjectRuleInfo(rule *gogitlab.ProjectApprovalRule) ApprovalRuleInfo {
+	info := ApprovalRuleInfo{
+		ID:                            rule.ID,
+		Name:                          rule.Name,
+		RuleType:                      rule.RuleType,
+		ReportType:                    rule.ReportType,
+		ApprovalsRequired:             rule.ApprovalsRequired,
+		EligibleApprovers:             toApprovers(rule.EligibleApprovers),
+		Groups:                        toApprovalGroups(rule.Groups),
+		AppliesToAllProtectedBranches: rule.AppliesToAllProtectedBranches,
+	}
+	if len(rule.Users) > 0 {
+		info.Users = toApprovers(rule.Users)
+	}
+	for _, b := range rule.ProtectedBranches {
+		if b != nil {
+			info.ProtectedBranches = append(info.ProtectedBranches, b.Name)
+		}
+	}
+	return info
+}
+
+func getApprovalStateHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input GetApprovalStateInput) (*mcp.CallToolResult, GetApprovalStateOutput, error) {
+	state, err := client.GetMergeRequestApprovalState(input.ProjectID, input.MergeRequestIID)
+	if err != nil {
+		return nil, GetApprovalStateOutput{}, err
+	}
+
+	output := GetApprovalStateOutput{
+		Approved:                 true,
+		ApprovalRulesOverwritten: state.ApprovalRulesOverwritten,
+		UnsatisfiedRules:         []string{},
+		Rules:                    make([]ApprovalRuleState, 0, len(state.Rules)),
+	}
+	for _, rule := range state.Rules {
+		approvedBy := toApprovers(rule.ApprovedBy)
+		output.Rules = append(output.Rules, ApprovalRuleState{
+			ApprovalRuleInfo: toMergeRequestRuleInfo(rule),
+			Approved:         rule.Approved,
+			ApprovedBy:       approvedBy,
+			ApprovalsLeft:    max(rule.ApprovalsRequired-int64(len(approvedBy)), 0),
+		})
+		if !rule.Approved {
+			output.Approved = false
+			output.UnsatisfiedRules = append(output.UnsatisfiedRules, rule.Name)
+		}
+	}
+
+	return nil, output, nil
+}
+
+func listMergeRequestApprovalRulesHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListMergeRequestApprovalRulesInput) (*mcp.CallToolResult, ListApprovalRulesOutput, error) {
+	rules, err := client.ListMergeRequestApprovalRules(input.ProjectID, input.MergeRequestIID)
+	if err != nil {
+		return nil, ListApprovalRulesOutput{}, err
+	}
+
+	infos := make([]ApprovalRuleInfo, len(rules))
+	for i, rule := range rules {
+		infos[i] = toMergeRequestRuleInfo(rule)
+	}
+
+	return nil, ListApprovalRulesOutput{Rules: infos}, nil
+}
+
+func createMergeRequestApprovalRuleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CreateMergeRequestApprovalRuleInput) (*mcp.CallToolResult, ApprovalRuleInfo, error) {
+	rule, err := client.CreateMergeRequestApprovalRule(input.ProjectID, input.MergeRequestIID, &gitlab.ApprovalRuleOptions{
+		Name:                  &input.Name,
+		ApprovalsRequired:     &input.ApprovalsRequired,
+		UserIDs:               input.UserIDs,
+		GroupIDs:              input.GroupIDs,
+		ApprovalProjectRuleID: input.ApprovalProjectRuleID,
+	})
+	if err != nil {
+		return nil, ApprovalRuleInfo{}, err
+	}
+
+	return nil, toMergeRequestRuleInfo(rule), nil
+}
+
+func updateMergeRequestApprovalRuleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input UpdateMergeRequestApprovalRuleInput) (*mcp.CallToolResult, ApprovalRuleInfo, error) {
+	rule, err := client.UpdateMergeRequestApprovalRule(input.ProjectID, input.MergeRequestIID, input.RuleID, &gitlab.ApprovalRuleOptions{
+		Name:              input.Name,
+		ApprovalsRequired: input.ApprovalsRequired,
+		UserIDs:           input.UserIDs,
+		GroupIDs:          input.GroupIDs,
+	})
+	if err != nil {
+		return nil, ApprovalRuleInfo{}, err
+	}
+
+	return nil, toMergeRequestRuleInfo(rule), nil
+}
+
+// deleteMergeRequestApprovalRuleSummary は確認のために削除する承認ルールを要約する
+func deleteMergeRequestApprovalRuleSummary(client *gitlab.Client, input DeleteMergeRequestApprovalRuleInput) (string, error) {
+	rule, err := client.GetMergeRequestApprovalRule(input.ProjectID, input.MergeRequestIID, input.RuleID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("delete approval rule '%s' (%d approvals required) from !%d", rule.Name, rule.ApprovalsRequired, input.MergeRequestIID), nil
+}
+
+func deleteMergeRequestApprovalRuleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeleteMergeRequestApprovalRuleInput) (*mcp.CallToolResult, DeleteApprovalRuleOutput, error) {
+	if err := client.DeleteMergeRequestApprovalRule(input.ProjectID, input.MergeRequestIID, input.RuleID); err != nil {
+		return nil, DeleteApprovalRuleOutput{}, err
+	}
+
+	return nil, DeleteApprovalRuleOutput{Success: true}, nil
+}
+
+func listProjectApprovalRulesHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListProjectApprovalRulesInput) (*mcp.CallToolResult, ListApprovalRulesOutput, error) {
+	rules, err := client.ListProjectApprovalRules(input.ProjectID, &gitlab.PaginationOptions{
+		Page:    input.Page,
+		PerPage: input.PerPage,
+	})
+	if err != nil {
+		return nil, ListApprovalRulesOutput{}, err
+	}
+
+	infos := make([]ApprovalRuleInfo, len(rules))
+	for i, rule := range rules {
+		infos[i] = toProjectRuleInfo(rule)
+	}
+
+	return nil, ListApprovalRulesOutput{Rules: infos}, nil
+}
+
+// toProjectRuleOptions はプロジェクトの承認ルールの共通入力をオプションに変換する
+func toProjectRuleOptions(name *string, approvalsRequired *int, input ProjectApprovalRuleOptionsInput) *gitlab.ApprovalRuleOptions {
+	return &gitlab.ApprovalRuleOptions{
+		Name:                          name,
+		ApprovalsRequired:             approvalsRequired,
+		UserIDs:                       input.UserIDs,
+		GroupIDs:                      input.GroupIDs,
+		Usernames:                     input.Usernames,
+		ProtectedBranchIDs:            input.ProtectedBranchIDs,
+		AppliesToAllProtectedBranches: input.AppliesToAllProtectedBranches,
+	}
+}
+
+func createProjectApprovalRuleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CreateProjectApprovalRuleInput) (*mcp.CallToolResult, ApprovalRuleInfo, error) {
+	opts := toProjectRuleOptions(&input.Name, &input.ApprovalsRequired, input.ProjectApprovalRuleOptionsInput)
+	rule, err := client.CreateProjectApprovalRule(input.ProjectID, opts)
+	if err != nil {
+		return nil, ApprovalRuleInfo{}, err
+	}
+
+	return nil, toProjectRuleInfo(rule), nil
+}
+
+func updateProjectApprovalRuleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input UpdateProjectApprovalRuleInput) (*mcp.CallToolResult, ApprovalRuleInfo, error) {
+	opts := toProjectRuleOptions(input.Name, input.ApprovalsRequired, input.ProjectApprovalRuleOptionsInput)
+	rule, err := client.UpdateProjectApprovalRule(input.ProjectID, input.RuleID, opts)
+	if err != nil {
+		return nil, ApprovalRuleInfo{}, err
+	}
+
+	return nil, toProjectRuleInfo(rule), nil
+}
+
+// deleteProjectApprovalRuleSummary は確認のために削除する承認ルールを要約する
+func deleteProjectApprovalRuleSummary(client *gitlab.Client, input DeleteProjectApprovalRuleInput) (string, error) {
+	rule, err := client.GetProjectApprovalRule(input.ProjectID, input.RuleID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("delete project approval rule '%s' (%d approvals required) from %s", rule.Name, rule.ApprovalsRequired, input.ProjectID), nil
+}
+
+func deleteProjectApprovalRuleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeleteProjectApprovalRuleInput) (*mcp.CallToolResult, DeleteApprovalRuleOutput, error) {
+	if err := client.DeleteProjectApprovalRule(input.ProjectID, input.RuleID); err != nil {
+		return nil, DeleteApprovalRuleOutput{}, err
+	}
+
+	return nil, DeleteApprovalRuleOutput{Success: true}, nil
+}
@@ -0,0 +1,258 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetApprovalStateTool(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/approval_state", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"approval_rules_overwritten": false,
+			"rules": []map[string]any{
+				{
+					"id":                 1,
+					"name":               "Backend",
+					"rule_type":          "regular",
+					"approvals_required": 2,
+					"eligible_approvers": []map[string]any{{"id": 10, "username": "alice"}, {"id": 11, "username": "bob"}},
+					"groups":             []map[string]any{{"id": 3, "full_path": "team/backend"}},
+					"approved_by":        []map[string]any{{"id": 10, "username": "alice"}},
+					"approved":           false,
+				},
+				{
+					"id":                 2,
+					"name":               "*.go",
+					"rule_type":          "code_owner",
+					"section":            "Go",
+					"approvals_required": 1,
+					"eligible_approvers": []map[string]any{{"id": 12, "username": "carol"}},
+					"approved_by":        []map[string]any{{"id": 12, "username": "carol"}},
+					"approved":           true,
+				},
+			},
+		})
+	}
+
+	client, reg, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	assert.True(t, reg.IsRegistered("get_merge_request_approval_state"))
+
+	input := GetApprovalStateInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+	_, output, err := getApprovalStateHandler(client, context.Background(), nil, input)
+
+	require.NoError(t, err)
+	assert.False(t, output.Approved)
+	assert.Equal(t, []string{"Backend"}, output.UnsatisfiedRules)
+	require.Len(t, output.Rules, 2)
+
+	backend := output.Rules[0]
+	assert.Equal(t, int64(1), backend.ApprovalsLeft)
+	assert.Equal(t, []Approver{{ID: 10, Username: "alice"}, {ID: 11, Username: "bob"}}, backend.EligibleApprovers)
+	assert.Equal(t, []Approver{{ID: 10, Username: "alice"}}, backend.ApprovedBy)
+	assert.Equal(t, []ApprovalGroup{{ID: 3, FullPath: "team/backend"}}, backend.Groups)
+
+	codeOwner := output.Rules[1]
+	assert.Equal(t, "code_owner", codeOwner.RuleType)
+	assert.Equal(t, "Go", codeOwner.Section)
+	assert.True(t, codeOwner.Approved)
+	assert.Equal(t, int64(0), codeOwner.ApprovalsLeft)
+}
+
+func TestMergeRequestApprovalRuleTools(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v4/projects/test-project/merge_requests/1/approval_rules":
+			json.NewEncoder(w).Encode([]map[string]any{{"id": 5, "name": "Security", "approvals_required": 1}})
+		case r.Method == "POST" && r.URL.Path == "/api/v4/projects/test-project/merge_requests/1/approval_rules":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "Security", body["name"])
+			assert.Equal(t, float64(1), body["approvals_required"])
+			assert.Equal(t, []any{float64(10)}, body["user_ids"])
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]any{"id": 5, "name": "Security", "approvals_required": 1})
+		case r.Method == "PUT" && r.URL.Path == "/api/v4/projects/test-project/merge_requests/1/approval_rules/5":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, float64(2), body["approvals_required"])
+			assert.NotContains(t, body, "name")
+			json.NewEncoder(w).Encode(map[string]any{"id": 5, "name": "Security", "approvals_required": 2})
+		case r.Method == "DELETE" && r.URL.Path == "/api/v4/projects/test-project/merge_requests/1/approval_rules/5":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	client, reg, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	for _, name := range []string{
+		"list_merge_request_approval_rules", "create_merge_request_approval_rule",
+		"update_merge_request_approval_rule", "delete_merge_request_approval_rule",
+	} {
+		assert.True(t, reg.IsRegistered(name), name)
+	}
+
+	ctx := context.Background()
+
+	_, list, err := listMergeRequestApprovalRulesHandler(client, ctx, nil, ListMergeRequestApprovalRulesInput{ProjectID: "test-project", MergeRequestIID: 1})
+	require.NoError(t, err)
+	require.Len(t, list.Rules, 1)
+	assert.Equal(t, "Security", list.Rules[0].Name)
+
+	_, created, err := createMergeRequestApprovalRuleHandler(client, ctx, nil, CreateMergeRequestApprovalRuleInput{
+		ProjectID:         "test-project",
+		MergeRequestIID:   1,
+		Name:              "Security",
+		ApprovalsRequired: 1,
+		UserIDs:           []int{10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), created.ID)
+
+	required := 2
+	_, updated, err := updateMergeRequestApprovalRuleHandler(client, ctx, nil, UpdateMergeRequestApprovalRuleInput{
+		ProjectID:         "test-project",
+		MergeRequestIID:   1,
+		RuleID:            5,
+		ApprovalsRequired: &required,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.ApprovalsRequired)
+
+	_, deleted, err := deleteMergeRequestApprovalRuleHandler(client, ctx, nil, DeleteMergeRequestApprovalRuleInput{
+		ProjectID:       "test-project",
+		MergeRequestIID: 1,
+		RuleID:          5,
+	})
+	require.NoError(t, err)
+	assert.True(t, deleted.Success)
+}
+
+func TestProjectApprovalRuleTools(t *testing.T) {
+	rule := map[string]any{
+		"id":                 7,
+		"name":               "Default",
+		"rule_type":          "regular",
+		"approvals_required": 1,
+		"users":              []map[string]any{{"id": 10, "username": "alice"}},
+		"protected_branches": []map[string]any{{"id": 1, "name": "main"}},
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v4/projects/test-project/approval_rules":
+			json.NewEncoder(w).Encode([]map[string]any{rule})
+		case r.Method == "POST" && r.URL.Path == "/api/v4/projects/test-project/approval_rules":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "Default", body["name"])
+			assert.Equal(t, []any{"alice"}, body["usernames"])
+			assert.Equal(t, []any{float64(1)}, body["protected_branch_ids"])
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(rule)
+		case r.Method == "PUT" && r.URL.Path == "/api/v4/projects/test-project/approval_rules/7":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, true, body["applies_to_all_protected_branches"])
+			json.NewEncoder(w).Encode(rule)
+		case r.Method == "DELETE" && r.URL.Path == "/api/v4/projects/test-project/approval_rules/7":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	client, reg, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	for _, name := range []string{
+		"list_project_approval_rules", "create_project_approval_rule",
+		"update_project_approval_rule", "delete_project_approval_rule",
+	} {
+		assert.True(t, reg.IsRegistered(name), name)
+	}
+
+	ctx := context.Background()
+
+	_, list, err := listProjectApprovalRulesHandler(client, ctx, nil, ListProjectApprovalRulesInput{ProjectID: "test-project"})
+	require.NoError(t, err)
+	require.Len(t, list.Rules, 1)
+	assert.Equal(t, []string{"main"}, list.Rules[0].ProtectedBranches)
+	assert.Equal(t, []Approver{{ID: 10, Username: "alice"}}, list.Rules[0].Users)
+
+	_, created, err := createProjectApprovalRuleHandler(client, ctx, nil, CreateProjectApprovalRuleInput{
+		ProjectID:         "test-project",
+		Name:              "Default",
+		ApprovalsRequired: 1,
+		ProjectApprovalRuleOptionsInput: ProjectApprovalRuleOptionsInput{
+			Usernames:          []string{"alice"},
+			ProtectedBranchIDs: []int{1},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), created.ID)
+
+	all := true
+	_, _, err = updateProjectApprovalRuleHandler(client, ctx, nil, UpdateProjectApprovalRuleInput{
+		ProjectID: "test-project",
+		RuleID:    7,
+		ProjectApprovalRuleOptionsInput: ProjectApprovalRuleOptionsInput{
+			AppliesToAllProtectedBranches: &all,
+		},
+	})
+	require.NoError(t, err)
+
+	_, deleted, err := deleteProjectApprovalRuleHandler(client, ctx, nil, DeleteProjectApprovalRuleInput{ProjectID: "test-project", RuleID: 7})
+	require.NoError(t, err)
+	assert.True(t, deleted.Success)
+}
+
+func TestDeleteMergeRequestApprovalRuleSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/approval_rules", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{{"id": 5, "name": "Other"}, {"id": 7, "name": "Security", "approvals_required": 2}})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := deleteMergeRequestApprovalRuleSummary(client, DeleteMergeRequestApprovalRuleInput{ProjectID: "test-project", MergeRequestIID: 1, RuleID: 7})
+
+	require.NoError(t, err)
+	assert.Equal(t, "delete approval rule 'Security' (2 approvals required) from !1", summary)
+}
+
+func TestDeleteProjectApprovalRuleSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/approval_rules/7", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 7, "name": "Security", "approvals_required": 2})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := deleteProjectApprovalRuleSummary(client, DeleteProjectApprovalRuleInput{ProjectID: "test-project", RuleID: 7})
+
+	require.NoError(t, err)
+	assert.Equal(t, "delete project approval rule 'Security' (2 approvals required) from test-project", summary)
+}
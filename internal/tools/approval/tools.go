@@ -11,7 +11,8 @@ import (
 // ApproveInput は approve_merge_request の入力パラメータ
 type ApproveInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	registry.DryRunOption
 }
 
 // ApproveOutput は approve_merge_request の出力
@@ -24,7 +25,8 @@ type ApproveOutput struct {
 // UnapproveInput は unapprove_merge_request の入力パラメータ
 type UnapproveInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	registry.DryRunOption
 }
 
 // UnapproveOutput は unapprove_merge_request の出力
@@ -35,7 +37,7 @@ type UnapproveOutput struct {
 // GetApprovalsInput は get_merge_request_approvals の入力パラメータ
 type GetApprovalsInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
 }
 
 // Approver は承認者情報
@@ -68,20 +70,21 @@ func Register(reg *registry.Registry, client *gitlab.Client) {
 	registry.RegisterTool(reg, "approve_merge_request",
 		"GitLab Merge Request を承認します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ApproveInput) (*mcp.CallToolResult, ApproveOutput, error) {
-			return approveHandler(holder.client, ctx, req, input)
+			return approveHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "unapprove_merge_request",
 		"GitLab Merge Request の承認を取り消します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input UnapproveInput) (*mcp.CallToolResult, UnapproveOutput, error) {
-			return unapproveHandler(holder.client, ctx, req, input)
+			return unapproveHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "get_merge_request_approvals",
 		"GitLab Merge Request の承認状態を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input GetApprovalsInput) (*mcp.CallToolResult, GetApprovalsOutput, error) {
 			return getApprovalsHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
+	registerRuleTools(reg)
 }
 
 func approveHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ApproveInput) (*mcp.CallToolResult, ApproveOutput, error) {
@@ -0,0 +1,63 @@
+package completion
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheTTL は補完候補をキャッシュする時間
+// 入力のたびに届く補完リクエストで GitLab API を呼びすぎないよう、同じ検索の結果を短時間再利用する
+const cacheTTL = 30 * time.Second
+
+// cacheEntry はキャッシュされた補完候補
+type cacheEntry struct {
+	values    []string
+	expiresAt time.Time
+}
+
+// cache は補完候補を種類・プロジェクト・検索語ごとに ttl の間保持する
+// エラーはキャッシュしない
+type cache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get はキャッシュされた候補を返す
+// キャッシュにない場合や期限切れの場合は fetch で取得して保存する
+func (c *cache) get(kind, project, search string, fetch func() ([]string, error)) ([]string, error) {
+	key := kind + "\x00" + project + "\x00" + search
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expiresAt) {
+		return entry.values, nil
+	}
+
+	values, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{values: values, expiresAt: now.Add(c.ttl)}
+	return values, nil
+}
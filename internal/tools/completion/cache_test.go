@@ -0,0 +1,51 @@
+package completion
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	c := newCache(30 * time.Second)
+	c.now = func() time.Time { return now }
+
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"main"}, nil
+	}
+
+	values, err := c.get("branches", "group/project", "ma", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main"}, values)
+
+	now = now.Add(29 * time.Second)
+	_, err = c.get("branches", "group/project", "ma", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Second)
+	_, err = c.get("branches", "group/project", "ma", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCache_ErrorNotCached(t *testing.T) {
+	c := newCache(30 * time.Second)
+
+	_, err := c.get("labels", "group/project", "", func() ([]string, error) {
+		return nil, errors.New("boom")
+	})
+	assert.Error(t, err)
+
+	values, err := c.get("labels", "group/project", "", func() ([]string, error) {
+		return []string{"bug"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bug"}, values)
+}
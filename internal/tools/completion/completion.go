@@ -0,0 +1,207 @@
+package completion
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+)
+
+// searchLimit は補完のために GitLab から取得する候補の数
+const searchLimit = 20
+
+// 補完する引数の名前
+// プロンプトの引数とリソーステンプレートの変数の両方の名前を含む
+var (
+	projectArgs   = []string{"project_id", "project"}
+	branchArgs    = []string{"source_branch", "target_branch", "branch"}
+	refArgs       = []string{"ref"}
+	compareArgs   = []string{"from", "to"}
+	labelArgs     = []string{"label", "labels"}
+	milestoneArgs = []string{"milestone"}
+	userArgs      = []string{"username", "assignee", "reviewer", "author"}
+)
+
+// clientHolder holds the GitLab client for handlers
+type clientHolder struct {
+	client *gitlab.Client
+	cache  *cache
+}
+
+var holder *clientHolder
+
+// Register はプロジェクト・ブランチ・ラベル・マイルストーン・ユーザーの引数補完を登録する
+// プロジェクト内の候補は入力済みの project_id（リソーステンプレートでは project）のプロジェクトから探す
+func Register(reg *registry.Registry, client *gitlab.Client) {
+	holder = &clientHolder{
+		client: client,
+		cache:  newCache(cacheTTL),
+	}
+
+	registry.RegisterCompletion(reg, projectArgs, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		return completeProjects(holder, value)
+	})
+	registry.RegisterCompletion(reg, branchArgs, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		return completeBranches(holder, projectFrom(args), value)
+	})
+	registry.RegisterCompletion(reg, refArgs, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		return completeRefs(holder, projectFrom(args), value, completeBranches, completeTags)
+	})
+	registry.RegisterCompletion(reg, compareArgs, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		return completeRefs(holder, projectFrom(args), value, completeTags, completeBranches)
+	})
+	registry.RegisterCompletion(reg, labelArgs, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		return completeLabels(holder, projectFrom(args), value)
+	})
+	registry.RegisterCompletion(reg, milestoneArgs, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		return completeMilestones(holder, projectFrom(args), value)
+	})
+	registry.RegisterCompletion(reg, userArgs, func(ctx context.Context, value string, args map[string]string) ([]string, error) {
+		return completeUsers(holder, projectFrom(args), value)
+	})
+}
+
+// projectFrom は入力済みの引数から補完の対象とするプロジェクトを返す
+// リソーステンプレートの変数は URL エンコードされたまま渡されることがあるためデコードする
+func projectFrom(args map[string]string) string {
+	for _, name := range projectArgs {
+		if v := args[name]; v != "" {
+			if decoded, err := url.PathUnescape(v); err == nil {
+				return decoded
+			}
+			return v
+		}
+	}
+	return ""
+}
+
+func completeProjects(h *clientHolder, value string) ([]string, error) {
+	return h.cache.get("projects", "", value, func() ([]string, error) {
+		projects, err := h.client.SearchProjects(value, searchLimit)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(projects))
+		for _, p := range projects {
+			values = append(values, p.PathWithNamespace)
+		}
+		return values, nil
+	})
+}
+
+func completeBranches(h *clientHolder, project, value string) ([]string, error) {
+	if project == "" {
+		return nil, nil
+	}
+	return h.cache.get("branches", project, value, func() ([]string, error) {
+		branches, err := h.client.SearchBranches(project, value, searchLimit)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(branches))
+		for _, b := range branches {
+			values = append(values, b.Name)
+		}
+		return values, nil
+	})
+}
+
+func completeTags(h *clientHolder, project, value string) ([]string, error) {
+	if project == "" {
+		return nil, nil
+	}
+	return h.cache.get("tags", project, value, func() ([]string, error) {
+		tags, err := h.client.SearchTags(project, value, searchLimit)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(tags))
+		for _, t := range tags {
+			values = append(values, t.Name)
+		}
+		return values, nil
+	})
+}
+
+// completeRefs はブランチとタグの候補を sources の順に並べて返す
+func completeRefs(h *clientHolder, project, value string, sources ...func(*clientHolder, string, string) ([]string, error)) ([]string, error) {
+	var values []string
+	for _, source := range sources {
+		v, err := source(h, project, value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v...)
+	}
+	return values, nil
+}
+
+// completeLabels はラベルの候補を返す
+// カンマ区切りで複数のラベルを入力している場合は最後のラベルを補完し、それより前の入力は候補にそのまま残す
+func completeLabels(h *clientHolder, project, value string) ([]string, error) {
+	if project == "" {
+		return nil, nil
+	}
+	prefix, search := "", value
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		prefix, search = value[:i+1], strings.TrimSpace(value[i+1:])
+	}
+
+	names, err := h.cache.get("labels", project, search, func() ([]string, error) {
+		labels, err := h.client.SearchLabels(project, search, searchLimit)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(labels))
+		for _, l := range labels {
+			values = append(values, l.Name)
+		}
+		return values, nil
+	})
+	if err != nil || prefix == "" {
+		return names, err
+	}
+
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, prefix+name)
+	}
+	return values, nil
+}
+
+func completeMilestones(h *clientHolder, project, value string) ([]string, error) {
+	if project == "" {
+		return nil, nil
+	}
+	return h.cache.get("milestones", project, value, func() ([]string, error) {
+		milestones, err := h.client.SearchMilestones(project, value, searchLimit)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(milestones))
+		for _, m := range milestones {
+			values = append(values, m.Title)
+		}
+		return values, nil
+	})
+}
+
+func completeUsers(h *clientHolder, project, value string) ([]string, error) {
+	if project == "" {
+		return nil, nil
+	}
+	search := strings.TrimPrefix(value, "@")
+	return h.cache.get("users", project, search, func() ([]string, error) {
+		users, err := h.client.SearchProjectUsers(project, search, searchLimit)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(users))
+		for _, u := range users {
+			values = append(values, u.Username)
+		}
+		return values, nil
+	})
+}
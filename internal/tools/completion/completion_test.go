@@ -0,0 +1,192 @@
+package completion
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestServer(t *testing.T, handler http.HandlerFunc) (*clientHolder, *registry.Registry, func()) {
+	server := httptest.NewServer(handler)
+
+	cfg := &config.Config{
+		GitLabURL:   server.URL,
+		GitLabToken: "test-token",
+	}
+
+	client, err := gitlab.NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	reg := registry.New(cfg)
+	Register(reg, client)
+
+	return holder, reg, server.Close
+}
+
+func TestCompleteProjects(t *testing.T) {
+	h, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects", r.URL.Path)
+		assert.Equal(t, "mcp", r.URL.Query().Get("search"))
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 1, "path_with_namespace": "group/gitlab-mcp"},
+			{"id": 2, "path_with_namespace": "group/sub/mcp-tools"},
+		})
+	})
+	defer cleanup()
+
+	values, err := completeProjects(h, "mcp")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"group/gitlab-mcp", "group/sub/mcp-tools"}, values)
+}
+
+func TestCompleteBranches(t *testing.T) {
+	h, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/group/project/repository/branches", r.URL.Path)
+		assert.Equal(t, "feat", r.URL.Query().Get("search"))
+		json.NewEncoder(w).Encode([]map[string]any{{"name": "feature/login"}})
+	})
+	defer cleanup()
+
+	values, err := completeBranches(h, "group/project", "feat")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feature/login"}, values)
+}
+
+func TestCompleteBranches_NoProject(t *testing.T) {
+	h, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL.Path)
+	})
+	defer cleanup()
+
+	values, err := completeBranches(h, "", "feat")
+
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestCompleteRefs(t *testing.T) {
+	h, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/projects/group/project/repository/tags":
+			json.NewEncoder(w).Encode([]map[string]any{{"name": "v1.2.0"}, {"name": "v1.1.0"}})
+		case "/api/v4/projects/group/project/repository/branches":
+			json.NewEncoder(w).Encode([]map[string]any{{"name": "v1-maintenance"}})
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	})
+	defer cleanup()
+
+	values, err := completeRefs(h, "group/project", "v1", completeTags, completeBranches)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.2.0", "v1.1.0", "v1-maintenance"}, values)
+}
+
+func TestCompleteLabels(t *testing.T) {
+	var search string
+	h, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/group/project/labels", r.URL.Path)
+		search = r.URL.Query().Get("search")
+		json.NewEncoder(w).Encode([]map[string]any{{"id": 1, "name": "frontend"}})
+	})
+	defer cleanup()
+
+	tests := []struct {
+		name       string
+		value      string
+		wantSearch string
+		want       []string
+	}{
+		{name: "single label", value: "fro", wantSearch: "fro", want: []string{"frontend"}},
+		{name: "last of comma separated labels", value: "bug, fro", wantSearch: "fro", want: []string{"bug,frontend"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h.cache = newCache(cacheTTL)
+
+			values, err := completeLabels(h, "group/project", tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSearch, search)
+			assert.Equal(t, tt.want, values)
+		})
+	}
+}
+
+func TestCompleteMilestones(t *testing.T) {
+	h, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/group/project/milestones", r.URL.Path)
+		json.NewEncoder(w).Encode([]map[string]any{{"id": 3, "iid": 1, "title": "2026 Q4"}})
+	})
+	defer cleanup()
+
+	values, err := completeMilestones(h, "group/project", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026 Q4"}, values)
+}
+
+func TestCompleteUsers(t *testing.T) {
+	h, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/group/project/users", r.URL.Path)
+		assert.Equal(t, "ali", r.URL.Query().Get("search"))
+		json.NewEncoder(w).Encode([]map[string]any{{"id": 7, "username": "alice", "name": "Alice"}})
+	})
+	defer cleanup()
+
+	values, err := completeUsers(h, "group/project", "@ali")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, values)
+}
+
+func TestCompleteBranches_Cached(t *testing.T) {
+	var requests atomic.Int32
+	h, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode([]map[string]any{{"name": "main"}})
+	})
+	defer cleanup()
+
+	for range 3 {
+		values, err := completeBranches(h, "group/project", "ma")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main"}, values)
+	}
+	assert.Equal(t, int32(1), requests.Load())
+
+	_, err := completeBranches(h, "other/project", "ma")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestProjectFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]string
+		want string
+	}{
+		{name: "prompt argument", args: map[string]string{"project_id": "group/project"}, want: "group/project"},
+		{name: "URL-encoded template variable", args: map[string]string{"project": "group%2Fproject"}, want: "group/project"},
+		{name: "numeric ID", args: map[string]string{"project_id": "42"}, want: "42"},
+		{name: "not filled in", args: map[string]string{"source_branch": "main"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, projectFrom(tt.args))
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package discussion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// CreateDraftNoteInput は create_draft_note の入力パラメータ
+type CreateDraftNoteInput struct {
+	ProjectID             string        `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID       int           `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Body                  string        `json:"body" jsonschema:"description:Draft note body text"`
+	Position              *DiffPosition `json:"position,omitempty" jsonschema:"description:Position for a line comment (omit for a general comment)"`
+	InReplyToDiscussionID string        `json:"in_reply_to_discussion_id,omitempty" jsonschema:"description:Discussion ID to reply to"`
+	ResolveDiscussion     *bool         `json:"resolve_discussion,omitempty" jsonschema:"description:Resolve the discussion when the reply is published"`
+	registry.DryRunOption
+}
+
+// DraftNoteInfo は下書きコメント情報
+type DraftNoteInfo struct {
+	ID                int64  `json:"id"`
+	Body              string `json:"body"`
+	DiscussionID      string `json:"discussion_id,omitempty"`
+	ResolveDiscussion bool   `json:"resolve_discussion"`
+	OldPath           string `json:"old_path,omitempty"`
+	NewPath           string `json:"new_path,omitempty"`
+	OldLine           int64  `json:"old_line,omitempty"`
+	NewLine           int64  `json:"new_line,omitempty"`
+}
+
+// CreateDraftNoteOutput は create_draft_note の出力
+type CreateDraftNoteOutput struct {
+	DraftNoteInfo
+	Position *ResolvedPositionInfo `json:"position,omitempty"`
+}
+
+// ListDraftNotesInput は list_draft_notes の入力パラメータ
+type ListDraftNotesInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Page            int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage         int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
+}
+
+// ListDraftNotesOutput は list_draft_notes の出力
+type ListDraftNotesOutput struct {
+	DraftNotes []DraftNoteInfo `json:"draft_notes"`
+}
+
+// UpdateDraftNoteInput は update_draft_note の入力パラメータ
+type UpdateDraftNoteInput struct {
+	ProjectID       string        `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int           `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	DraftNoteID     int           `json:"draft_note_id" jsonschema:"minimum:1,description:Draft note ID"`
+	Body            *string       `json:"body,omitempty" jsonschema:"description:New draft note body text"`
+	Position        *DiffPosition `json:"position,omitempty" jsonschema:"description:New position for the draft note"`
+	registry.DryRunOption
+}
+
+// UpdateDraftNoteOutput は update_draft_note の出力
+type UpdateDraftNoteOutput = CreateDraftNoteOutput
+
+// DeleteDraftNoteInput は delete_draft_note の入力パラメータ
+type DeleteDraftNoteInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	DraftNoteID     int    `json:"draft_note_id" jsonschema:"minimum:1,description:Draft note ID to delete"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// DeleteDraftNoteOutput は delete_draft_note の出力
+type DeleteDraftNoteOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// PublishReviewInput は publish_review の入力パラメータ
+type PublishReviewInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Summary         string `json:"summary,omitempty" jsonschema:"description:Review summary posted as a general comment after publishing"`
+	Approve         bool   `json:"approve,omitempty" jsonschema:"description:Approve the merge request after publishing"`
+	registry.DryRunOption
+}
+
+// PublishReviewOutput は publish_review の出力
+type PublishReviewOutput struct {
+	PublishedCount int   `json:"published_count"`
+	SummaryNoteID  int64 `json:"summary_note_id,omitempty"`
+	Approved       bool  `json:"approved"`
+	// ApprovalError は公開後に承認だけが失敗した場合のエラーメッセージ
+	ApprovalError string `json:"approval_error,omitempty"`
+}
+
+// registerDraftTools は下書きコメント関連ツールを登録する
+func registerDraftTools(reg *registry.Registry) {
+	registry.RegisterTool(reg, "create_draft_note",
+		"GitLab Merge Request に下書きコメント（一般コメントまたは行コメント）を作成します。publish_review でまとめて公開されます",
+		func(ctx context.Context, req *mcp.CallToolRequest, input CreateDraftNoteInput) (*mcp.CallToolResult, CreateDraftNoteOutput, error) {
+			return createDraftNoteHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "list_draft_notes",
+		"GitLab Merge Request の自分の下書きコメント一覧を取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input ListDraftNotesInput) (*mcp.CallToolResult, ListDraftNotesOutput, error) {
+			return listDraftNotesHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "update_draft_note",
+		"GitLab Merge Request の下書きコメントを更新します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input UpdateDraftNoteInput) (*mcp.CallToolResult, UpdateDraftNoteOutput, error) {
+			return updateDraftNoteHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "delete_draft_note",
+		"GitLab Merge Request の下書きコメントを削除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input DeleteDraftNoteInput) (*mcp.CallToolResult, DeleteDraftNoteOutput, error) {
+			return deleteDraftNoteHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeleteDraftNoteInput) (string, error) {
+			return deleteDraftNoteSummary(holder.client, input)
+		}))
+
+	registry.RegisterTool(reg, "publish_review",
+		"GitLab Merge Request の下書きコメントをすべて公開し、任意でサマリーコメントの投稿と承認を行います",
+		func(ctx context.Context, req *mcp.CallToolRequest, input PublishReviewInput) (*mcp.CallToolResult, PublishReviewOutput, error) {
+			return publishReviewHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+}
+
+func toDraftNoteInfo(n *gogitlab.DraftNote) DraftNoteInfo {
+	info := DraftNoteInfo{
+		ID:                n.ID,
+		Body:              n.Note,
+		DiscussionID:      n.DiscussionID,
+		ResolveDiscussion: n.ResolveDiscussion,
+	}
+	if p := n.Position; p != nil {
+		info.OldPath = p.OldPath
+		info.NewPath = p.NewPath
+		info.OldLine = p.OldLine
+		info.NewLine = p.NewLine
+	}
+	return info
+}
+
+func createDraftNoteHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CreateDraftNoteInput) (*mcp.CallToolResult, CreateDraftNoteOutput, error) {
+	opts := &gitlab.CreateDraftNoteOptions{
+		Note:                  input.Body,
+		InReplyToDiscussionID: input.InReplyToDiscussionID,
+		ResolveDiscussion:     input.ResolveDiscussion,
+	}
+
+	var resolvedInfo *ResolvedPositionInfo
+	if input.Position != nil {
+		opts.Position = &gitlab.DiffPositionOptions{}
+		var err error
+		resolvedInfo, err = applyPosition(client, input.ProjectID, input.MergeRequestIID, input.Position, opts.Position)
+		if err != nil {
+			return nil, CreateDraftNoteOutput{}, err
+		}
+	}
+
+	note, err := client.CreateDraftNote(input.ProjectID, input.MergeRequestIID, opts)
+	if err != nil {
+		return nil, CreateDraftNoteOutput{}, err
+	}
+
+	return nil, CreateDraftNoteOutput{
+		DraftNoteInfo: toDraftNoteInfo(note),
+		Position:      resolvedInfo,
+	}, nil
+}
+
+func listDraftNotesHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListDraftNotesInput) (*mcp.CallToolResult, ListDraftNotesOutput, error) {
+	notes, err := client.ListDraftNotes(input.ProjectID, input.MergeRequestIID, &gitlab.PaginationOptions{
+		Page:    input.Page,
+		PerPage: input.PerPage,
+	})
+	if err != nil {
+		return nil, ListDraftNotesOutput{}, err
+	}
+
+	output := ListDraftNotesOutput{DraftNotes: make([]DraftNoteInfo, len(notes))}
+	for i, n := range notes {
+		output.DraftNotes[i] = toDraftNoteInfo(n)
+	}
+	return nil, output, nil
+}
+
+func updateDraftNoteHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input UpdateDraftNoteInput) (*mcp.CallToolResult, UpdateDraftNoteOutput, error) {
+	opts := &gitlab.UpdateDraftNoteOptions{
+		Note: input.Body,
+	}
+
+	var resolvedInfo *ResolvedPositionInfo
+	if input.Position != nil {
+		opts.Position = &gitlab.DiffPositionOptions{}
+		var err error
+		resolvedInfo, err = applyPosition(client, input.ProjectID, input.MergeRequestIID, input.Position, opts.Position)
+		if err != nil {
+			return nil, UpdateDraftNoteOutput{}, err
+		}
+	}
+
+	note, err := client.UpdateDraftNote(input.ProjectID, input.MergeRequestIID, input.DraftNoteID, opts)
+	if err != nil {
+		return nil, UpdateDraftNoteOutput{}, err
+	}
+
+	return nil, UpdateDraftNoteOutput{
+		DraftNoteInfo: toDraftNoteInfo(note),
+		Position:      resolvedInfo,
+	}, nil
+}
+
+// deleteDraftNoteSummary は確認のために削除する下書きコメントを要約する
+func deleteDraftNoteSummary(client *gitlab.Client, input DeleteDraftNoteInput) (string, error) {
+	note, err := client.GetDraftNote(input.ProjectID, input.MergeRequestIID, input.DraftNoteID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("delete draft note %d on !%d: '%s'", note.ID, input.MergeRequestIID, summarizeBody(note.Note)), nil
+}
+
+func deleteDraftNoteHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeleteDraftNoteInput) (*mcp.CallToolResult, DeleteDraftNoteOutput, error) {
+	err := client.DeleteDraftNote(input.ProjectID, input.MergeRequestIID, input.DraftNoteID)
+	if err != nil {
+		return nil, DeleteDraftNoteOutput{}, err
+	}
+
+	return nil, DeleteDraftNoteOutput{
+		Success: true,
+		Message: "Draft note deleted successfully",
+	}, nil
+}
+
+func publishReviewHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input PublishReviewInput) (*mcp.CallToolResult, PublishReviewOutput, error) {
+	// 公開前に件数を数えておく（公開 API は件数を返さないため）
+	drafts, err := client.ListAllDraftNotes(input.ProjectID, input.MergeRequestIID)
+	if err != nil {
+		return nil, PublishReviewOutput{}, err
+	}
+	count := len(drafts)
+
+	// サマリーも下書きとして作成し、他の下書きと一緒にまとめて公開する
+	var summary *gogitlab.DraftNote
+	if input.Summary != "" {
+		summary, err = client.CreateDraftNote(input.ProjectID, input.MergeRequestIID, &gitlab.CreateDraftNoteOptions{Note: input.Summary})
+		if err != nil {
+			return nil, PublishReviewOutput{}, err
+		}
+		count++
+	}
+
+	if count > 0 {
+		if err := client.PublishAllDraftNotes(input.ProjectID, input.MergeRequestIID); err != nil {
+			// 再実行でサマリーが重複しないよう、作成したサマリーの下書きは取り消す
+			if summary != nil {
+				_ = client.DeleteDraftNote(input.ProjectID, input.MergeRequestIID, int(summary.ID))
+			}
+			return nil, PublishReviewOutput{}, err
+		}
+	}
+
+	output := PublishReviewOutput{PublishedCount: count}
+
+	// ドライランではサマリーを公開していないため、公開されたコメントを探さない
+	if summary != nil && !client.IsDryRun() {
+		output.SummaryNoteID = findPublishedSummary(client, input.ProjectID, input.MergeRequestIID, summary)
+	}
+
+	// ここまでで公開済みのため、承認の失敗はエラーにせず結果と一緒に返す
+	if input.Approve {
+		if _, err := client.ApproveMergeRequest(input.ProjectID, input.MergeRequestIID); err != nil {
+			output.ApprovalError = err.Error()
+		} else {
+			output.Approved = true
+		}
+	}
+
+	return nil, output, nil
+}
+
+// findPublishedSummary は公開されたサマリーのコメント ID を探す
+// 下書きの ID は公開後のコメント ID と異なるため、新しいコメントから本文と作成者で照合する
+// 見つからない場合は 0 を返す
+func findPublishedSummary(client *gitlab.Client, projectID string, mrIID int, summary *gogitlab.DraftNote) int64 {
+	notes, err := client.ListRecentMergeRequestNotes(projectID, mrIID, &gitlab.PaginationOptions{PerPage: 20})
+	if err != nil {
+		return 0
+	}
+	for _, n := range notes {
+		if !n.System && n.Body == summary.Note && n.Author.ID == summary.AuthorID {
+			return n.ID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,357 @@
+package discussion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDraftNoteTool(t *testing.T) {
+	t.Run("creates general draft note", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/draft_notes", r.URL.Path)
+			assert.Equal(t, "POST", r.Method)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"id": 1, "note": "Overall looks good"})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("create_draft_note"))
+
+		input := CreateDraftNoteInput{ProjectID: "test-project", MergeRequestIID: 1, Body: "Overall looks good"}
+
+		ctx := context.Background()
+		_, output, err := createDraftNoteHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), output.ID)
+		assert.Nil(t, output.Position)
+	})
+
+	t.Run("resolves position for line draft note", func(t *testing.T) {
+		versions := versionHandler(t, nil)
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v4/projects/test-project/merge_requests/1/draft_notes" {
+				versions(w, r)
+				return
+			}
+
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			position := body["position"].(map[string]any)
+			assert.Equal(t, "pkg/old.go", position["old_path"])
+			assert.Equal(t, "head", position["head_sha"])
+			assert.Equal(t, float64(11), position["new_line"])
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":       2,
+				"note":     "Nit",
+				"position": map[string]any{"new_path": "pkg/new.go", "old_path": "pkg/old.go", "new_line": 11},
+			})
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		newLine := 11
+		input := CreateDraftNoteInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			Body:            "Nit",
+			Position:        &DiffPosition{NewPath: "pkg/new.go", NewLine: &newLine},
+		}
+
+		ctx := context.Background()
+		_, output, err := createDraftNoteHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "pkg/new.go", output.NewPath)
+		assert.Equal(t, int64(11), output.NewLine)
+		require.NotNil(t, output.Position)
+		assert.Equal(t, "added", output.Position.LineType)
+	})
+}
+
+func TestListDraftNotesTool(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/draft_notes", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 1, "note": "General"},
+			{"id": 2, "note": "Reply", "discussion_id": "disc1", "resolve_discussion": true},
+		})
+	}
+
+	client, reg, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	assert.True(t, reg.IsRegistered("list_draft_notes"))
+
+	input := ListDraftNotesInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+	ctx := context.Background()
+	_, output, err := listDraftNotesHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	require.Len(t, output.DraftNotes, 2)
+	assert.Equal(t, "disc1", output.DraftNotes[1].DiscussionID)
+	assert.True(t, output.DraftNotes[1].ResolveDiscussion)
+}
+
+func TestUpdateDraftNoteTool(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/draft_notes/2", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "Updated", body["note"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 2, "note": "Updated"})
+	}
+
+	client, reg, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	assert.True(t, reg.IsRegistered("update_draft_note"))
+
+	body := "Updated"
+	input := UpdateDraftNoteInput{ProjectID: "test-project", MergeRequestIID: 1, DraftNoteID: 2, Body: &body}
+
+	ctx := context.Background()
+	_, output, err := updateDraftNoteHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", output.Body)
+}
+
+func TestDeleteDraftNoteTool(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/draft_notes/2", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, reg, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	assert.True(t, reg.IsRegistered("delete_draft_note"))
+
+	input := DeleteDraftNoteInput{ProjectID: "test-project", MergeRequestIID: 1, DraftNoteID: 2}
+
+	ctx := context.Background()
+	_, output, err := deleteDraftNoteHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	assert.True(t, output.Success)
+}
+
+func TestPublishReviewTool(t *testing.T) {
+	t.Run("publishes drafts with the summary and approves", func(t *testing.T) {
+		var calls []string
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method + " " + r.URL.Path {
+			case "GET /api/v4/projects/test-project/merge_requests/1":
+				json.NewEncoder(w).Encode(map[string]any{"iid": 1})
+			case "POST /api/v4/projects/test-project/merge_requests/1/draft_notes":
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				assert.Equal(t, "LGTM with nits", body["note"])
+				json.NewEncoder(w).Encode(map[string]any{"id": 3, "author_id": 7, "note": "LGTM with nits"})
+			case "GET /api/v4/projects/test-project/merge_requests/1/draft_notes":
+				json.NewEncoder(w).Encode([]map[string]any{{"id": 1, "note": "a"}, {"id": 2, "note": "b"}})
+			case "POST /api/v4/projects/test-project/merge_requests/1/draft_notes/bulk_publish":
+				w.WriteHeader(http.StatusNoContent)
+			case "GET /api/v4/projects/test-project/merge_requests/1/notes":
+				assert.Equal(t, "desc", r.URL.Query().Get("sort"))
+				json.NewEncoder(w).Encode([]map[string]any{
+					{"id": 51, "body": "LGTM with nits", "author": map[string]any{"id": 8}},
+					{"id": 50, "body": "LGTM with nits", "author": map[string]any{"id": 7}},
+				})
+			case "POST /api/v4/projects/test-project/merge_requests/1/approve":
+				json.NewEncoder(w).Encode(map[string]any{"approved": true})
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("publish_review"))
+
+		input := PublishReviewInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			Summary:         "LGTM with nits",
+			Approve:         true,
+		}
+
+		ctx := context.Background()
+		_, output, err := publishReviewHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, output.PublishedCount)
+		assert.Equal(t, int64(50), output.SummaryNoteID)
+		assert.True(t, output.Approved)
+		assert.Empty(t, output.ApprovalError)
+		assert.Equal(t, []string{
+			"GET /api/v4/projects/test-project/merge_requests/1/draft_notes",
+			"POST /api/v4/projects/test-project/merge_requests/1/draft_notes",
+			"POST /api/v4/projects/test-project/merge_requests/1/draft_notes/bulk_publish",
+			"GET /api/v4/projects/test-project/merge_requests/1/notes",
+			"POST /api/v4/projects/test-project/merge_requests/1/approve",
+		}, calls)
+	})
+
+	t.Run("counts drafts across pages", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method + " " + r.URL.Path {
+			case "GET /api/v4/projects/test-project/merge_requests/1":
+				json.NewEncoder(w).Encode(map[string]any{"iid": 1})
+			case "GET /api/v4/projects/test-project/merge_requests/1/draft_notes":
+				if r.URL.Query().Get("page") == "2" {
+					json.NewEncoder(w).Encode([]map[string]any{{"id": 3}})
+					return
+				}
+				w.Header().Set("X-Next-Page", "2")
+				json.NewEncoder(w).Encode([]map[string]any{{"id": 1}, {"id": 2}})
+			case "POST /api/v4/projects/test-project/merge_requests/1/draft_notes/bulk_publish":
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := PublishReviewInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+		ctx := context.Background()
+		_, output, err := publishReviewHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, output.PublishedCount)
+	})
+
+	t.Run("discards the summary draft when publishing fails", func(t *testing.T) {
+		var deleted bool
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method + " " + r.URL.Path {
+			case "GET /api/v4/projects/test-project/merge_requests/1":
+				json.NewEncoder(w).Encode(map[string]any{"iid": 1})
+			case "POST /api/v4/projects/test-project/merge_requests/1/draft_notes":
+				json.NewEncoder(w).Encode(map[string]any{"id": 3, "note": "summary"})
+			case "GET /api/v4/projects/test-project/merge_requests/1/draft_notes":
+				json.NewEncoder(w).Encode([]map[string]any{})
+			case "GET /api/v4/projects/test-project/merge_requests/1/draft_notes/3":
+				json.NewEncoder(w).Encode(map[string]any{"id": 3, "note": "summary"})
+			case "POST /api/v4/projects/test-project/merge_requests/1/draft_notes/bulk_publish":
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]any{"message": "403 Forbidden"})
+			case "DELETE /api/v4/projects/test-project/merge_requests/1/draft_notes/3":
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := PublishReviewInput{ProjectID: "test-project", MergeRequestIID: 1, Summary: "summary"}
+
+		ctx := context.Background()
+		_, _, err := publishReviewHandler(client, ctx, nil, input)
+
+		require.Error(t, err)
+		assert.True(t, deleted)
+	})
+
+	t.Run("returns partial results when approval fails", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method + " " + r.URL.Path {
+			case "GET /api/v4/projects/test-project/merge_requests/1":
+				json.NewEncoder(w).Encode(map[string]any{"iid": 1})
+			case "GET /api/v4/projects/test-project/merge_requests/1/draft_notes":
+				json.NewEncoder(w).Encode([]map[string]any{{"id": 1}})
+			case "POST /api/v4/projects/test-project/merge_requests/1/draft_notes/bulk_publish":
+				w.WriteHeader(http.StatusNoContent)
+			case "POST /api/v4/projects/test-project/merge_requests/1/approve":
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]any{"message": "401 Unauthorized"})
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := PublishReviewInput{ProjectID: "test-project", MergeRequestIID: 1, Approve: true}
+
+		ctx := context.Background()
+		_, output, err := publishReviewHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.PublishedCount)
+		assert.False(t, output.Approved)
+		assert.NotEmpty(t, output.ApprovalError)
+	})
+
+	t.Run("skips publishing when there are no drafts", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]map[string]any{})
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := PublishReviewInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+		ctx := context.Background()
+		_, output, err := publishReviewHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, output.PublishedCount)
+		assert.False(t, output.Approved)
+	})
+}
+
+func TestDeleteDraftNoteSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/draft_notes/9", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 9, "note": "Consider\nrenaming this"})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := deleteDraftNoteSummary(client, DeleteDraftNoteInput{ProjectID: "test-project", MergeRequestIID: 1, DraftNoteID: 9})
+
+	require.NoError(t, err)
+	assert.Equal(t, "delete draft note 9 on !1: 'Consider renaming this'", summary)
+}
@@ -2,6 +2,8 @@ package discussion
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/kqns91/gitlab-mcp/internal/gitlab"
 	"github.com/kqns91/gitlab-mcp/internal/registry"
@@ -11,8 +13,9 @@ import (
 // AddCommentInput は add_merge_request_comment の入力パラメータ
 type AddCommentInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
 	Body            string `json:"body" jsonschema:"description:Comment body text"`
+	registry.DryRunOption
 }
 
 // AddCommentOutput は add_merge_request_comment の出力
@@ -24,35 +27,87 @@ type AddCommentOutput struct {
 }
 
 // DiffPosition は差分内の位置を指定する
+// SHA が省略された場合は MR の最新の差分バージョンから SHA と行の組み合わせを自動で解決する
 type DiffPosition struct {
-	BaseSHA  string `json:"base_sha" jsonschema:"description:Base commit SHA"`
-	StartSHA string `json:"start_sha" jsonschema:"description:Start commit SHA"`
-	HeadSHA  string `json:"head_sha" jsonschema:"description:Head commit SHA"`
-	OldPath  string `json:"old_path" jsonschema:"description:Old file path"`
+	BaseSHA  string `json:"base_sha,omitempty" jsonschema:"description:Base commit SHA (resolved from the latest MR diff version if omitted)"`
+	StartSHA string `json:"start_sha,omitempty" jsonschema:"description:Start commit SHA (resolved from the latest MR diff version if omitted)"`
+	HeadSHA  string `json:"head_sha,omitempty" jsonschema:"description:Head commit SHA (resolved from the latest MR diff version if omitted)"`
+	OldPath  string `json:"old_path,omitempty" jsonschema:"description:Old file path (resolved automatically for renamed files)"`
 	NewPath  string `json:"new_path" jsonschema:"description:New file path"`
-	OldLine  *int   `json:"old_line,omitempty" jsonschema:"description:Line number in old file"`
-	NewLine  *int   `json:"new_line,omitempty" jsonschema:"description:Line number in new file"`
+	OldLine  *int   `json:"old_line,omitempty" jsonschema:"minimum:1,description:Line number in old file (use for removed lines). For multi-line comments this is the last line"`
+	NewLine  *int   `json:"new_line,omitempty" jsonschema:"minimum:1,description:Line number in new file (use for added or unchanged lines). For multi-line comments this is the last line"`
+
+	StartOldLine *int `json:"start_old_line,omitempty" jsonschema:"minimum:1,description:First line of a multi-line comment in old file (removed lines)"`
+	StartNewLine *int `json:"start_new_line,omitempty" jsonschema:"minimum:1,description:First line of a multi-line comment in new file (added or unchanged lines)"`
 }
 
 // AddDiscussionInput は add_merge_request_discussion の入力パラメータ
 type AddDiscussionInput struct {
 	ProjectID       string        `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int           `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int           `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
 	Body            string        `json:"body" jsonschema:"description:Discussion body text"`
 	Position        *DiffPosition `json:"position,omitempty" jsonschema:"description:Position for line comment"`
+	registry.DryRunOption
+}
+
+// LinePositionInfo は複数行コメントの範囲の端点
+type LinePositionInfo struct {
+	LineCode string `json:"line_code"`
+	Type     string `json:"type,omitempty"`
+	OldLine  *int   `json:"old_line,omitempty"`
+	NewLine  *int   `json:"new_line,omitempty"`
+}
+
+// LineRangeInfo は複数行コメントの範囲
+type LineRangeInfo struct {
+	Start LinePositionInfo `json:"start"`
+	End   LinePositionInfo `json:"end"`
+}
+
+// ResolvedPositionInfo は自動解決された差分内の位置
+type ResolvedPositionInfo struct {
+	BaseSHA   string         `json:"base_sha"`
+	StartSHA  string         `json:"start_sha"`
+	HeadSHA   string         `json:"head_sha"`
+	OldPath   string         `json:"old_path"`
+	NewPath   string         `json:"new_path"`
+	OldLine   *int           `json:"old_line,omitempty"`
+	NewLine   *int           `json:"new_line,omitempty"`
+	LineType  string         `json:"line_type"`
+	LineRange *LineRangeInfo `json:"line_range,omitempty"`
 }
 
 // AddDiscussionOutput は add_merge_request_discussion の出力
 type AddDiscussionOutput struct {
-	ID string `json:"id"`
+	ID       string                `json:"id"`
+	Position *ResolvedPositionInfo `json:"position,omitempty"`
+}
+
+// AddSuggestionInput は add_merge_request_suggestion の入力パラメータ
+type AddSuggestionInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	FilePath        string `json:"file_path" jsonschema:"description:File path in the new version of the file"`
+	StartLine       *int   `json:"start_line,omitempty" jsonschema:"minimum:1,description:First line (new file) to replace (default: end_line)"`
+	EndLine         int    `json:"end_line" jsonschema:"minimum:1,description:Last line (new file) to replace"`
+	Suggestion      string `json:"suggestion" jsonschema:"description:Replacement text for the lines start_line..end_line (empty string removes them)"`
+	Comment         string `json:"comment,omitempty" jsonschema:"description:Explanation shown above the suggestion"`
+	registry.DryRunOption
+}
+
+// AddSuggestionOutput は add_merge_request_suggestion の出力
+type AddSuggestionOutput struct {
+	ID       string                `json:"id"`
+	Body     string                `json:"body"`
+	Position *ResolvedPositionInfo `json:"position,omitempty"`
 }
 
 // ListDiscussionsInput は list_merge_request_discussions の入力パラメータ
 type ListDiscussionsInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
-	Page            int    `json:"page,omitempty" jsonschema:"description:Page number (default: 1)"`
-	PerPage         int    `json:"per_page,omitempty" jsonschema:"description:Number of items per page (default: 100, max: 100)"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Page            int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage         int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
 }
 
 // DiscussionNote はディスカッション内のノート情報
@@ -78,9 +133,10 @@ type ListDiscussionsOutput struct {
 // ResolveDiscussionInput は resolve_discussion の入力パラメータ
 type ResolveDiscussionInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
 	DiscussionID    string `json:"discussion_id" jsonschema:"description:Discussion ID"`
 	Resolved        bool   `json:"resolved" jsonschema:"description:Set to true to resolve or false to unresolve"`
+	registry.DryRunOption
 }
 
 // ResolveDiscussionOutput は resolve_discussion の出力
@@ -92,8 +148,10 @@ type ResolveDiscussionOutput struct {
 // DeleteCommentInput は delete_merge_request_comment の入力パラメータ
 type DeleteCommentInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
-	NoteID          int    `json:"note_id" jsonschema:"description:Note ID to delete"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	NoteID          int    `json:"note_id" jsonschema:"minimum:1,description:Note ID to delete"`
+	registry.Confirmation
+	registry.DryRunOption
 }
 
 // DeleteCommentOutput は delete_merge_request_comment の出力
@@ -105,9 +163,10 @@ type DeleteCommentOutput struct {
 // ReplyToCommentInput は reply_to_merge_request_comment の入力パラメータ
 type ReplyToCommentInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
 	DiscussionID    string `json:"discussion_id" jsonschema:"description:Discussion ID to reply to"`
 	Body            string `json:"body" jsonschema:"description:Reply body text"`
+	registry.DryRunOption
 }
 
 // ReplyToCommentOutput は reply_to_merge_request_comment の出力
@@ -132,38 +191,48 @@ func Register(reg *registry.Registry, client *gitlab.Client) {
 	registry.RegisterTool(reg, "add_merge_request_comment",
 		"GitLab Merge Request に一般コメントを追加します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input AddCommentInput) (*mcp.CallToolResult, AddCommentOutput, error) {
-			return addCommentHandler(holder.client, ctx, req, input)
+			return addCommentHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "add_merge_request_discussion",
-		"GitLab Merge Request に行コメント（ディスカッション）を作成します",
+		"GitLab Merge Request に行コメント（ディスカッション）を作成します（SHA と行の組み合わせは最新の差分から自動解決されます）",
 		func(ctx context.Context, req *mcp.CallToolRequest, input AddDiscussionInput) (*mcp.CallToolResult, AddDiscussionOutput, error) {
-			return addDiscussionHandler(holder.client, ctx, req, input)
+			return addDiscussionHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "add_merge_request_suggestion",
+		"GitLab Merge Request の差分に、作成者がワンクリックで適用できる変更提案（suggestion）を作成します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input AddSuggestionInput) (*mcp.CallToolResult, AddSuggestionOutput, error) {
+			return addSuggestionHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "list_merge_request_discussions",
 		"GitLab Merge Request のディスカッション一覧を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ListDiscussionsInput) (*mcp.CallToolResult, ListDiscussionsOutput, error) {
 			return listDiscussionsHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "resolve_discussion",
 		"GitLab Merge Request のディスカッションを解決済み/未解決に設定します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ResolveDiscussionInput) (*mcp.CallToolResult, ResolveDiscussionOutput, error) {
-			return resolveDiscussionHandler(holder.client, ctx, req, input)
+			return resolveDiscussionHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "delete_merge_request_comment",
 		"GitLab Merge Request のコメントを削除します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input DeleteCommentInput) (*mcp.CallToolResult, DeleteCommentOutput, error) {
-			return deleteCommentHandler(holder.client, ctx, req, input)
-		})
+			return deleteCommentHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeleteCommentInput) (string, error) {
+			return deleteCommentSummary(holder.client, input)
+		}))
 
 	registry.RegisterTool(reg, "reply_to_merge_request_comment",
 		"GitLab Merge Request のディスカッションに返信を追加します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ReplyToCommentInput) (*mcp.CallToolResult, ReplyToCommentOutput, error) {
-			return replyToCommentHandler(holder.client, ctx, req, input)
+			return replyToCommentHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
+
+	registerDraftTools(reg)
 }
 
 func addCommentHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input AddCommentInput) (*mcp.CallToolResult, AddCommentOutput, error) {
@@ -187,18 +256,80 @@ func addCommentHandler(client *gitlab.Client, ctx context.Context, req *mcp.Call
 	}, nil
 }
 
+// applyPosition は入力された位置を差分内の位置のオプションに設定する
+// SHA が省略されているか複数行が指定された場合は MR の最新差分から位置を解決し、その結果を返す
+func applyPosition(client *gitlab.Client, projectID string, mrIID int, pos *DiffPosition, opts *gitlab.DiffPositionOptions) (*ResolvedPositionInfo, error) {
+	isRange := pos.StartNewLine != nil || pos.StartOldLine != nil
+	if pos.BaseSHA != "" && pos.StartSHA != "" && pos.HeadSHA != "" && !isRange {
+		// SHA がすべて指定されている場合は指定どおりの位置を使う
+		opts.FilePath = pos.NewPath
+		opts.OldPath = pos.OldPath
+		opts.OldLine = pos.OldLine
+		opts.NewLine = pos.NewLine
+		opts.BaseSHA = pos.BaseSHA
+		opts.HeadSHA = pos.HeadSHA
+		opts.StartSHA = pos.StartSHA
+		return nil, nil
+	}
+
+	filePath := pos.NewPath
+	if filePath == "" {
+		filePath = pos.OldPath
+	}
+	resolved, err := client.ResolveMergeRequestDiffRange(projectID, mrIID, filePath, pos.StartNewLine, pos.StartOldLine, pos.NewLine, pos.OldLine)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.FilePath = resolved.NewPath
+	opts.OldPath = resolved.OldPath
+	opts.OldLine = resolved.OldLine
+	opts.NewLine = resolved.NewLine
+	opts.BaseSHA = resolved.BaseSHA
+	opts.HeadSHA = resolved.HeadSHA
+	opts.StartSHA = resolved.StartSHA
+	opts.LineRange = resolved.LineRange
+
+	info := &ResolvedPositionInfo{
+		BaseSHA:  resolved.BaseSHA,
+		StartSHA: resolved.StartSHA,
+		HeadSHA:  resolved.HeadSHA,
+		OldPath:  resolved.OldPath,
+		NewPath:  resolved.NewPath,
+		OldLine:  resolved.OldLine,
+		NewLine:  resolved.NewLine,
+		LineType: resolved.LineType,
+	}
+	if r := resolved.LineRange; r != nil {
+		info.LineRange = &LineRangeInfo{
+			Start: toLinePositionInfo(r.Start),
+			End:   toLinePositionInfo(r.End),
+		}
+	}
+	return info, nil
+}
+
+func toLinePositionInfo(p gitlab.LinePosition) LinePositionInfo {
+	return LinePositionInfo{
+		LineCode: p.LineCode,
+		Type:     p.Type,
+		OldLine:  p.OldLine,
+		NewLine:  p.NewLine,
+	}
+}
+
 func addDiscussionHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input AddDiscussionInput) (*mcp.CallToolResult, AddDiscussionOutput, error) {
 	opts := &gitlab.CreateDiscussionOptions{
 		Body: input.Body,
 	}
 
+	var resolvedInfo *ResolvedPositionInfo
 	if input.Position != nil {
-		opts.FilePath = input.Position.NewPath
-		opts.OldLine = input.Position.OldLine
-		opts.NewLine = input.Position.NewLine
-		opts.BaseSHA = input.Position.BaseSHA
-		opts.HeadSHA = input.Position.HeadSHA
-		opts.StartSHA = input.Position.StartSHA
+		var err error
+		resolvedInfo, err = applyPosition(client, input.ProjectID, input.MergeRequestIID, input.Position, &opts.DiffPositionOptions)
+		if err != nil {
+			return nil, AddDiscussionOutput{}, err
+		}
 	}
 
 	discussion, err := client.CreateMergeRequestDiscussion(input.ProjectID, input.MergeRequestIID, opts)
@@ -207,7 +338,69 @@ func addDiscussionHandler(client *gitlab.Client, ctx context.Context, req *mcp.C
 	}
 
 	return nil, AddDiscussionOutput{
-		ID: discussion.ID,
+		ID:       discussion.ID,
+		Position: resolvedInfo,
+	}, nil
+}
+
+// buildSuggestionBody は置換テキストを GitLab の suggestion ブロックで囲んだコメント本文を作成する
+// コメントは最終行に付けるため、linesAbove はその上に含める行数になる
+func buildSuggestionBody(comment, suggestion string, linesAbove int) string {
+	// 置換テキストに含まれるバッククォートより長いフェンスを使う
+	fence := "```"
+	for strings.Contains(suggestion, fence) {
+		fence += "`"
+	}
+
+	var b strings.Builder
+	if comment != "" {
+		b.WriteString(comment)
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(&b, "%ssuggestion:-%d+0\n", fence, linesAbove)
+	if suggestion != "" {
+		b.WriteString(strings.TrimSuffix(suggestion, "\n"))
+		b.WriteString("\n")
+	}
+	b.WriteString(fence)
+	return b.String()
+}
+
+func addSuggestionHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input AddSuggestionInput) (*mcp.CallToolResult, AddSuggestionOutput, error) {
+	startLine := input.EndLine
+	if input.StartLine != nil {
+		startLine = *input.StartLine
+	}
+	if startLine > input.EndLine {
+		return nil, AddSuggestionOutput{}, &gitlab.MCPError{
+			Code:    gitlab.ErrCodeBadRequest,
+			Message: gitlab.Msg(gitlab.MsgStartLineAfterEnd),
+		}
+	}
+
+	endLine := input.EndLine
+	pos := &DiffPosition{NewPath: input.FilePath, NewLine: &endLine}
+	if startLine != endLine {
+		pos.StartNewLine = &startLine
+	}
+
+	opts := &gitlab.CreateDiscussionOptions{
+		Body: buildSuggestionBody(input.Comment, input.Suggestion, endLine-startLine),
+	}
+	resolvedInfo, err := applyPosition(client, input.ProjectID, input.MergeRequestIID, pos, &opts.DiffPositionOptions)
+	if err != nil {
+		return nil, AddSuggestionOutput{}, err
+	}
+
+	discussion, err := client.CreateMergeRequestDiscussion(input.ProjectID, input.MergeRequestIID, opts)
+	if err != nil {
+		return nil, AddSuggestionOutput{}, err
+	}
+
+	return nil, AddSuggestionOutput{
+		ID:       discussion.ID,
+		Body:     opts.Body,
+		Position: resolvedInfo,
 	}, nil
 }
 
@@ -265,6 +458,28 @@ func resolveDiscussionHandler(client *gitlab.Client, ctx context.Context, req *m
 	}, nil
 }
 
+// summaryBodyLength は確認で示すコメント本文の最大文字数
+const summaryBodyLength = 80
+
+// deleteCommentSummary は確認のために削除するコメントを要約する
+func deleteCommentSummary(client *gitlab.Client, input DeleteCommentInput) (string, error) {
+	note, err := client.GetMergeRequestNote(input.ProjectID, input.MergeRequestIID, input.NoteID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("delete comment %d by @%s on !%d: '%s'", note.ID, note.Author.Username, input.MergeRequestIID, summarizeBody(note.Body)), nil
+}
+
+// summarizeBody は確認で示すために本文の改行をまとめて summaryBodyLength 文字までに切り詰める
+func summarizeBody(text string) string {
+	body := []rune(strings.Join(strings.Fields(text), " "))
+	if len(body) > summaryBodyLength {
+		body = append(body[:summaryBodyLength], '…')
+	}
+	return string(body)
+}
+
 func deleteCommentHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeleteCommentInput) (*mcp.CallToolResult, DeleteCommentOutput, error) {
 	err := client.DeleteMergeRequestNote(input.ProjectID, input.MergeRequestIID, input.NoteID)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/kqns91/gitlab-mcp/internal/config"
@@ -173,6 +174,211 @@ func TestAddMergeRequestDiscussionTool(t *testing.T) {
 	})
 }
 
+// versionHandler は差分バージョンの取得とディスカッション作成を処理するハンドラを作成する
+func versionHandler(t *testing.T, onCreate func(body map[string]any)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/merge_requests/1/versions":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 5, "head_commit_sha": "head", "base_commit_sha": "base", "start_commit_sha": "start"},
+			})
+		case "/api/v4/projects/test-project/merge_requests/1/versions/5":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":               5,
+				"head_commit_sha":  "head",
+				"base_commit_sha":  "base",
+				"start_commit_sha": "start",
+				"diffs": []map[string]any{
+					{
+						"old_path":     "pkg/old.go",
+						"new_path":     "pkg/new.go",
+						"renamed_file": true,
+						"diff":         "@@ -10,3 +10,3 @@ func f() {\n \tx := 1\n-\ty := 2\n+\ty := 3\n \treturn\n",
+					},
+				},
+			})
+		case "/api/v4/projects/test-project/merge_requests/1/discussions":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			onCreate(body)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]any{"id": "resolved-disc"})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestAddMergeRequestDiscussionTool_ResolvePosition(t *testing.T) {
+	t.Run("fills SHAs and maps context line for renamed file", func(t *testing.T) {
+		var position map[string]any
+		client, _, cleanup := setupTestServer(t, versionHandler(t, func(body map[string]any) { position = body["position"].(map[string]any) }))
+		defer cleanup()
+
+		newLine := 10
+		input := AddDiscussionInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			Body:            "Why x?",
+			Position:        &DiffPosition{NewPath: "pkg/new.go", NewLine: &newLine},
+		}
+
+		ctx := context.Background()
+		_, output, err := addDiscussionHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "resolved-disc", output.ID)
+		require.NotNil(t, output.Position)
+		assert.Equal(t, "context", output.Position.LineType)
+
+		assert.Equal(t, "base", position["base_sha"])
+		assert.Equal(t, "start", position["start_sha"])
+		assert.Equal(t, "head", position["head_sha"])
+		assert.Equal(t, "pkg/old.go", position["old_path"])
+		assert.Equal(t, "pkg/new.go", position["new_path"])
+		assert.Equal(t, float64(10), position["old_line"])
+		assert.Equal(t, float64(10), position["new_line"])
+	})
+
+	t.Run("removed line sends only old_line", func(t *testing.T) {
+		var position map[string]any
+		client, _, cleanup := setupTestServer(t, versionHandler(t, func(body map[string]any) { position = body["position"].(map[string]any) }))
+		defer cleanup()
+
+		oldLine := 11
+		input := AddDiscussionInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			Body:            "Removed?",
+			Position:        &DiffPosition{NewPath: "pkg/new.go", OldLine: &oldLine},
+		}
+
+		ctx := context.Background()
+		_, output, err := addDiscussionHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "removed", output.Position.LineType)
+		assert.Equal(t, float64(11), position["old_line"])
+		assert.NotContains(t, position, "new_line")
+	})
+
+	t.Run("returns error when line is not in the diff", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, versionHandler(t, func(body map[string]any) {
+			t.Error("discussion should not be created")
+		}))
+		defer cleanup()
+
+		newLine := 42
+		input := AddDiscussionInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			Body:            "Out of range",
+			Position:        &DiffPosition{NewPath: "pkg/new.go", NewLine: &newLine},
+		}
+
+		ctx := context.Background()
+		_, _, err := addDiscussionHandler(client, ctx, nil, input)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pkg/new.go")
+	})
+}
+
+func TestAddMergeRequestDiscussionTool_LineRange(t *testing.T) {
+	var position map[string]any
+	client, _, cleanup := setupTestServer(t, versionHandler(t, func(body map[string]any) { position = body["position"].(map[string]any) }))
+	defer cleanup()
+
+	startLine, endLine := 10, 11
+	input := AddDiscussionInput{
+		ProjectID:       "test-project",
+		MergeRequestIID: 1,
+		Body:            "These two lines",
+		Position:        &DiffPosition{NewPath: "pkg/new.go", StartNewLine: &startLine, NewLine: &endLine},
+	}
+
+	ctx := context.Background()
+	_, output, err := addDiscussionHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, output.Position.LineRange)
+	assert.Equal(t, "new", output.Position.LineRange.End.Type)
+
+	lineRange := position["line_range"].(map[string]any)
+	start := lineRange["start"].(map[string]any)
+	end := lineRange["end"].(map[string]any)
+	assert.Regexp(t, `^[0-9a-f]{40}_10_10$`, start["line_code"])
+	assert.Regexp(t, `^[0-9a-f]{40}_12_11$`, end["line_code"])
+	assert.Equal(t, float64(11), position["new_line"])
+}
+
+func TestAddMergeRequestSuggestionTool(t *testing.T) {
+	t.Run("wraps replacement in suggestion block anchored to the last line", func(t *testing.T) {
+		var position map[string]any
+		var body string
+		handler := versionHandler(t, func(req map[string]any) {
+			position = req["position"].(map[string]any)
+			body = req["body"].(string)
+		})
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("add_merge_request_suggestion"))
+
+		startLine := 10
+		input := AddSuggestionInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			FilePath:        "pkg/new.go",
+			StartLine:       &startLine,
+			EndLine:         11,
+			Suggestion:      "\tx, y := 1, 3\n",
+			Comment:         "Combine the declarations",
+		}
+
+		ctx := context.Background()
+		_, output, err := addSuggestionHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "resolved-disc", output.ID)
+		assert.Equal(t, "Combine the declarations\n\n```suggestion:-1+0\n\tx, y := 1, 3\n```", body)
+		assert.Equal(t, body, output.Body)
+		assert.Equal(t, float64(11), position["new_line"])
+		assert.NotNil(t, position["line_range"])
+	})
+
+	t.Run("returns error when start_line is after end_line", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, versionHandler(t, func(body map[string]any) {
+			t.Error("discussion should not be created")
+		}))
+		defer cleanup()
+
+		startLine := 12
+		input := AddSuggestionInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			FilePath:        "pkg/new.go",
+			StartLine:       &startLine,
+			EndLine:         11,
+			Suggestion:      "x",
+		}
+
+		ctx := context.Background()
+		_, _, err := addSuggestionHandler(client, ctx, nil, input)
+
+		require.Error(t, err)
+	})
+}
+
+func TestBuildSuggestionBody(t *testing.T) {
+	assert.Equal(t, "```suggestion:-0+0\nfoo\n```", buildSuggestionBody("", "foo", 0))
+	assert.Equal(t, "```suggestion:-2+0\n```", buildSuggestionBody("", "", 2))
+	assert.Equal(t, "````suggestion:-0+0\n```go\ncode\n```\n````", buildSuggestionBody("", "```go\ncode\n```", 0))
+}
+
 func TestListMergeRequestDiscussionsTool(t *testing.T) {
 	t.Run("returns discussions list successfully", func(t *testing.T) {
 		handler := func(w http.ResponseWriter, r *http.Request) {
@@ -377,6 +583,49 @@ func TestDeleteMergeRequestCommentTool(t *testing.T) {
 	})
 }
 
+func TestDeleteCommentSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "short comment",
+			body: "Please rename\nthis variable",
+			want: "delete comment 123 by @alice on !1: 'Please rename this variable'",
+		},
+		{
+			name: "long comment is truncated",
+			body: strings.Repeat("a", 100),
+			want: "delete comment 123 by @alice on !1: '" + strings.Repeat("a", 80) + "…'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1/notes/123", r.URL.Path)
+				assert.Equal(t, "GET", r.Method)
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]any{
+					"id":     123,
+					"body":   tt.body,
+					"author": map[string]any{"username": "alice"},
+				})
+			}
+
+			client, _, cleanup := setupTestServer(t, handler)
+			defer cleanup()
+
+			summary, err := deleteCommentSummary(client, DeleteCommentInput{ProjectID: "test-project", MergeRequestIID: 1, NoteID: 123})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, summary)
+		})
+	}
+}
+
 func TestReplyToMergeRequestCommentTool(t *testing.T) {
 	t.Run("replies to discussion successfully", func(t *testing.T) {
 		handler := func(w http.ResponseWriter, r *http.Request) {
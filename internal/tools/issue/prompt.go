@@ -0,0 +1,93 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// promptDiscussionsPerPage はプロンプトに含めるディスカッションの最大数
+const promptDiscussionsPerPage = 100
+
+// summarizeInstructions は summarize_issue_thread のモデルへの指示
+const summarizeInstructions = `Summarize the GitLab issue and its discussion below for someone who has not read it. Use these sections:
+- Problem: what the issue is about, in two or three sentences
+- Decisions: what has been agreed, and by whom
+- Open questions: unresolved points and disagreements
+- Action items: concrete next steps with owners where they are stated
+
+Be faithful to the thread: attribute opinions to their authors and do not invent conclusions that were not reached.`
+
+// registerPrompts は Issue のプロンプトを登録する
+func registerPrompts(reg *registry.Registry) {
+	registry.RegisterPrompt(reg, &mcp.Prompt{
+		Name:        "summarize_issue_thread",
+		Title:       "Summarize Issue Thread",
+		Description: "Issue の説明とディスカッションを要約し、決定事項・未解決の論点・次のアクションをまとめる",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "project_id", Description: "Project ID or URL-encoded path", Required: true},
+			{Name: "issue_iid", Description: "Issue IID", Required: true},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+		return summarizeIssueThreadPrompt(holder.client, ctx, args)
+	})
+}
+
+func summarizeIssueThreadPrompt(client *gitlab.Client, ctx context.Context, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+	iid, err := args.Int("issue_iid")
+	if err != nil {
+		return nil, err
+	}
+	projectID := args["project_id"]
+
+	_, issue, err := getIssueHandler(client, ctx, nil, GetIssueInput{ProjectID: projectID, IssueIID: iid})
+	if err != nil {
+		return nil, err
+	}
+
+	discussions, err := client.ListIssueDiscussions(projectID, iid, &gitlab.PaginationOptions{PerPage: promptDiscussionsPerPage})
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(summarizeInstructions)
+	fmt.Fprintf(&b, "\n\n# Issue #%d: %s\n\n", issue.IID, issue.Title)
+	fmt.Fprintf(&b, "- State: %s\n- Author: %s\n", issue.State, issue.AuthorName)
+	if len(issue.Labels) > 0 {
+		fmt.Fprintf(&b, "- Labels: %s\n", strings.Join(issue.Labels, ", "))
+	}
+	fmt.Fprintf(&b, "- URL: %s\n", issue.WebURL)
+	if issue.Description != "" {
+		fmt.Fprintf(&b, "\n## Description\n\n%s\n", issue.Description)
+	}
+
+	b.WriteString("\n## Discussion\n\n")
+	count := 0
+	for _, d := range discussions {
+		for i, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			count++
+			prefix := "-"
+			if i > 0 {
+				prefix = "  - reply:"
+			}
+			date := ""
+			if n.CreatedAt != nil {
+				date = " (" + n.CreatedAt.Format("2006-01-02") + ")"
+			}
+			fmt.Fprintf(&b, "%s %s%s: %s\n", prefix, n.Author.Username, date, n.Body)
+		}
+	}
+	if count == 0 {
+		b.WriteString("No comments yet.\n")
+	}
+
+	return registry.UserPrompt(fmt.Sprintf("Summary of issue #%d", issue.IID), b.String()), nil
+}
@@ -0,0 +1,63 @@
+package issue
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeIssueThreadPrompt(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/issues/5":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":          50,
+				"iid":         5,
+				"title":       "Login fails on Safari",
+				"description": "Users cannot log in with Safari 17",
+				"state":       "opened",
+				"labels":      []string{"bug"},
+				"author":      map[string]any{"username": "alice"},
+			})
+		case "/api/v4/projects/test-project/issues/5/discussions":
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": "d1", "notes": []map[string]any{
+					{"id": 1, "body": "Cookies are blocked", "author": map[string]any{"username": "bob"}, "created_at": "2026-10-01T10:00:00Z"},
+					{"id": 2, "body": "Let's set SameSite=None", "author": map[string]any{"username": "alice"}, "created_at": "2026-10-02T10:00:00Z"},
+				}},
+				{"id": "d2", "notes": []map[string]any{
+					{"id": 3, "body": "added ~bug label", "system": true, "author": map[string]any{"username": "alice"}},
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	res, err := summarizeIssueThreadPrompt(client, context.Background(), registry.PromptArgs{
+		"project_id": "test-project",
+		"issue_iid":  "5",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+	content, ok := res.Messages[0].Content.(*mcp.TextContent)
+	require.True(t, ok)
+
+	assert.Contains(t, content.Text, "# Issue #5: Login fails on Safari")
+	assert.Contains(t, content.Text, "- Labels: bug")
+	assert.Contains(t, content.Text, "Users cannot log in with Safari 17")
+	assert.Contains(t, content.Text, "- bob (2026-10-01): Cookies are blocked\n")
+	assert.Contains(t, content.Text, "  - reply: alice (2026-10-02): Let's set SameSite=None\n")
+	assert.NotContains(t, content.Text, "added ~bug label")
+}
@@ -0,0 +1,53 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// IssueURITemplate は Issue のリソースの URI テンプレート
+// project はプロジェクト ID または URL エンコードしたパス（例: group%2Fproject）
+const IssueURITemplate = "gitlab://{project}/issues/{iid}"
+
+// registerResources は Issue のリソーステンプレートを登録する
+func registerResources(reg *registry.Registry) {
+	registry.RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "issue",
+		Title:       "GitLab Issue",
+		Description: "GitLab Issue の詳細（タイトル、説明、状態、ラベル）",
+		MIMEType:    "application/json",
+		URITemplate: IssueURITemplate,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params registry.ResourceParams) (*mcp.ReadResourceResult, error) {
+		iid, err := params.Int("iid")
+		if err != nil {
+			return nil, err
+		}
+		_, detail, err := getIssueHandler(holder.client, ctx, nil, GetIssueInput{
+			ProjectID: params["project"],
+			IssueIID:  iid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return registry.JSONResource(req.Params.URI, detail)
+	}, registry.WithSubscription(func(ctx context.Context, params registry.ResourceParams) (string, error) {
+		return issueVersion(holder.client, params)
+	}))
+}
+
+// issueVersion は購読中の Issue の変更を検出するためのバージョン（updated_at）を返す
+func issueVersion(client *gitlab.Client, params registry.ResourceParams) (string, error) {
+	iid, err := params.Int("iid")
+	if err != nil {
+		return "", err
+	}
+	issue, err := client.GetIssue(params["project"], iid)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(issue.UpdatedAt), nil
+}
@@ -2,6 +2,8 @@ package issue
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/kqns91/gitlab-mcp/internal/gitlab"
 	"github.com/kqns91/gitlab-mcp/internal/registry"
@@ -16,8 +18,8 @@ type ListIssuesInput struct {
 	AssigneeID *int     `json:"assignee_id,omitempty" jsonschema:"description:Assignee user ID filter"`
 	AuthorID   *int     `json:"author_id,omitempty" jsonschema:"description:Author user ID filter"`
 	Search     *string  `json:"search,omitempty" jsonschema:"description:Search query"`
-	Page       int      `json:"page,omitempty" jsonschema:"description:Page number (default: 1)"`
-	PerPage    int      `json:"per_page,omitempty" jsonschema:"description:Number of items per page (default: 100, max: 100)"`
+	Page       int      `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage    int      `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
 }
 
 // IssueSummary はIssue一覧の各項目
@@ -38,7 +40,7 @@ type ListIssuesOutput struct {
 // GetIssueInput は get_issue の入力パラメータ
 type GetIssueInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID  int    `json:"issue_iid" jsonschema:"description:Issue IID"`
+	IssueIID  int    `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
 }
 
 // IssueDetail はIssue詳細情報
@@ -65,6 +67,7 @@ type CreateIssueInput struct {
 	Labels      []string `json:"labels,omitempty" jsonschema:"description:Labels to add"`
 	AssigneeIDs []int    `json:"assignee_ids,omitempty" jsonschema:"description:Assignee user IDs"`
 	MilestoneID *int     `json:"milestone_id,omitempty" jsonschema:"description:Milestone ID"`
+	registry.DryRunOption
 }
 
 // CreateIssueOutput は create_issue の出力
@@ -77,13 +80,14 @@ type CreateIssueOutput struct {
 // UpdateIssueInput は update_issue の入力パラメータ
 type UpdateIssueInput struct {
 	ProjectID   string   `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID    int      `json:"issue_iid" jsonschema:"description:Issue IID"`
+	IssueIID    int      `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
 	Title       *string  `json:"title,omitempty" jsonschema:"description:New title"`
 	Description *string  `json:"description,omitempty" jsonschema:"description:New description"`
 	StateEvent  *string  `json:"state_event,omitempty" jsonschema:"enum:close,enum:reopen,description:State event"`
 	Labels      []string `json:"labels,omitempty" jsonschema:"description:New labels"`
 	AssigneeIDs []int    `json:"assignee_ids,omitempty" jsonschema:"description:New assignee user IDs"`
 	MilestoneID *int     `json:"milestone_id,omitempty" jsonschema:"description:New milestone ID"`
+	registry.DryRunOption
 }
 
 // UpdateIssueOutput は update_issue の出力
@@ -97,7 +101,9 @@ type UpdateIssueOutput struct {
 // DeleteIssueInput は delete_issue の入力パラメータ
 type DeleteIssueInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID  int    `json:"issue_iid" jsonschema:"description:Issue IID"`
+	IssueIID  int    `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
+	registry.Confirmation
+	registry.DryRunOption
 }
 
 // DeleteIssueOutput は delete_issue の出力
@@ -109,9 +115,9 @@ type DeleteIssueOutput struct {
 // ListIssueNotesInput は list_issue_notes の入力パラメータ
 type ListIssueNotesInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID  int    `json:"issue_iid" jsonschema:"description:Issue IID"`
-	Page      int    `json:"page,omitempty" jsonschema:"description:Page number (default: 1)"`
-	PerPage   int    `json:"per_page,omitempty" jsonschema:"description:Number of items per page (default: 100, max: 100)"`
+	IssueIID  int    `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
+	Page      int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage   int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
 }
 
 // NoteInfo はノート情報
@@ -131,8 +137,9 @@ type ListIssueNotesOutput struct {
 // CreateIssueNoteInput は create_issue_note の入力パラメータ
 type CreateIssueNoteInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID  int    `json:"issue_iid" jsonschema:"description:Issue IID"`
+	IssueIID  int    `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
 	Body      string `json:"body" jsonschema:"description:Comment body text"`
+	registry.DryRunOption
 }
 
 // CreateIssueNoteOutput は create_issue_note の出力
@@ -146,8 +153,10 @@ type CreateIssueNoteOutput struct {
 // DeleteIssueNoteInput は delete_issue_note の入力パラメータ
 type DeleteIssueNoteInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID  int    `json:"issue_iid" jsonschema:"description:Issue IID"`
-	NoteID    int    `json:"note_id" jsonschema:"description:Note ID to delete"`
+	IssueIID  int    `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
+	NoteID    int    `json:"note_id" jsonschema:"minimum:1,description:Note ID to delete"`
+	registry.Confirmation
+	registry.DryRunOption
 }
 
 // DeleteIssueNoteOutput は delete_issue_note の出力
@@ -159,9 +168,9 @@ type DeleteIssueNoteOutput struct {
 // ListIssueDiscussionsInput は list_issue_discussions の入力パラメータ
 type ListIssueDiscussionsInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID  int    `json:"issue_iid" jsonschema:"description:Issue IID"`
-	Page      int    `json:"page,omitempty" jsonschema:"description:Page number (default: 1)"`
-	PerPage   int    `json:"per_page,omitempty" jsonschema:"description:Number of items per page (default: 100, max: 100)"`
+	IssueIID  int    `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
+	Page      int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage   int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
 }
 
 // DiscussionNote はディスカッション内のノート情報
@@ -185,8 +194,9 @@ type ListIssueDiscussionsOutput struct {
 // CreateIssueDiscussionInput は create_issue_discussion の入力パラメータ
 type CreateIssueDiscussionInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID  int    `json:"issue_iid" jsonschema:"description:Issue IID"`
+	IssueIID  int    `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
 	Body      string `json:"body" jsonschema:"description:Discussion body text"`
+	registry.DryRunOption
 }
 
 // CreateIssueDiscussionOutput は create_issue_discussion の出力
@@ -197,9 +207,10 @@ type CreateIssueDiscussionOutput struct {
 // ReplyToIssueDiscussionInput は reply_to_issue_discussion の入力パラメータ
 type ReplyToIssueDiscussionInput struct {
 	ProjectID    string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	IssueIID     int    `json:"issue_iid" jsonschema:"description:Issue IID"`
+	IssueIID     int    `json:"issue_iid" jsonschema:"minimum:1,description:Issue IID"`
 	DiscussionID string `json:"discussion_id" jsonschema:"description:Discussion ID to reply to"`
 	Body         string `json:"body" jsonschema:"description:Reply body text"`
+	registry.DryRunOption
 }
 
 // ReplyToIssueDiscussionOutput は reply_to_issue_discussion の出力
@@ -225,67 +236,74 @@ func Register(reg *registry.Registry, client *gitlab.Client) {
 		"GitLab プロジェクトの Issue 一覧を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ListIssuesInput) (*mcp.CallToolResult, ListIssuesOutput, error) {
 			return listIssuesHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "get_issue",
 		"GitLab Issue の詳細情報を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input GetIssueInput) (*mcp.CallToolResult, GetIssueOutput, error) {
 			return getIssueHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "create_issue",
 		"GitLab に新しい Issue を作成します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input CreateIssueInput) (*mcp.CallToolResult, CreateIssueOutput, error) {
-			return createIssueHandler(holder.client, ctx, req, input)
+			return createIssueHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "update_issue",
 		"GitLab Issue を更新します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input UpdateIssueInput) (*mcp.CallToolResult, UpdateIssueOutput, error) {
-			return updateIssueHandler(holder.client, ctx, req, input)
+			return updateIssueHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "delete_issue",
 		"GitLab Issue を削除します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input DeleteIssueInput) (*mcp.CallToolResult, DeleteIssueOutput, error) {
-			return deleteIssueHandler(holder.client, ctx, req, input)
-		})
+			return deleteIssueHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeleteIssueInput) (string, error) {
+			return deleteIssueSummary(holder.client, input)
+		}))
 
 	registry.RegisterTool(reg, "list_issue_notes",
 		"GitLab Issue のコメント一覧を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ListIssueNotesInput) (*mcp.CallToolResult, ListIssueNotesOutput, error) {
 			return listIssueNotesHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "create_issue_note",
 		"GitLab Issue にコメントを追加します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input CreateIssueNoteInput) (*mcp.CallToolResult, CreateIssueNoteOutput, error) {
-			return createIssueNoteHandler(holder.client, ctx, req, input)
+			return createIssueNoteHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "delete_issue_note",
 		"GitLab Issue のコメントを削除します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input DeleteIssueNoteInput) (*mcp.CallToolResult, DeleteIssueNoteOutput, error) {
-			return deleteIssueNoteHandler(holder.client, ctx, req, input)
-		})
+			return deleteIssueNoteHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeleteIssueNoteInput) (string, error) {
+			return deleteIssueNoteSummary(holder.client, input)
+		}))
 
 	registry.RegisterTool(reg, "list_issue_discussions",
 		"GitLab Issue のディスカッション一覧を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ListIssueDiscussionsInput) (*mcp.CallToolResult, ListIssueDiscussionsOutput, error) {
 			return listIssueDiscussionsHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "create_issue_discussion",
 		"GitLab Issue にディスカッションを作成します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input CreateIssueDiscussionInput) (*mcp.CallToolResult, CreateIssueDiscussionOutput, error) {
-			return createIssueDiscussionHandler(holder.client, ctx, req, input)
+			return createIssueDiscussionHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "reply_to_issue_discussion",
 		"GitLab Issue のディスカッションに返信を追加します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ReplyToIssueDiscussionInput) (*mcp.CallToolResult, ReplyToIssueDiscussionOutput, error) {
-			return replyToIssueDiscussionHandler(holder.client, ctx, req, input)
+			return replyToIssueDiscussionHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
+
+	registerResources(reg)
+	registerPrompts(reg)
 }
 
 func listIssuesHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListIssuesInput) (*mcp.CallToolResult, ListIssuesOutput, error) {
@@ -400,6 +418,15 @@ func updateIssueHandler(client *gitlab.Client, ctx context.Context, req *mcp.Cal
 	}, nil
 }
 
+// deleteIssueSummary は確認のために削除する Issue を要約する
+func deleteIssueSummary(client *gitlab.Client, input DeleteIssueInput) (string, error) {
+	issue, err := client.GetIssue(input.ProjectID, input.IssueIID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("permanently delete issue #%d '%s'", issue.IID, issue.Title), nil
+}
+
 func deleteIssueHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeleteIssueInput) (*mcp.CallToolResult, DeleteIssueOutput, error) {
 	err := client.DeleteIssue(input.ProjectID, input.IssueIID)
 	if err != nil {
@@ -468,6 +495,24 @@ func createIssueNoteHandler(client *gitlab.Client, ctx context.Context, req *mcp
 	}, nil
 }
 
+// summaryBodyLength は確認で示すコメント本文の最大文字数
+const summaryBodyLength = 80
+
+// deleteIssueNoteSummary は確認のために削除するコメントを要約する
+// 本文は改行をまとめて summaryBodyLength 文字までに切り詰める
+func deleteIssueNoteSummary(client *gitlab.Client, input DeleteIssueNoteInput) (string, error) {
+	note, err := client.GetIssueNote(input.ProjectID, input.IssueIID, input.NoteID)
+	if err != nil {
+		return "", err
+	}
+
+	body := []rune(strings.Join(strings.Fields(note.Body), " "))
+	if len(body) > summaryBodyLength {
+		body = append(body[:summaryBodyLength], '…')
+	}
+	return fmt.Sprintf("delete comment %d by @%s on #%d: '%s'", note.ID, note.Author.Username, input.IssueIID, string(body)), nil
+}
+
 func deleteIssueNoteHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeleteIssueNoteInput) (*mcp.CallToolResult, DeleteIssueNoteOutput, error) {
 	err := client.DeleteIssueNote(input.ProjectID, input.IssueIID, input.NoteID)
 	if err != nil {
@@ -230,6 +230,24 @@ func TestDeleteIssueTool(t *testing.T) {
 	})
 }
 
+func TestDeleteIssueSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/issues/1", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 10, "iid": 1, "title": "Flaky login test"})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := deleteIssueSummary(client, DeleteIssueInput{ProjectID: "test-project", IssueIID: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "permanently delete issue #1 'Flaky login test'", summary)
+}
+
 func TestListIssueNotesTool(t *testing.T) {
 	t.Run("returns notes list successfully", func(t *testing.T) {
 		handler := func(w http.ResponseWriter, r *http.Request) {
@@ -419,3 +437,21 @@ func TestToolDisabled(t *testing.T) {
 	assert.True(t, reg.IsRegistered("list_issues"))
 	assert.False(t, reg.IsToolEnabled("list_issues"))
 }
+
+func TestDeleteIssueNoteSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/issues/1/notes/123", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 123, "body": "Duplicate of\n#2", "author": map[string]any{"username": "alice"}})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := deleteIssueNoteSummary(client, DeleteIssueNoteInput{ProjectID: "test-project", IssueIID: 1, NoteID: 123})
+
+	require.NoError(t, err)
+	assert.Equal(t, "delete comment 123 by @alice on #1: 'Duplicate of #2'", summary)
+}
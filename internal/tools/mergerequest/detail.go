@@ -0,0 +1,178 @@
+package mergerequest
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// get_merge_request の include で指定できるセクション
+const (
+	includeMergeStatus = "merge_status"
+	includePipeline    = "pipeline"
+	includePeople      = "people"
+	includeLabels      = "labels"
+	includeDiffRefs    = "diff_refs"
+	includeApprovals   = "approvals"
+)
+
+// detailSections は get_merge_request が扱うセクションの一覧
+var detailSections = []string{
+	includeMergeStatus, includePipeline, includePeople, includeLabels, includeDiffRefs, includeApprovals,
+}
+
+// defaultDetailSections は include 省略時に返すセクション
+// approvals は追加の API 呼び出しが必要なため明示的に指定された場合のみ返す
+var defaultDetailSections = []string{
+	includeMergeStatus, includePipeline, includePeople, includeLabels, includeDiffRefs,
+}
+
+// UserInfo はユーザーの情報
+type UserInfo struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name,omitempty"`
+}
+
+// PipelineSummary は MR のヘッドパイプラインの情報
+type PipelineSummary struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+	SHA    string `json:"sha,omitempty"`
+	WebURL string `json:"web_url,omitempty"`
+}
+
+// MilestoneInfo はマイルストーンの情報
+type MilestoneInfo struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	DueDate string `json:"due_date,omitempty"`
+}
+
+// DiffRefs は MR の差分の基準となるコミット SHA
+type DiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+// ApprovalSummary は MR の承認状況
+type ApprovalSummary struct {
+	Approved          bool     `json:"approved"`
+	ApprovalsRequired int64    `json:"approvals_required"`
+	ApprovalsLeft     int64    `json:"approvals_left"`
+	ApprovedBy        []string `json:"approved_by"`
+}
+
+// resolveDetailSections は include の指定を検証し、返すセクションの集合を返す
+func resolveDetailSections(include []string) (map[string]bool, error) {
+	if len(include) == 0 {
+		include = defaultDetailSections
+	}
+
+	sections := make(map[string]bool, len(include))
+	for _, s := range include {
+		if !slices.Contains(detailSections, s) {
+			return nil, &gitlab.MCPError{
+				Code:    gitlab.ErrCodeBadRequest,
+				Message: gitlab.Msg(gitlab.MsgUnknownSection, s, strings.Join(detailSections, ", ")),
+			}
+		}
+		sections[s] = true
+	}
+	return sections, nil
+}
+
+// toUserInfos は GitLab のユーザー一覧を変換する
+func toUserInfos(users []*gogitlab.BasicUser) []UserInfo {
+	infos := make([]UserInfo, 0, len(users))
+	for _, u := range users {
+		if u == nil {
+			continue
+		}
+		infos = append(infos, UserInfo{ID: u.ID, Username: u.Username, Name: u.Name})
+	}
+	return infos
+}
+
+// toMergeRequestDetail は MR を指定されたセクションを含む詳細情報に変換する
+func toMergeRequestDetail(mr *gogitlab.MergeRequest, sections map[string]bool) MergeRequestDetail {
+	detail := MergeRequestDetail{
+		IID:          mr.IID,
+		Title:        mr.Title,
+		Description:  mr.Description,
+		State:        mr.State,
+		Draft:        mr.Draft,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		SHA:          mr.SHA,
+		WebURL:       mr.WebURL,
+	}
+	if mr.Author != nil {
+		detail.AuthorName = mr.Author.Username
+	}
+
+	if sections[includeMergeStatus] {
+		detail.DetailedMergeStatus = mr.DetailedMergeStatus
+		detail.HasConflicts = &mr.HasConflicts
+		detail.BlockingDiscussionsResolved = &mr.BlockingDiscussionsResolved
+		detail.MergeError = mr.MergeError
+		detail.ChangesCount = mr.ChangesCount
+	}
+
+	if sections[includePipeline] && mr.HeadPipeline != nil {
+		detail.HeadPipeline = &PipelineSummary{
+			ID:     mr.HeadPipeline.ID,
+			Status: mr.HeadPipeline.Status,
+			SHA:    mr.HeadPipeline.SHA,
+			WebURL: mr.HeadPipeline.WebURL,
+		}
+	}
+
+	if sections[includePeople] {
+		detail.Assignees = toUserInfos(mr.Assignees)
+		detail.Reviewers = toUserInfos(mr.Reviewers)
+	}
+
+	if sections[includeLabels] {
+		detail.Labels = []string(mr.Labels)
+		if detail.Labels == nil {
+			detail.Labels = []string{}
+		}
+		if m := mr.Milestone; m != nil {
+			detail.Milestone = &MilestoneInfo{ID: m.ID, Title: m.Title, State: m.State}
+			if m.DueDate != nil {
+				detail.Milestone.DueDate = m.DueDate.String()
+			}
+		}
+	}
+
+	if sections[includeDiffRefs] {
+		detail.DiffRefs = &DiffRefs{
+			BaseSHA:  mr.DiffRefs.BaseSha,
+			HeadSHA:  mr.DiffRefs.HeadSha,
+			StartSHA: mr.DiffRefs.StartSha,
+		}
+	}
+
+	return detail
+}
+
+// toApprovalSummary は MR の承認状況を変換する
+func toApprovalSummary(approvals *gogitlab.MergeRequestApprovals) *ApprovalSummary {
+	approvedBy := make([]string, 0, len(approvals.ApprovedBy))
+	for _, a := range approvals.ApprovedBy {
+		if a != nil && a.User != nil {
+			approvedBy = append(approvedBy, a.User.Username)
+		}
+	}
+	return &ApprovalSummary{
+		Approved:          approvals.Approved,
+		ApprovalsRequired: approvals.ApprovalsRequired,
+		ApprovalsLeft:     approvals.ApprovalsLeft,
+		ApprovedBy:        approvedBy,
+	}
+}
@@ -0,0 +1,225 @@
+package mergerequest
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// defaultMaxTotalBytes は get_merge_request_changes が返す差分の合計サイズのデフォルト上限
+const defaultMaxTotalBytes = 100 * 1024
+
+// gitAttributesPath は linguist-generated を読み取る .gitattributes のパス
+const gitAttributesPath = ".gitattributes"
+
+// defaultGeneratedPatterns は生成・ベンダー・ロックファイルとみなすデフォルトのパス
+// GITLAB_MCP_GENERATED_FILE_PATTERNS で置き換えられる
+var defaultGeneratedPatterns = []string{
+	"**/vendor/**",
+	"**/node_modules/**",
+	"**/third_party/**",
+	"go.sum",
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"Cargo.lock",
+	"Gemfile.lock",
+	"composer.lock",
+	"poetry.lock",
+	"Pipfile.lock",
+	"*.min.js",
+	"*.min.css",
+	"*.pb.go",
+	"*_generated.go",
+	"*.gen.go",
+}
+
+// 差分がマニフェストのみに含まれる理由
+const (
+	skipReasonGenerated = "generated"
+	skipReasonBudget    = "budget"
+)
+
+// compileGlob は .gitattributes と同じ規則で glob を正規表現に変換する
+// "/" を含まないパターンは任意の階層のファイル名に、含むパターンはリポジトリルートからのパスにマッチする
+// "**" は0個以上のディレクトリ、"*" と "?" は "/" 以外の文字にマッチする
+func compileGlob(pattern string) *regexp.Regexp {
+	pattern = strings.TrimSpace(pattern)
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// compileGlobs は glob のリストをまとめて変換する
+func compileGlobs(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if strings.TrimSpace(p) != "" {
+			compiled = append(compiled, compileGlob(p))
+		}
+	}
+	return compiled
+}
+
+// matchAny はパスがいずれかのパターンにマッチするかを返す
+func matchAny(patterns []*regexp.Regexp, path string) bool {
+	for _, p := range patterns {
+		if p.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeRule は .gitattributes の1行で指定された生成ファイル属性
+type attributeRule struct {
+	pattern   *regexp.Regexp
+	generated bool
+}
+
+// parseGitAttributes は .gitattributes から linguist-generated / linguist-vendored の指定を読み取る
+func parseGitAttributes(content string) []attributeRule {
+	var rules []attributeRule
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "!") {
+			continue
+		}
+
+		var generated, set bool
+		for _, attr := range fields[1:] {
+			switch attr {
+			case "linguist-generated", "linguist-generated=true", "linguist-vendored", "linguist-vendored=true":
+				generated, set = true, true
+			case "-linguist-generated", "linguist-generated=false", "-linguist-vendored", "linguist-vendored=false":
+				generated, set = false, true
+			}
+		}
+		if set {
+			rules = append(rules, attributeRule{pattern: compileGlob(fields[0]), generated: generated})
+		}
+	}
+	return rules
+}
+
+// generatedDetector は差分ファイルが生成・ベンダー・ロックファイルかを判定する
+type generatedDetector struct {
+	patterns   []*regexp.Regexp
+	attributes []attributeRule
+}
+
+// isGenerated は .gitattributes の指定（後の行が優先）、GitLab の判定、パターンの順に判定する
+func (d *generatedDetector) isGenerated(diff *gogitlab.MergeRequestDiff) bool {
+	path := diffPath(diff)
+	for i := len(d.attributes) - 1; i >= 0; i-- {
+		if d.attributes[i].pattern.MatchString(path) {
+			return d.attributes[i].generated
+		}
+	}
+	if diff.GeneratedFile {
+		return true
+	}
+	return matchAny(d.patterns, path)
+}
+
+// diffPath は差分ファイルの代表パスを返す（削除されたファイルは変更前のパス）
+func diffPath(diff *gogitlab.MergeRequestDiff) string {
+	if diff.DeletedFile {
+		return diff.OldPath
+	}
+	return diff.NewPath
+}
+
+// splitHunks は差分を "@@" ヘッダごとのハンクに分割する
+// 最初のヘッダより前の行は最初のハンクに含める
+func splitHunks(diff string) []string {
+	if diff == "" {
+		return nil
+	}
+	var hunks []string
+	start := 0
+	for i := 0; i < len(diff); {
+		end := strings.IndexByte(diff[i:], '\n')
+		next := len(diff)
+		if end >= 0 {
+			next = i + end + 1
+		}
+		if i > start && strings.HasPrefix(diff[i:], "@@") {
+			hunks = append(hunks, diff[start:i])
+			start = i
+		}
+		i = next
+	}
+	return append(hunks, diff[start:])
+}
+
+// countChanges は差分の追加行数と削除行数を数える
+// GitLab の差分には +++/--- のヘッダーが含まれないため、@@ で始まるハンク内の行だけを数える
+func countChanges(diff string) (additions, deletions int) {
+	inHunk := false
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// truncateHunks は limit バイト以内に収まるハンクを連結して返す
+// 最初のハンクも収まらない場合は行単位で切り詰める。limit が0以下の場合は切り詰めない
+func truncateHunks(hunks []string, limit int) (diff string, truncated bool) {
+	var b strings.Builder
+	for i, h := range hunks {
+		if limit > 0 && b.Len()+len(h) > limit {
+			if i == 0 {
+				// マルチバイト文字の途中で切らないよう文字の先頭まで戻す
+				end := limit
+				for end > 0 && !utf8.RuneStart(h[end]) {
+					end--
+				}
+				cut := h[:end]
+				if nl := strings.LastIndexByte(cut, '\n'); nl >= 0 {
+					cut = cut[:nl+1]
+				}
+				b.WriteString(cut)
+			}
+			return b.String(), true
+		}
+		b.WriteString(h)
+	}
+	return b.String(), false
+}
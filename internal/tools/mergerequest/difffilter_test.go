@@ -0,0 +1,84 @@
+package mergerequest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/server/main.go", true},
+		{"*.go", "main.go.orig", false},
+		{"cmd/*.go", "cmd/main.go", true},
+		{"cmd/*.go", "cmd/server/main.go", false},
+		{"cmd/*.go", "tools/cmd/main.go", false},
+		{"/go.sum", "go.sum", true},
+		{"/go.sum", "sub/go.sum", false},
+		{"**/vendor/**", "vendor/a/b.go", true},
+		{"**/vendor/**", "web/vendor/x.js", true},
+		{"**/vendor/**", "vendored.go", false},
+		{"docs/", "docs/a/b.md", true},
+		{"src/**/*.ts", "src/a.ts", true},
+		{"src/**/*.ts", "src/a/b/c.ts", true},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file/.txt", false},
+		{"a+b.txt", "a+b.txt", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, compileGlob(tt.pattern).MatchString(tt.path))
+		})
+	}
+}
+
+func TestParseGitAttributes(t *testing.T) {
+	rules := parseGitAttributes("# comment\n*.pb.go linguist-generated=true\nthird_party/** linguist-vendored\n*.pb.go -linguist-generated\n*.txt text eol=lf\n")
+
+	assert.Len(t, rules, 3)
+	assert.True(t, rules[0].generated)
+	assert.True(t, rules[1].generated)
+	assert.False(t, rules[2].generated)
+}
+
+func TestSplitHunks(t *testing.T) {
+	hunks := splitHunks("@@ -1 +1 @@\n-a\n+b\n@@ -10 +10 @@\n c\n+d")
+
+	assert.Equal(t, []string{"@@ -1 +1 @@\n-a\n+b\n", "@@ -10 +10 @@\n c\n+d"}, hunks)
+	assert.Nil(t, splitHunks(""))
+}
+
+func TestTruncateHunks(t *testing.T) {
+	hunks := []string{"@@ -1 +1 @@\n+a\n", "@@ -5 +5 @@\n+b\n"}
+
+	diff, truncated := truncateHunks(hunks, 0)
+	assert.Equal(t, "@@ -1 +1 @@\n+a\n@@ -5 +5 @@\n+b\n", diff)
+	assert.False(t, truncated)
+
+	diff, truncated = truncateHunks(hunks, 20)
+	assert.Equal(t, "@@ -1 +1 @@\n+a\n", diff)
+	assert.True(t, truncated)
+
+	diff, truncated = truncateHunks(hunks, 13)
+	assert.Equal(t, "@@ -1 +1 @@\n", diff)
+	assert.True(t, truncated)
+
+	// 改行がない場合もマルチバイト文字の途中では切らない
+	diff, truncated = truncateHunks([]string{"+日本語"}, 5)
+	assert.Equal(t, "+日", diff)
+	assert.True(t, truncated)
+}
+
+func TestCountChanges(t *testing.T) {
+	// ハンク内の +++/--- で始まる行も追加・削除行として数える
+	additions, deletions := countChanges("@@ -1,2 +1,2 @@\n--- old\n+++ new\n context\n\\ No newline at end of file")
+
+	assert.Equal(t, 1, additions)
+	assert.Equal(t, 1, deletions)
+}
@@ -0,0 +1,245 @@
+package mergerequest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+const (
+	// defaultRebaseTimeout は rebase_merge_request のデフォルトのタイムアウト
+	defaultRebaseTimeout = time.Minute
+	// maxRebaseTimeout は rebase_merge_request のタイムアウトの上限
+	maxRebaseTimeout = 10 * time.Minute
+)
+
+// rebasePollInterval はリベース完了の確認間隔（テストで短縮できるよう変数にしている）
+var rebasePollInterval = 2 * time.Second
+
+// draftPrefixPattern は GitLab がドラフトとみなすタイトルの接頭辞
+var draftPrefixPattern = regexp.MustCompile(`(?i)^\s*(\[draft\]|\(draft\)|draft:|draft\s+-|\[wip\]|wip:)\s*`)
+
+// RebaseMergeRequestInput は rebase_merge_request の入力パラメータ
+type RebaseMergeRequestInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	SkipCI          bool   `json:"skip_ci,omitempty" jsonschema:"description:Do not create a pipeline for the rebased commits"`
+	TimeoutSeconds  int    `json:"timeout_seconds,omitempty" jsonschema:"minimum:1,maximum:600,description:Maximum time to wait for the rebase in seconds (default: 60, max: 600)"`
+	registry.DryRunOption
+}
+
+// RebaseMergeRequestOutput は rebase_merge_request の出力
+type RebaseMergeRequestOutput struct {
+	IID              int64  `json:"iid"`
+	RebaseInProgress bool   `json:"rebase_in_progress"`
+	TimedOut         bool   `json:"timed_out"`
+	MergeError       string `json:"merge_error,omitempty"`
+	SHA              string `json:"sha"`
+	WebURL           string `json:"web_url"`
+}
+
+// SetMergeRequestDraftInput は set_merge_request_draft の入力パラメータ
+type SetMergeRequestDraftInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Draft           bool   `json:"draft" jsonschema:"description:true to mark as draft, false to mark as ready"`
+	registry.DryRunOption
+}
+
+// MergeRequestStateInput は close_merge_request などの状態変更ツールの入力パラメータ
+type MergeRequestStateInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	registry.DryRunOption
+}
+
+// CloseMergeRequestInput は close_merge_request の入力パラメータ
+type CloseMergeRequestInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// MergeRequestStateOutput は MR の状態変更ツールの出力
+type MergeRequestStateOutput struct {
+	IID    int64  `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Draft  bool   `json:"draft"`
+	WebURL string `json:"web_url"`
+}
+
+// SubscriptionOutput は subscribe_to_merge_request / unsubscribe_from_merge_request の出力
+type SubscriptionOutput struct {
+	IID        int64 `json:"iid"`
+	Subscribed bool  `json:"subscribed"`
+}
+
+// registerLifecycleTools は MR の状態を変更するツールを登録する
+func registerLifecycleTools(reg *registry.Registry) {
+	registry.RegisterTool(reg, "rebase_merge_request",
+		"GitLab Merge Request のソースブランチをターゲットブランチにリベースし、完了まで待機します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input RebaseMergeRequestInput) (*mcp.CallToolResult, RebaseMergeRequestOutput, error) {
+			return rebaseMergeRequestHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "set_merge_request_draft",
+		"GitLab Merge Request をドラフトにする、またはドラフトを解除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input SetMergeRequestDraftInput) (*mcp.CallToolResult, MergeRequestStateOutput, error) {
+			return setMergeRequestDraftHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "close_merge_request",
+		"GitLab Merge Request をクローズします",
+		func(ctx context.Context, req *mcp.CallToolRequest, input CloseMergeRequestInput) (*mcp.CallToolResult, MergeRequestStateOutput, error) {
+			stateInput := MergeRequestStateInput{ProjectID: input.ProjectID, MergeRequestIID: input.MergeRequestIID, DryRunOption: input.DryRunOption}
+			return changeMergeRequestStateHandler(holder.client.WithDryRun(ctx), ctx, req, stateInput, "close")
+		}, registry.WithConfirmation(func(ctx context.Context, input CloseMergeRequestInput) (string, error) {
+			return closeMergeRequestSummary(holder.client, input)
+		}))
+
+	registry.RegisterTool(reg, "reopen_merge_request",
+		"クローズされた GitLab Merge Request を再オープンします",
+		func(ctx context.Context, req *mcp.CallToolRequest, input MergeRequestStateInput) (*mcp.CallToolResult, MergeRequestStateOutput, error) {
+			return changeMergeRequestStateHandler(holder.client.WithDryRun(ctx), ctx, req, input, "reopen")
+		})
+
+	registry.RegisterTool(reg, "subscribe_to_merge_request",
+		"GitLab Merge Request の通知を購読します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input MergeRequestStateInput) (*mcp.CallToolResult, SubscriptionOutput, error) {
+			return subscribeToMergeRequestHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "unsubscribe_from_merge_request",
+		"GitLab Merge Request の通知の購読を解除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input MergeRequestStateInput) (*mcp.CallToolResult, SubscriptionOutput, error) {
+			return unsubscribeFromMergeRequestHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+}
+
+// draftTitle は draft に応じてタイトルの Draft 接頭辞を付け外しする
+func draftTitle(title string, draft bool) string {
+	stripped := draftPrefixPattern.ReplaceAllString(title, "")
+	if draft {
+		return "Draft: " + stripped
+	}
+	return stripped
+}
+
+func toMergeRequestStateOutput(mr *gogitlab.MergeRequest) MergeRequestStateOutput {
+	return MergeRequestStateOutput{
+		IID:    mr.IID,
+		Title:  mr.Title,
+		State:  mr.State,
+		Draft:  mr.Draft,
+		WebURL: mr.WebURL,
+	}
+}
+
+func rebaseMergeRequestHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input RebaseMergeRequestInput) (*mcp.CallToolResult, RebaseMergeRequestOutput, error) {
+	timeout := defaultRebaseTimeout
+	if input.TimeoutSeconds > 0 {
+		timeout = min(time.Duration(input.TimeoutSeconds)*time.Second, maxRebaseTimeout)
+	}
+	deadline := time.Now().Add(timeout)
+
+	if err := client.RebaseMergeRequest(input.ProjectID, input.MergeRequestIID, input.SkipCI); err != nil {
+		return nil, RebaseMergeRequestOutput{}, err
+	}
+	// ドライランではリベースを開始していないため、GitLab の状態を待たない
+	if client.IsDryRun() {
+		return nil, RebaseMergeRequestOutput{}, nil
+	}
+
+	for {
+		mr, err := client.GetMergeRequestRebaseStatus(input.ProjectID, input.MergeRequestIID)
+		if err != nil {
+			return nil, RebaseMergeRequestOutput{}, err
+		}
+
+		timedOut := mr.RebaseInProgress && !time.Now().Before(deadline)
+		if !mr.RebaseInProgress || timedOut {
+			return nil, RebaseMergeRequestOutput{
+				IID:              mr.IID,
+				RebaseInProgress: mr.RebaseInProgress,
+				TimedOut:         timedOut,
+				MergeError:       mr.MergeError,
+				SHA:              mr.SHA,
+				WebURL:           mr.WebURL,
+			}, nil
+		}
+
+		timer := time.NewTimer(min(rebasePollInterval, time.Until(deadline)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, RebaseMergeRequestOutput{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func setMergeRequestDraftHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input SetMergeRequestDraftInput) (*mcp.CallToolResult, MergeRequestStateOutput, error) {
+	mr, err := client.GetMergeRequest(input.ProjectID, input.MergeRequestIID)
+	if err != nil {
+		return nil, MergeRequestStateOutput{}, err
+	}
+	if mr.Draft == input.Draft {
+		return nil, toMergeRequestStateOutput(mr), nil
+	}
+
+	title := draftTitle(mr.Title, input.Draft)
+	updated, err := client.UpdateMergeRequest(input.ProjectID, input.MergeRequestIID, &gitlab.UpdateMergeRequestOptions{
+		Title: &title,
+	})
+	if err != nil {
+		return nil, MergeRequestStateOutput{}, err
+	}
+
+	return nil, toMergeRequestStateOutput(updated), nil
+}
+
+// closeMergeRequestSummary は確認のためにクローズする MR を要約する
+func closeMergeRequestSummary(client *gitlab.Client, input CloseMergeRequestInput) (string, error) {
+	mr, err := client.GetMergeRequest(input.ProjectID, input.MergeRequestIID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("close !%d '%s' (%s into %s)", mr.IID, mr.Title, mr.SourceBranch, mr.TargetBranch), nil
+}
+
+func changeMergeRequestStateHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input MergeRequestStateInput, stateEvent string) (*mcp.CallToolResult, MergeRequestStateOutput, error) {
+	mr, err := client.UpdateMergeRequest(input.ProjectID, input.MergeRequestIID, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: &stateEvent,
+	})
+	if err != nil {
+		return nil, MergeRequestStateOutput{}, err
+	}
+
+	return nil, toMergeRequestStateOutput(mr), nil
+}
+
+func subscribeToMergeRequestHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input MergeRequestStateInput) (*mcp.CallToolResult, SubscriptionOutput, error) {
+	mr, err := client.SubscribeToMergeRequest(input.ProjectID, input.MergeRequestIID)
+	if err != nil {
+		return nil, SubscriptionOutput{}, err
+	}
+
+	return nil, SubscriptionOutput{IID: mr.IID, Subscribed: mr.Subscribed}, nil
+}
+
+func unsubscribeFromMergeRequestHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input MergeRequestStateInput) (*mcp.CallToolResult, SubscriptionOutput, error) {
+	mr, err := client.UnsubscribeFromMergeRequest(input.ProjectID, input.MergeRequestIID)
+	if err != nil {
+		return nil, SubscriptionOutput{}, err
+	}
+
+	return nil, SubscriptionOutput{IID: mr.IID, Subscribed: mr.Subscribed}, nil
+}
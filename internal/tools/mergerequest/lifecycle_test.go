@@ -0,0 +1,243 @@
+package mergerequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useFastRebasePolling はテスト中のリベース確認間隔を短縮する
+func useFastRebasePolling(t *testing.T) {
+	orig := rebasePollInterval
+	rebasePollInterval = time.Millisecond
+	t.Cleanup(func() { rebasePollInterval = orig })
+}
+
+func TestRebaseMergeRequestTool(t *testing.T) {
+	useFastRebasePolling(t)
+
+	// rebaseHandler は polls 回目の取得でリベースが完了するハンドラを作成する
+	rebaseHandler := func(t *testing.T, polls int32, mergeError string) http.HandlerFunc {
+		var count atomic.Int32
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/v4/projects/test-project/merge_requests/1/rebase":
+				assert.Equal(t, "PUT", r.Method)
+				w.WriteHeader(http.StatusAccepted)
+				json.NewEncoder(w).Encode(map[string]any{"rebase_in_progress": true})
+			case "/api/v4/projects/test-project/merge_requests/1":
+				n := count.Add(1)
+				json.NewEncoder(w).Encode(map[string]any{
+					"iid":                1,
+					"sha":                "rebased-sha",
+					"rebase_in_progress": n < polls,
+					"merge_error":        mergeError,
+				})
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		}
+	}
+
+	t.Run("waits until the rebase finishes", func(t *testing.T) {
+		client, reg, cleanup := setupTestServer(t, rebaseHandler(t, 3, ""))
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("rebase_merge_request"))
+
+		input := RebaseMergeRequestInput{ProjectID: "test-project", MergeRequestIID: 1, SkipCI: true}
+
+		_, output, err := rebaseMergeRequestHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.False(t, output.RebaseInProgress)
+		assert.False(t, output.TimedOut)
+		assert.Equal(t, "rebased-sha", output.SHA)
+	})
+
+	t.Run("reports rebase failure", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, rebaseHandler(t, 1, "Rebase failed due to conflicts"))
+		defer cleanup()
+
+		input := RebaseMergeRequestInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+		_, output, err := rebaseMergeRequestHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Rebase failed due to conflicts", output.MergeError)
+	})
+
+	t.Run("returns current state on timeout", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, rebaseHandler(t, 1<<30, ""))
+		defer cleanup()
+
+		input := RebaseMergeRequestInput{ProjectID: "test-project", MergeRequestIID: 1, TimeoutSeconds: 1}
+
+		_, output, err := rebaseMergeRequestHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.TimedOut)
+		assert.True(t, output.RebaseInProgress)
+	})
+}
+
+func TestDraftTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		draft bool
+		want  string
+	}{
+		{"Add feature", true, "Draft: Add feature"},
+		{"Draft: Add feature", true, "Draft: Add feature"},
+		{"Draft: Add feature", false, "Add feature"},
+		{"[Draft] Add feature", false, "Add feature"},
+		{"(draft) Add feature", false, "Add feature"},
+		{"WIP: Add feature", false, "Add feature"},
+		{"Drafting guide", false, "Drafting guide"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, draftTitle(tt.title, tt.draft), tt.title)
+	}
+}
+
+func TestSetMergeRequestDraftTool(t *testing.T) {
+	t.Run("marks MR as ready", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == "PUT" {
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				assert.Equal(t, "Add feature", body["title"])
+				json.NewEncoder(w).Encode(map[string]any{"iid": 1, "title": "Add feature", "draft": false, "state": "opened"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"iid": 1, "title": "Draft: Add feature", "draft": true, "state": "opened"})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("set_merge_request_draft"))
+
+		input := SetMergeRequestDraftInput{ProjectID: "test-project", MergeRequestIID: 1, Draft: false}
+
+		_, output, err := setMergeRequestDraftHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.False(t, output.Draft)
+		assert.Equal(t, "Add feature", output.Title)
+	})
+
+	t.Run("does nothing when already in the requested state", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"iid": 1, "title": "Draft: Add feature", "draft": true})
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := SetMergeRequestDraftInput{ProjectID: "test-project", MergeRequestIID: 1, Draft: true}
+
+		_, output, err := setMergeRequestDraftHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.Draft)
+	})
+}
+
+func TestChangeMergeRequestStateTools(t *testing.T) {
+	for _, tt := range []struct {
+		tool       string
+		stateEvent string
+		state      string
+	}{
+		{"close_merge_request", "close", "closed"},
+		{"reopen_merge_request", "reopen", "opened"},
+	} {
+		t.Run(tt.tool, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "PUT", r.Method)
+				assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1", r.URL.Path)
+
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				assert.Equal(t, tt.stateEvent, body["state_event"])
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]any{"iid": 1, "state": tt.state})
+			}
+
+			client, reg, cleanup := setupTestServer(t, handler)
+			defer cleanup()
+
+			assert.True(t, reg.IsRegistered(tt.tool))
+
+			input := MergeRequestStateInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+			_, output, err := changeMergeRequestStateHandler(client, context.Background(), nil, input, tt.stateEvent)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.state, output.State)
+		})
+	}
+}
+
+func TestSubscriptionTools(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/merge_requests/1/subscribe":
+			json.NewEncoder(w).Encode(map[string]any{"iid": 1, "subscribed": true})
+		case "/api/v4/projects/test-project/merge_requests/1/unsubscribe":
+			json.NewEncoder(w).Encode(map[string]any{"iid": 1, "subscribed": false})
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}
+
+	client, reg, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	assert.True(t, reg.IsRegistered("subscribe_to_merge_request"))
+	assert.True(t, reg.IsRegistered("unsubscribe_from_merge_request"))
+
+	input := MergeRequestStateInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+	_, output, err := subscribeToMergeRequestHandler(client, context.Background(), nil, input)
+	require.NoError(t, err)
+	assert.True(t, output.Subscribed)
+
+	_, output, err = unsubscribeFromMergeRequestHandler(client, context.Background(), nil, input)
+	require.NoError(t, err)
+	assert.False(t, output.Subscribed)
+}
+
+func TestCloseMergeRequestSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/merge_requests/42", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"iid": 42, "title": "Add billing", "source_branch": "feature", "target_branch": "main"})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := closeMergeRequestSummary(client, CloseMergeRequestInput{ProjectID: "test-project", MergeRequestIID: 42})
+
+	require.NoError(t, err)
+	assert.Equal(t, "close !42 'Add billing' (feature into main)", summary)
+}
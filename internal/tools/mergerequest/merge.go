@@ -0,0 +1,99 @@
+package mergerequest
+
+import (
+	"slices"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// マージを妨げる要因のコード
+const (
+	blockerNotOpen               = "not_open"
+	blockerDraft                 = "draft"
+	blockerConflicts             = "conflicts"
+	blockerSHAMismatch           = "sha_mismatch"
+	blockerPipeline              = "pipeline"
+	blockerDiscussionsUnresolved = "discussions_unresolved"
+	blockerApprovalsMissing      = "approvals_missing"
+	blockerMergeStatus           = "merge_status"
+)
+
+// MergeBlocker はマージを妨げている要因
+type MergeBlocker struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// mergeStatusBlockers は detailed_merge_status のうち個別のチェックで扱っているものと対応するコード
+var mergeStatusBlockers = map[string]string{
+	"not_open":                 blockerNotOpen,
+	"draft_status":             blockerDraft,
+	"conflict":                 blockerConflicts,
+	"ci_must_pass":             blockerPipeline,
+	"ci_still_running":         blockerPipeline,
+	"discussions_not_resolved": blockerDiscussionsUnresolved,
+	"not_approved":             blockerApprovalsMissing,
+}
+
+// mergeableStatus はマージを妨げる要因がないことを示す detailed_merge_status
+const mergeableStatus = "mergeable"
+
+// checkingMergeStatuses は GitLab がマージ可否を計算中であることを示すステータス
+var checkingMergeStatuses = []string{"checking", "unchecked", "preparing", "approvals_syncing"}
+
+// mergeBlockers はマージ前のチェックを行い、マージを妨げる要因を返す
+// autoMerge が true の場合、実行中のパイプラインはパイプライン成功後にマージされるため要因としない
+func mergeBlockers(mr *gogitlab.MergeRequest, approvals *gogitlab.MergeRequestApprovals, sha string, autoMerge bool) []MergeBlocker {
+	var blockers []MergeBlocker
+	add := func(code string, key gitlab.MessageKey, args ...any) {
+		blockers = append(blockers, MergeBlocker{Code: code, Message: gitlab.Msg(key, args...)})
+	}
+
+	if mr.State != "opened" {
+		add(blockerNotOpen, gitlab.MsgBlockerNotOpen, mr.State)
+	}
+	if mr.Draft {
+		add(blockerDraft, gitlab.MsgBlockerDraft)
+	}
+	if mr.HasConflicts {
+		add(blockerConflicts, gitlab.MsgBlockerConflicts)
+	}
+	if sha != "" && sha != mr.SHA {
+		add(blockerSHAMismatch, gitlab.MsgBlockerSHAMismatch, mr.SHA, sha)
+	}
+	if p := mr.HeadPipeline; p != nil {
+		switch p.Status {
+		case "success", "skipped":
+		case "running", "pending", "created", "waiting_for_resource", "preparing", "scheduled":
+			if !autoMerge {
+				add(blockerPipeline, gitlab.MsgBlockerPipelineRunning, p.ID, p.Status)
+			}
+		case "manual":
+			// 手動ジョブの開始を待っているだけで失敗ではない。自動マージでも開始されるまでマージされない
+			add(blockerPipeline, gitlab.MsgBlockerPipelineManual, p.ID)
+		default:
+			add(blockerPipeline, gitlab.MsgBlockerPipelineFailed, p.ID, p.Status)
+		}
+	}
+	if !mr.BlockingDiscussionsResolved {
+		add(blockerDiscussionsUnresolved, gitlab.MsgBlockerDiscussionsUnresolved)
+	}
+	if approvals != nil && approvals.ApprovalsLeft > 0 {
+		add(blockerApprovalsMissing, gitlab.MsgBlockerApprovalsMissing, approvals.ApprovalsLeft)
+	}
+
+	status := mr.DetailedMergeStatus
+	if status != "" && status != mergeableStatus && !slices.Contains(checkingMergeStatuses, status) {
+		code, known := mergeStatusBlockers[status]
+		covered := slices.ContainsFunc(blockers, func(b MergeBlocker) bool { return b.Code == code })
+		switch {
+		case !known:
+			add(blockerMergeStatus, gitlab.MsgBlockerMergeStatus, status)
+		case !covered && !(status == "ci_still_running" && autoMerge):
+			add(code, gitlab.MsgBlockerMergeStatus, status)
+		}
+	}
+
+	return blockers
+}
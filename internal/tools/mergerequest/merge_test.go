@@ -0,0 +1,168 @@
+package mergerequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestMergeBlockers(t *testing.T) {
+	mergeable := func() *gogitlab.MergeRequest {
+		mr := &gogitlab.MergeRequest{HeadPipeline: &gogitlab.Pipeline{ID: 7, Status: "success"}}
+		mr.State = "opened"
+		mr.SHA = "head"
+		mr.BlockingDiscussionsResolved = true
+		mr.DetailedMergeStatus = "mergeable"
+		return mr
+	}
+	codes := func(blockers []MergeBlocker) []string {
+		var c []string
+		for _, b := range blockers {
+			c = append(c, b.Code)
+		}
+		return c
+	}
+
+	t.Run("no blockers for a mergeable MR", func(t *testing.T) {
+		assert.Empty(t, mergeBlockers(mergeable(), &gogitlab.MergeRequestApprovals{}, "head", false))
+	})
+
+	t.Run("reports every blocker once", func(t *testing.T) {
+		mr := mergeable()
+		mr.Draft = true
+		mr.HasConflicts = true
+		mr.BlockingDiscussionsResolved = false
+		mr.HeadPipeline.Status = "failed"
+		mr.DetailedMergeStatus = "draft_status"
+
+		blockers := mergeBlockers(mr, &gogitlab.MergeRequestApprovals{ApprovalsLeft: 1}, "stale", false)
+
+		assert.Equal(t, []string{
+			blockerDraft, blockerConflicts, blockerSHAMismatch, blockerPipeline,
+			blockerDiscussionsUnresolved, blockerApprovalsMissing,
+		}, codes(blockers))
+	})
+
+	t.Run("manual pipeline is waiting rather than failed", func(t *testing.T) {
+		mr := mergeable()
+		mr.HeadPipeline.Status = "manual"
+
+		blockers := mergeBlockers(mr, nil, "", true)
+
+		require.Len(t, blockers, 1)
+		assert.Equal(t, blockerPipeline, blockers[0].Code)
+		assert.Equal(t, gitlab.Msg(gitlab.MsgBlockerPipelineManual, 7), blockers[0].Message)
+	})
+
+	t.Run("ignores statuses GitLab is still computing", func(t *testing.T) {
+		mr := mergeable()
+		mr.DetailedMergeStatus = "checking"
+
+		assert.Empty(t, mergeBlockers(mr, nil, "", false))
+	})
+
+	t.Run("running pipeline is allowed with auto merge", func(t *testing.T) {
+		mr := mergeable()
+		mr.HeadPipeline.Status = "running"
+		mr.DetailedMergeStatus = "ci_still_running"
+
+		assert.Empty(t, mergeBlockers(mr, nil, "", true))
+		assert.Equal(t, []string{blockerPipeline}, codes(mergeBlockers(mr, nil, "", false)))
+	})
+
+	t.Run("reports detailed merge status not covered by other checks", func(t *testing.T) {
+		mr := mergeable()
+		mr.DetailedMergeStatus = "need_rebase"
+
+		blockers := mergeBlockers(mr, nil, "", false)
+
+		require.Len(t, blockers, 1)
+		assert.Equal(t, blockerMergeStatus, blockers[0].Code)
+		assert.Contains(t, blockers[0].Message, "need_rebase")
+	})
+
+	t.Run("messages follow the configured language", func(t *testing.T) {
+		mr := mergeable()
+		mr.Draft = true
+
+		assert.Equal(t, "The merge request is a draft", mergeBlockers(mr, nil, "", false)[0].Message)
+
+		gitlab.SetLanguage(gitlab.LangJapanese)
+		defer gitlab.SetLanguage(gitlab.LangEnglish)
+		assert.Equal(t, "MR がドラフトです", mergeBlockers(mr, nil, "", false)[0].Message)
+	})
+
+	t.Run("reports required pipeline when none exists", func(t *testing.T) {
+		mr := mergeable()
+		mr.HeadPipeline = nil
+		mr.DetailedMergeStatus = "ci_must_pass"
+
+		assert.Equal(t, []string{blockerPipeline}, codes(mergeBlockers(mr, nil, "", false)))
+	})
+}
+
+func TestMergeMergeRequestTool_Preflight(t *testing.T) {
+	// preflightHandler は指定されたパイプラインステータスの MR を返し、マージ時のリクエストボディを記録する
+	preflightHandler := func(t *testing.T, pipelineStatus string, mergeBody *map[string]any) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/v4/projects/test-project/merge_requests/1":
+				json.NewEncoder(w).Encode(map[string]any{
+					"iid":                           1,
+					"state":                         "opened",
+					"sha":                           "head-sha",
+					"detailed_merge_status":         "mergeable",
+					"blocking_discussions_resolved": true,
+					"head_pipeline":                 map[string]any{"id": 7, "status": pipelineStatus},
+				})
+			case "/api/v4/projects/test-project/merge_requests/1/approvals":
+				json.NewEncoder(w).Encode(map[string]any{"approvals_left": 0})
+			case "/api/v4/projects/test-project/merge_requests/1/merge":
+				json.NewDecoder(r.Body).Decode(mergeBody)
+				json.NewEncoder(w).Encode(map[string]any{"iid": 1, "state": "merged", "merge_commit_sha": "merged-sha"})
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		}
+	}
+
+	t.Run("merges with the checked HEAD pinned", func(t *testing.T) {
+		var body map[string]any
+		client, _, cleanup := setupTestServer(t, preflightHandler(t, "success", &body))
+		defer cleanup()
+
+		input := MergeMergeRequestInput{ProjectID: "test-project", MergeRequestIID: 1, Preflight: true}
+
+		_, output, err := mergeMergeRequestHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.Merged)
+		assert.Equal(t, "merged-sha", output.MergeCommitSHA)
+		assert.Empty(t, output.Blockers)
+		assert.Equal(t, "head-sha", body["sha"])
+	})
+
+	t.Run("refuses to merge when blocked", func(t *testing.T) {
+		var body map[string]any
+		client, _, cleanup := setupTestServer(t, preflightHandler(t, "failed", &body))
+		defer cleanup()
+
+		input := MergeMergeRequestInput{ProjectID: "test-project", MergeRequestIID: 1, Preflight: true}
+
+		_, output, err := mergeMergeRequestHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.False(t, output.Merged)
+		assert.Equal(t, "opened", output.State)
+		require.Len(t, output.Blockers, 1)
+		assert.Equal(t, blockerPipeline, output.Blockers[0].Code)
+		assert.Nil(t, body)
+	})
+}
@@ -0,0 +1,234 @@
+package mergerequest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// promptDiscussionsPerPage はプロンプトに含めるディスカッションの最大数
+const promptDiscussionsPerPage = 100
+
+// reviewInstructions は review_merge_request のモデルへの指示
+const reviewInstructions = `You are reviewing the GitLab merge request below. Review the diff for:
+- Correctness: bugs, edge cases, error handling, concurrency issues
+- Security: injection, secrets, authorization, unsafe input handling
+- Design: readability, naming, duplication, consistency with the surrounding code
+- Tests: missing or insufficient coverage for the changed behavior
+
+Do not repeat points already raised in the existing discussions. For each finding, cite the file and new line number, explain the problem and suggest a concrete fix. Classify findings as blocking or non-blocking and finish with an overall verdict (approve, or request changes).
+
+To post the review, use create_draft_note for each finding on its line and publish_review to submit them together.`
+
+// descriptionInstructions は write_mr_description のモデルへの指示
+const descriptionInstructions = `Write a GitLab merge request title and description for the changes below.
+
+- Title: imperative mood, at most 72 characters
+- Description (Markdown) with the sections: "## What", "## Why", "## How to test"
+- Mention breaking changes, migrations or configuration changes explicitly
+- Base the description only on the commits and diff; do not invent motivation that is not evident
+
+After the user confirms the text, create the merge request with create_merge_request (or update an existing one with update_merge_request).`
+
+// registerPrompts は MR のプロンプトを登録する
+func registerPrompts(reg *registry.Registry) {
+	registry.RegisterPrompt(reg, &mcp.Prompt{
+		Name:        "review_merge_request",
+		Title:       "Review Merge Request",
+		Description: "Merge Request の差分と既存のディスカッションをもとにコードレビューを行う",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "project_id", Description: "Project ID or URL-encoded path", Required: true},
+			{Name: "merge_request_iid", Description: "Merge Request IID", Required: true},
+			{Name: "focus", Description: "Optional area to focus the review on (e.g. security, performance)"},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+		return reviewMergeRequestPrompt(holder, ctx, args)
+	})
+
+	registry.RegisterPrompt(reg, &mcp.Prompt{
+		Name:        "write_mr_description",
+		Title:       "Write Merge Request Description",
+		Description: "ブランチのコミットと差分から Merge Request のタイトルと説明を作成する",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "project_id", Description: "Project ID or URL-encoded path", Required: true},
+			{Name: "source_branch", Description: "Branch containing the changes", Required: true},
+			{Name: "target_branch", Description: "Branch the changes will be merged into", Required: true},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+		return writeMRDescriptionPrompt(holder, ctx, args)
+	})
+}
+
+func reviewMergeRequestPrompt(h *clientHolder, ctx context.Context, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+	iid, err := args.Int("merge_request_iid")
+	if err != nil {
+		return nil, err
+	}
+	projectID := args["project_id"]
+
+	_, mr, err := getMergeRequestHandler(h.client, ctx, nil, GetMergeRequestInput{
+		ProjectID:       projectID,
+		MergeRequestIID: iid,
+		Include:         []string{includeLabels},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, changes, err := getMergeRequestChangesHandler(h, ctx, nil, GetMergeRequestChangesInput{
+		ProjectID:       projectID,
+		MergeRequestIID: iid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	discussions, err := h.client.ListMergeRequestDiscussions(projectID, iid, &gitlab.PaginationOptions{PerPage: promptDiscussionsPerPage})
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(reviewInstructions)
+	if focus := args["focus"]; focus != "" {
+		fmt.Fprintf(&b, "\n\nFocus the review on: %s", focus)
+	}
+
+	fmt.Fprintf(&b, "\n\n# Merge request !%d: %s\n\n", mr.IID, mr.Title)
+	fmt.Fprintf(&b, "- Branches: %s → %s\n", mr.SourceBranch, mr.TargetBranch)
+	fmt.Fprintf(&b, "- Author: %s\n", mr.AuthorName)
+	if len(mr.Labels) > 0 {
+		fmt.Fprintf(&b, "- Labels: %s\n", strings.Join(mr.Labels, ", "))
+	}
+	fmt.Fprintf(&b, "- URL: %s\n", mr.WebURL)
+	if mr.Description != "" {
+		fmt.Fprintf(&b, "\n## Description\n\n%s\n", mr.Description)
+	}
+
+	writeChanges(&b, changes)
+	writeMergeRequestDiscussions(&b, discussions)
+
+	return registry.UserPrompt(fmt.Sprintf("Review of merge request !%d", mr.IID), b.String()), nil
+}
+
+func writeMRDescriptionPrompt(h *clientHolder, ctx context.Context, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+	source, target := args["source_branch"], args["target_branch"]
+
+	compare, err := h.client.CompareRefs(args["project_id"], target, source)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(descriptionInstructions)
+	fmt.Fprintf(&b, "\n\n# Changes from %s into %s\n", source, target)
+
+	fmt.Fprintf(&b, "\n## Commits (%d)\n\n", len(compare.Commits))
+	for _, c := range compare.Commits {
+		fmt.Fprintf(&b, "### %s %s\n", c.ShortID, c.Title)
+		if body := strings.TrimSpace(strings.TrimPrefix(c.Message, c.Title)); body != "" {
+			fmt.Fprintf(&b, "\n%s\n", body)
+		}
+		b.WriteString("\n")
+	}
+
+	writeCompareDiffs(&b, compare.Diffs, defaultMaxTotalBytes)
+
+	return registry.UserPrompt(fmt.Sprintf("Merge request description for %s → %s", source, target), b.String()), nil
+}
+
+// writeChanges は MR の差分をプロンプトに書き込む
+// 生成ファイルや予算を超えたファイルはパスのみを列挙する
+func writeChanges(b *strings.Builder, changes GetMergeRequestChangesOutput) {
+	b.WriteString("\n## Diff\n")
+	for _, c := range changes.Changes {
+		path := c.NewPath
+		if c.DeletedFile {
+			path = c.OldPath
+		}
+		writeDiffBlock(b, path, c.Diff, c.Truncated)
+	}
+
+	var skipped []string
+	for _, m := range changes.Manifest {
+		if m.Skipped != "" {
+			skipped = append(skipped, fmt.Sprintf("- %s (%s, +%d -%d)", m.Path, m.Skipped, m.Additions, m.Deletions))
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(b, "\nFiles omitted from the diff (use get_merge_request_changes to inspect them):\n%s\n", strings.Join(skipped, "\n"))
+	}
+}
+
+// writeCompareDiffs は比較結果の差分を limit バイトまでプロンプトに書き込む
+func writeCompareDiffs(b *strings.Builder, diffs []*gogitlab.Diff, limit int) {
+	b.WriteString("\n## Diff\n")
+	var omitted []string
+	for _, d := range diffs {
+		path := d.NewPath
+		if d.DeletedFile {
+			path = d.OldPath
+		}
+		if limit <= 0 {
+			omitted = append(omitted, "- "+path)
+			continue
+		}
+		text, truncated := truncateHunks(splitHunks(d.Diff), limit)
+		limit -= len(text)
+		writeDiffBlock(b, path, text, truncated)
+	}
+	if len(omitted) > 0 {
+		fmt.Fprintf(b, "\nFiles omitted because the diff is too large:\n%s\n", strings.Join(omitted, "\n"))
+	}
+}
+
+// writeDiffBlock は 1 ファイルの差分をコードブロックとして書き込む
+func writeDiffBlock(b *strings.Builder, path, diff string, truncated bool) {
+	if diff != "" && !strings.HasSuffix(diff, "\n") {
+		diff += "\n"
+	}
+	fmt.Fprintf(b, "\n### %s\n\n```diff\n%s```\n", path, diff)
+	if truncated {
+		b.WriteString("(diff truncated)\n")
+	}
+}
+
+// writeMergeRequestDiscussions は MR のディスカッションをプロンプトに書き込む
+// システムノートは除外し、差分へのコメントには位置を付ける
+func writeMergeRequestDiscussions(b *strings.Builder, discussions []*gogitlab.Discussion) {
+	b.WriteString("\n## Existing discussions\n\n")
+
+	count := 0
+	for _, d := range discussions {
+		for i, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			count++
+			prefix := "-"
+			if i > 0 {
+				prefix = "  - reply:"
+			}
+			status := ""
+			if n.Resolvable {
+				status = " [unresolved]"
+				if n.Resolved {
+					status = " [resolved]"
+				}
+			}
+			location := ""
+			if n.Position != nil && n.Position.NewPath != "" {
+				location = fmt.Sprintf(" on %s:%d", n.Position.NewPath, n.Position.NewLine)
+			}
+			fmt.Fprintf(b, "%s %s%s%s: %s\n", prefix, n.Author.Username, location, status, n.Body)
+		}
+	}
+	if count == 0 {
+		b.WriteString("None.\n")
+	}
+}
@@ -0,0 +1,114 @@
+package mergerequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func promptText(t *testing.T, res *mcp.GetPromptResult) string {
+	t.Helper()
+	require.Len(t, res.Messages, 1)
+	text, ok := res.Messages[0].Content.(*mcp.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
+
+func TestReviewMergeRequestPrompt(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/merge_requests/42":
+			json.NewEncoder(w).Encode(map[string]any{
+				"iid":           42,
+				"title":         "Add billing",
+				"description":   "Adds the billing page",
+				"source_branch": "billing",
+				"target_branch": "main",
+				"sha":           "abc123",
+				"labels":        []string{"feature"},
+				"author":        map[string]any{"username": "alice"},
+			})
+		case "/api/v4/projects/test-project/merge_requests/42/diffs":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"old_path": "billing.go", "new_path": "billing.go", "diff": "@@ -1 +1 @@\n-old\n+new"},
+				{"old_path": "go.sum", "new_path": "go.sum", "diff": "@@ -1 +1 @@\n-a\n+b\n"},
+			})
+		case "/api/v4/projects/test-project/merge_requests/42/discussions":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": "d1", "notes": []map[string]any{
+					{"id": 1, "body": "Please add tests", "author": map[string]any{"username": "bob"}, "resolvable": true,
+						"position": map[string]any{"new_path": "billing.go", "new_line": 1}},
+					{"id": 2, "body": "Will do", "author": map[string]any{"username": "alice"}},
+				}},
+				{"id": "d2", "notes": []map[string]any{
+					{"id": 3, "body": "added 1 commit", "system": true, "author": map[string]any{"username": "alice"}},
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "404 Not found"})
+		}
+	}
+
+	_, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	res, err := reviewMergeRequestPrompt(holder, context.Background(), registry.PromptArgs{
+		"project_id":        "test-project",
+		"merge_request_iid": "42",
+		"focus":             "security",
+	})
+	require.NoError(t, err)
+
+	text := promptText(t, res)
+	assert.Contains(t, text, "Focus the review on: security")
+	assert.Contains(t, text, "# Merge request !42: Add billing")
+	assert.Contains(t, text, "- Branches: billing → main")
+	assert.Contains(t, text, "- Labels: feature")
+	assert.Contains(t, text, "Adds the billing page")
+	assert.Contains(t, text, "### billing.go\n\n```diff\n@@ -1 +1 @@\n-old\n+new\n```")
+	assert.Contains(t, text, "- go.sum (generated, +1 -1)")
+	assert.Contains(t, text, "- bob on billing.go:1 [unresolved]: Please add tests")
+	assert.Contains(t, text, "  - reply: alice: Will do")
+	assert.NotContains(t, text, "added 1 commit")
+}
+
+func TestWriteMRDescriptionPrompt(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/repository/compare", r.URL.Path)
+		assert.Equal(t, "main", r.URL.Query().Get("from"))
+		assert.Equal(t, "billing", r.URL.Query().Get("to"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"commits": []map[string]any{
+				{"short_id": "abc1234", "title": "Add billing page", "message": "Add billing page\n\nCustomers can now see invoices."},
+			},
+			"diffs": []map[string]any{
+				{"old_path": "billing.go", "new_path": "billing.go", "diff": "@@ -0,0 +1 @@\n+package billing\n", "new_file": true},
+			},
+		})
+	}
+
+	_, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	res, err := writeMRDescriptionPrompt(holder, context.Background(), registry.PromptArgs{
+		"project_id":    "test-project",
+		"source_branch": "billing",
+		"target_branch": "main",
+	})
+	require.NoError(t, err)
+
+	text := promptText(t, res)
+	assert.Contains(t, text, "# Changes from billing into main")
+	assert.Contains(t, text, "### abc1234 Add billing page\n\nCustomers can now see invoices.")
+	assert.Contains(t, text, "### billing.go\n\n```diff\n@@ -0,0 +1 @@\n+package billing\n```")
+}
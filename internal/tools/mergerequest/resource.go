@@ -0,0 +1,60 @@
+package mergerequest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MergeRequestURITemplate は MR のリソースの URI テンプレート
+// project はプロジェクト ID または URL エンコードしたパス（例: group%2Fproject）
+const MergeRequestURITemplate = "gitlab://{project}/merge_requests/{iid}"
+
+// registerResources は MR のリソーステンプレートを登録する
+func registerResources(reg *registry.Registry) {
+	registry.RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "merge_request",
+		Title:       "GitLab Merge Request",
+		Description: "GitLab Merge Request の詳細（説明、マージ可否、パイプライン、レビュアー、ラベル、承認状況）",
+		MIMEType:    "application/json",
+		URITemplate: MergeRequestURITemplate,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params registry.ResourceParams) (*mcp.ReadResourceResult, error) {
+		iid, err := params.Int("iid")
+		if err != nil {
+			return nil, err
+		}
+		_, detail, err := getMergeRequestHandler(holder.client, ctx, nil, GetMergeRequestInput{
+			ProjectID:       params["project"],
+			MergeRequestIID: iid,
+			Include:         detailSections,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return registry.JSONResource(req.Params.URI, detail)
+	}, registry.WithSubscription(func(ctx context.Context, params registry.ResourceParams) (string, error) {
+		return mergeRequestVersion(holder.client, params)
+	}))
+}
+
+// mergeRequestVersion は購読中の MR の変更を検出するためのバージョンを返す
+// コメントの追加などで更新される updated_at に加え、updated_at が変わらない head pipeline の状態も含める
+func mergeRequestVersion(client *gitlab.Client, params registry.ResourceParams) (string, error) {
+	iid, err := params.Int("iid")
+	if err != nil {
+		return "", err
+	}
+	mr, err := client.GetMergeRequest(params["project"], iid)
+	if err != nil {
+		return "", err
+	}
+
+	version := fmt.Sprint(mr.UpdatedAt)
+	if mr.HeadPipeline != nil {
+		version += fmt.Sprintf("|pipeline:%d:%s", mr.HeadPipeline.ID, mr.HeadPipeline.Status)
+	}
+	return version, nil
+}
@@ -0,0 +1,50 @@
+package mergerequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeRequestVersion(t *testing.T) {
+	t.Run("changes with head pipeline status", func(t *testing.T) {
+		status := "running"
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/group/project/merge_requests/1", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"iid":           1,
+				"updated_at":    "2026-10-01T10:00:00Z",
+				"head_pipeline": map[string]any{"id": 100, "status": status},
+			})
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		params := registry.ResourceParams{"project": "group/project", "iid": "1"}
+		running, err := mergeRequestVersion(client, params)
+		require.NoError(t, err)
+
+		status = "success"
+		success, err := mergeRequestVersion(client, params)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, running, success)
+	})
+
+	t.Run("invalid iid", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("unexpected request")
+		})
+		defer cleanup()
+
+		_, err := mergeRequestVersion(client, registry.ResourceParams{"project": "1", "iid": "abc"})
+		assert.ErrorIs(t, err, gitlab.ErrBadRequest)
+	})
+}
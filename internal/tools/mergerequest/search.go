@@ -0,0 +1,115 @@
+package mergerequest
+
+import (
+	"context"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ListGlobalMergeRequestsInput は list_global_merge_requests の入力パラメータ
+type ListGlobalMergeRequestsInput struct {
+	GroupID          *string  `json:"group_id,omitempty" jsonschema:"description:Group ID or URL-encoded path (lists MRs across all accessible projects if omitted)"`
+	Scope            *string  `json:"scope,omitempty" jsonschema:"enum:created_by_me,enum:assigned_to_me,enum:reviews_for_me,enum:all,description:Scope filter (default: created_by_me globally, all for groups)"`
+	State            *string  `json:"state,omitempty" jsonschema:"enum:opened,enum:closed,enum:locked,enum:merged,enum:all,description:MR state filter"`
+	AuthorUsername   *string  `json:"author_username,omitempty" jsonschema:"description:Author username filter"`
+	ReviewerUsername *string  `json:"reviewer_username,omitempty" jsonschema:"description:Reviewer username filter"`
+	Labels           []string `json:"labels,omitempty" jsonschema:"description:Only MRs with all of these labels"`
+	Draft            *bool    `json:"draft,omitempty" jsonschema:"description:Filter by draft status"`
+	UpdatedAfter     *string  `json:"updated_after,omitempty" jsonschema:"description:Only MRs updated after this time (RFC 3339 or YYYY-MM-DD)"`
+	OrderBy          *string  `json:"order_by,omitempty" jsonschema:"enum:created_at,enum:updated_at,enum:merged_at,enum:title,description:Order by field (default: created_at)"`
+	Sort             *string  `json:"sort,omitempty" jsonschema:"enum:asc,enum:desc,description:Sort order (default: desc)"`
+	Page             int      `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage          int      `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
+}
+
+// registerSearchTools はプロジェクトをまたいだ MR 一覧ツールを登録する
+func registerSearchTools(reg *registry.Registry) {
+	registry.RegisterTool(reg, "list_global_merge_requests",
+		"プロジェクトをまたいで GitLab Merge Request の一覧を取得します（全プロジェクトまたはグループ単位、レビュー待ちの確認などに利用）",
+		func(ctx context.Context, req *mcp.CallToolRequest, input ListGlobalMergeRequestsInput) (*mcp.CallToolResult, ListMergeRequestsOutput, error) {
+			return listGlobalMergeRequestsHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+}
+
+// parseTimeFilter は RFC 3339 または YYYY-MM-DD 形式の日時をパースする
+func parseTimeFilter(name, value string) (*time.Time, error) {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, &gitlab.MCPError{
+		Code:    gitlab.ErrCodeBadRequest,
+		Message: gitlab.Msg(gitlab.MsgInvalidTimeFilter, name, value),
+	}
+}
+
+// toMergeRequestSummary は MR を一覧の項目に変換する
+func toMergeRequestSummary(mr *gogitlab.BasicMergeRequest) MergeRequestSummary {
+	summary := MergeRequestSummary{
+		IID:          mr.IID,
+		Title:        mr.Title,
+		State:        mr.State,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		WebURL:       mr.WebURL,
+		Draft:        mr.Draft,
+		ProjectID:    mr.ProjectID,
+	}
+	if mr.Author != nil {
+		summary.AuthorName = mr.Author.Username
+	}
+	if mr.References != nil {
+		summary.Reference = mr.References.Full
+	}
+	if mr.UpdatedAt != nil {
+		summary.UpdatedAt = mr.UpdatedAt.Format(time.RFC3339)
+	}
+	return summary
+}
+
+func listGlobalMergeRequestsHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListGlobalMergeRequestsInput) (*mcp.CallToolResult, ListMergeRequestsOutput, error) {
+	opts := &gitlab.SearchMergeRequestsOptions{
+		State:            input.State,
+		Scope:            input.Scope,
+		AuthorUsername:   input.AuthorUsername,
+		ReviewerUsername: input.ReviewerUsername,
+		Labels:           input.Labels,
+		Draft:            input.Draft,
+		OrderBy:          input.OrderBy,
+		Sort:             input.Sort,
+		Pagination: &gitlab.PaginationOptions{
+			Page:    input.Page,
+			PerPage: input.PerPage,
+		},
+	}
+	if input.UpdatedAfter != nil {
+		updatedAfter, err := parseTimeFilter("updated_after", *input.UpdatedAfter)
+		if err != nil {
+			return nil, ListMergeRequestsOutput{}, err
+		}
+		opts.UpdatedAfter = updatedAfter
+	}
+
+	var mrs []*gogitlab.BasicMergeRequest
+	var err error
+	if input.GroupID != nil {
+		mrs, err = client.ListGroupMergeRequests(*input.GroupID, opts)
+	} else {
+		mrs, err = client.ListGlobalMergeRequests(opts)
+	}
+	if err != nil {
+		return nil, ListMergeRequestsOutput{}, err
+	}
+
+	summaries := make([]MergeRequestSummary, len(mrs))
+	for i, mr := range mrs {
+		summaries[i] = toMergeRequestSummary(mr)
+	}
+
+	return nil, ListMergeRequestsOutput{MergeRequests: summaries}, nil
+}
@@ -0,0 +1,110 @@
+package mergerequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGlobalMergeRequestsTool(t *testing.T) {
+	mrList := []map[string]any{
+		{
+			"iid":        12,
+			"project_id": 5,
+			"title":      "Fix login",
+			"state":      "opened",
+			"draft":      false,
+			"updated_at": "2026-10-17T09:30:00Z",
+			"author":     map[string]any{"username": "bob"},
+			"references": map[string]any{"full": "team/app!12"},
+		},
+	}
+
+	t.Run("lists review queue across all projects", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/merge_requests", r.URL.Path)
+			q := r.URL.Query()
+			assert.Equal(t, "all", q.Get("scope"))
+			assert.Equal(t, "alice", q.Get("reviewer_username"))
+			assert.Equal(t, "opened", q.Get("state"))
+			assert.Equal(t, "2026-10-01T00:00:00Z", q.Get("updated_after"))
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mrList)
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("list_global_merge_requests"))
+
+		scope := "all"
+		reviewer := "alice"
+		state := "opened"
+		updatedAfter := "2026-10-01"
+		input := ListGlobalMergeRequestsInput{
+			Scope:            &scope,
+			ReviewerUsername: &reviewer,
+			State:            &state,
+			UpdatedAfter:     &updatedAfter,
+		}
+
+		_, output, err := listGlobalMergeRequestsHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		require.Len(t, output.MergeRequests, 1)
+		assert.Equal(t, MergeRequestSummary{
+			IID:        12,
+			Title:      "Fix login",
+			State:      "opened",
+			AuthorName: "bob",
+			ProjectID:  5,
+			Reference:  "team/app!12",
+			UpdatedAt:  "2026-10-17T09:30:00Z",
+		}, output.MergeRequests[0])
+	})
+
+	t.Run("lists merge requests in a group", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/groups/team/merge_requests", r.URL.Path)
+			assert.Equal(t, "bug", r.URL.Query().Get("labels"))
+			assert.Equal(t, "true", r.URL.Query().Get("draft"))
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mrList)
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		group := "team"
+		draft := true
+		input := ListGlobalMergeRequestsInput{GroupID: &group, Labels: []string{"bug"}, Draft: &draft}
+
+		_, output, err := listGlobalMergeRequestsHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.Len(t, output.MergeRequests, 1)
+	})
+
+	t.Run("rejects invalid updated_after", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		})
+		defer cleanup()
+
+		updatedAfter := "yesterday"
+		input := ListGlobalMergeRequestsInput{UpdatedAfter: &updatedAfter}
+
+		_, _, err := listGlobalMergeRequestsHandler(client, context.Background(), nil, input)
+
+		var mcpErr *gitlab.MCPError
+		require.ErrorAs(t, err, &mcpErr)
+		assert.Equal(t, gitlab.ErrCodeBadRequest, mcpErr.Code)
+	})
+}
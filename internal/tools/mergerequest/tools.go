@@ -2,6 +2,9 @@ package mergerequest
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/kqns91/gitlab-mcp/internal/gitlab"
 	"github.com/kqns91/gitlab-mcp/internal/registry"
@@ -14,8 +17,8 @@ type ListMergeRequestsInput struct {
 	State      *string `json:"state,omitempty" jsonschema:"enum:opened,enum:closed,enum:merged,enum:all,description:MR state filter"`
 	AuthorID   *int    `json:"author_id,omitempty" jsonschema:"description:Author user ID filter"`
 	AssigneeID *int    `json:"assignee_id,omitempty" jsonschema:"description:Assignee user ID filter"`
-	Page       int     `json:"page,omitempty" jsonschema:"description:Page number (default: 1)"`
-	PerPage    int     `json:"per_page,omitempty" jsonschema:"description:Number of items per page (default: 100, max: 100)"`
+	Page       int     `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage    int     `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
 }
 
 // MergeRequestSummary はMR一覧の各項目
@@ -27,6 +30,10 @@ type MergeRequestSummary struct {
 	TargetBranch string `json:"target_branch"`
 	WebURL       string `json:"web_url"`
 	AuthorName   string `json:"author_name,omitempty"`
+	Draft        bool   `json:"draft"`
+	ProjectID    int64  `json:"project_id,omitempty"`
+	Reference    string `json:"reference,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
 }
 
 // ListMergeRequestsOutput は list_merge_requests の出力
@@ -36,20 +43,48 @@ type ListMergeRequestsOutput struct {
 
 // GetMergeRequestInput は get_merge_request の入力パラメータ
 type GetMergeRequestInput struct {
-	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	ProjectID       string   `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int      `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Include         []string `json:"include,omitempty" jsonschema:"enum:merge_status,enum:pipeline,enum:people,enum:labels,enum:diff_refs,enum:approvals,description:Sections to return: merge_status, pipeline, people, labels, diff_refs, approvals (default: all except approvals)"`
 }
 
 // MergeRequestDetail はMR詳細情報
+// 基本項目以外は include で指定されたセクションのみ設定される
 type MergeRequestDetail struct {
 	IID          int64  `json:"iid"`
 	Title        string `json:"title"`
 	Description  string `json:"description"`
 	State        string `json:"state"`
+	Draft        bool   `json:"draft"`
 	SourceBranch string `json:"source_branch"`
 	TargetBranch string `json:"target_branch"`
+	SHA          string `json:"sha,omitempty"`
 	WebURL       string `json:"web_url"`
 	AuthorName   string `json:"author_name,omitempty"`
+
+	// merge_status
+	DetailedMergeStatus         string `json:"detailed_merge_status,omitempty"`
+	HasConflicts                *bool  `json:"has_conflicts,omitempty"`
+	BlockingDiscussionsResolved *bool  `json:"blocking_discussions_resolved,omitempty"`
+	MergeError                  string `json:"merge_error,omitempty"`
+	ChangesCount                string `json:"changes_count,omitempty"`
+
+	// pipeline
+	HeadPipeline *PipelineSummary `json:"head_pipeline,omitempty"`
+
+	// people
+	Assignees []UserInfo `json:"assignees,omitempty"`
+	Reviewers []UserInfo `json:"reviewers,omitempty"`
+
+	// labels
+	Labels    []string       `json:"labels,omitempty"`
+	Milestone *MilestoneInfo `json:"milestone,omitempty"`
+
+	// diff_refs
+	DiffRefs *DiffRefs `json:"diff_refs,omitempty"`
+
+	// approvals
+	Approvals *ApprovalSummary `json:"approvals,omitempty"`
 }
 
 // GetMergeRequestOutput は get_merge_request の出力
@@ -65,6 +100,7 @@ type CreateMergeRequestInput struct {
 	AssigneeIDs  []int    `json:"assignee_ids,omitempty" jsonschema:"description:Assignee user IDs"`
 	ReviewerIDs  []int    `json:"reviewer_ids,omitempty" jsonschema:"description:Reviewer user IDs"`
 	Labels       []string `json:"labels,omitempty" jsonschema:"description:Labels to add"`
+	registry.DryRunOption
 }
 
 // CreateMergeRequestOutput は create_merge_request の出力
@@ -77,13 +113,14 @@ type CreateMergeRequestOutput struct {
 // UpdateMergeRequestInput は update_merge_request の入力パラメータ
 type UpdateMergeRequestInput struct {
 	ProjectID       string   `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int      `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int      `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
 	Title           *string  `json:"title,omitempty" jsonschema:"description:New title"`
 	Description     *string  `json:"description,omitempty" jsonschema:"description:New description"`
 	AssigneeIDs     []int    `json:"assignee_ids,omitempty" jsonschema:"description:New assignee user IDs"`
 	ReviewerIDs     []int    `json:"reviewer_ids,omitempty" jsonschema:"description:New reviewer user IDs"`
 	Labels          []string `json:"labels,omitempty" jsonschema:"description:New labels"`
 	TargetBranch    *string  `json:"target_branch,omitempty" jsonschema:"description:New target branch"`
+	registry.DryRunOption
 }
 
 // UpdateMergeRequestOutput は update_merge_request の出力
@@ -95,25 +132,43 @@ type UpdateMergeRequestOutput struct {
 
 // MergeMergeRequestInput は merge_merge_request の入力パラメータ
 type MergeMergeRequestInput struct {
-	ProjectID                string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID          int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
-	Squash                   *bool  `json:"squash,omitempty" jsonschema:"description:Squash commits when merging"`
-	ShouldRemoveSourceBranch *bool  `json:"should_remove_source_branch,omitempty" jsonschema:"description:Remove source branch after merge"`
+	ProjectID                string  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID          int     `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Squash                   *bool   `json:"squash,omitempty" jsonschema:"description:Squash commits when merging"`
+	ShouldRemoveSourceBranch *bool   `json:"should_remove_source_branch,omitempty" jsonschema:"description:Remove source branch after merge"`
+	SHA                      *string `json:"sha,omitempty" jsonschema:"description:Merge only if the source branch HEAD equals this SHA"`
+	AutoMerge                *bool   `json:"auto_merge,omitempty" jsonschema:"description:Merge automatically when the pipeline succeeds (merge_when_pipeline_succeeds)"`
+	MergeCommitMessage       *string `json:"merge_commit_message,omitempty" jsonschema:"description:Custom merge commit message"`
+	SquashCommitMessage      *string `json:"squash_commit_message,omitempty" jsonschema:"description:Custom squash commit message"`
+	Preflight                bool    `json:"preflight,omitempty" jsonschema:"description:Check pipeline, discussions, approvals, conflicts and draft status first and refuse to merge if anything blocks it; the checked HEAD is pinned as sha"`
+	registry.Confirmation
+	registry.DryRunOption
 }
 
 // MergeMergeRequestOutput は merge_merge_request の出力
 type MergeMergeRequestOutput struct {
-	IID    int64  `json:"iid"`
-	State  string `json:"state"`
-	WebURL string `json:"web_url"`
+	IID              int64          `json:"iid"`
+	State            string         `json:"state"`
+	WebURL           string         `json:"web_url"`
+	Merged           bool           `json:"merged"`
+	AutoMergeEnabled bool           `json:"auto_merge_enabled"`
+	MergeCommitSHA   string         `json:"merge_commit_sha,omitempty"`
+	Blockers         []MergeBlocker `json:"blockers,omitempty"`
 }
 
 // GetMergeRequestChangesInput は get_merge_request_changes の入力パラメータ
 type GetMergeRequestChangesInput struct {
-	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
-	Page            int    `json:"page,omitempty" jsonschema:"description:Page number (default: 1)"`
-	PerPage         int    `json:"per_page,omitempty" jsonschema:"description:Number of items per page (default: 100, max: 100)"`
+	ProjectID       string   `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int      `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Page            int      `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage         int      `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
+	Include         []string `json:"include,omitempty" jsonschema:"description:Only return files matching these globs (.gitattributes syntax, e.g. src/**/*.go)"`
+	Exclude         []string `json:"exclude,omitempty" jsonschema:"description:Drop files matching these globs"`
+	SkipGenerated   *bool    `json:"skip_generated,omitempty" jsonschema:"description:Omit diffs of generated, vendored and lock files, honoring .gitattributes linguist-generated (default: true)"`
+	MaxBytesPerFile int      `json:"max_bytes_per_file,omitempty" jsonschema:"minimum:1,description:Truncate each file's diff to this many bytes at hunk boundaries (default: no per-file limit)"`
+	MaxTotalBytes   int      `json:"max_total_bytes,omitempty" jsonschema:"minimum:1,description:Maximum total bytes of diffs returned (default: 102400)"`
+	HunkOffset      int      `json:"hunk_offset,omitempty" jsonschema:"minimum:0,description:Skip the first N hunks of each file; combine with include to page through a truncated file"`
+	ManifestOnly    bool     `json:"manifest_only,omitempty" jsonschema:"description:Return only the per-file manifest without diffs"`
 }
 
 // ChangeInfo は変更ファイルの情報
@@ -124,59 +179,92 @@ type ChangeInfo struct {
 	NewFile     bool   `json:"new_file"`
 	RenamedFile bool   `json:"renamed_file"`
 	DeletedFile bool   `json:"deleted_file"`
+	Truncated   bool   `json:"truncated,omitempty"`
+}
+
+// FileManifest は変更ファイルごとの概要
+// Size と Hunks は hunk_offset 適用前の差分全体の値
+type FileManifest struct {
+	Path      string `json:"path"`
+	OldPath   string `json:"old_path,omitempty"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Size      int    `json:"size"`
+	Hunks     int    `json:"hunks"`
+	Truncated bool   `json:"truncated"`
+	Skipped   string `json:"skipped,omitempty"`
 }
 
 // GetMergeRequestChangesOutput は get_merge_request_changes の出力
 type GetMergeRequestChangesOutput struct {
-	Changes []ChangeInfo `json:"changes"`
+	Changes    []ChangeInfo   `json:"changes"`
+	Manifest   []FileManifest `json:"manifest"`
+	TotalBytes int            `json:"total_bytes"`
+	Truncated  bool           `json:"truncated"`
 }
 
 // clientHolder holds the GitLab client for handlers
 type clientHolder struct {
 	client *gitlab.Client
+	// generatedPatterns は生成ファイルとみなすパスの glob
+	generatedPatterns []string
 }
 
 var holder *clientHolder
 
 // Register は MR 関連ツールを登録する
 func Register(reg *registry.Registry, client *gitlab.Client) {
-	holder = &clientHolder{client: client}
+	holder = &clientHolder{
+		client:            client,
+		generatedPatterns: defaultGeneratedPatterns,
+	}
+	if patterns := reg.Config().GeneratedFilePatterns; patterns != nil {
+		holder.generatedPatterns = patterns
+	}
 
 	registry.RegisterTool(reg, "list_merge_requests",
 		"GitLab プロジェクトの Merge Request 一覧を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ListMergeRequestsInput) (*mcp.CallToolResult, ListMergeRequestsOutput, error) {
 			return listMergeRequestsHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "get_merge_request",
-		"GitLab Merge Request の詳細情報を取得します",
+		"GitLab Merge Request の詳細情報を取得します（マージ可否、コンフリクト、パイプライン、レビュアー、承認状況などを include で選択可能）",
 		func(ctx context.Context, req *mcp.CallToolRequest, input GetMergeRequestInput) (*mcp.CallToolResult, GetMergeRequestOutput, error) {
 			return getMergeRequestHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "create_merge_request",
 		"GitLab に新しい Merge Request を作成します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input CreateMergeRequestInput) (*mcp.CallToolResult, CreateMergeRequestOutput, error) {
-			return createMergeRequestHandler(holder.client, ctx, req, input)
+			return createMergeRequestHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "update_merge_request",
 		"GitLab Merge Request を更新します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input UpdateMergeRequestInput) (*mcp.CallToolResult, UpdateMergeRequestOutput, error) {
-			return updateMergeRequestHandler(holder.client, ctx, req, input)
+			return updateMergeRequestHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "merge_merge_request",
-		"GitLab Merge Request をマージします",
+		"GitLab Merge Request をマージします（SHA の固定、パイプライン成功時の自動マージ、事前チェックに対応）",
 		func(ctx context.Context, req *mcp.CallToolRequest, input MergeMergeRequestInput) (*mcp.CallToolResult, MergeMergeRequestOutput, error) {
-			return mergeMergeRequestHandler(holder.client, ctx, req, input)
-		})
+			return mergeMergeRequestHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithConfirmation(func(ctx context.Context, input MergeMergeRequestInput) (string, error) {
+			return mergeMergeRequestSummary(holder.client, input)
+		}))
 
 	registry.RegisterTool(reg, "get_merge_request_changes",
-		"GitLab Merge Request の変更差分を取得します",
+		"GitLab Merge Request の変更差分を取得します（glob による絞り込み、生成ファイルの除外、サイズ上限とファイルごとのマニフェスト付き）",
 		func(ctx context.Context, req *mcp.CallToolRequest, input GetMergeRequestChangesInput) (*mcp.CallToolResult, GetMergeRequestChangesOutput, error) {
-			return getMergeRequestChangesHandler(holder.client, ctx, req, input)
-		})
+			return getMergeRequestChangesHandler(holder, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registerVersionTools(reg)
+	registerLifecycleTools(reg)
+	registerSearchTools(reg)
+	registerResources(reg)
+	registerPrompts(reg)
 }
 
 func listMergeRequestsHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListMergeRequestsInput) (*mcp.CallToolResult, ListMergeRequestsOutput, error) {
@@ -195,45 +283,34 @@ func listMergeRequestsHandler(client *gitlab.Client, ctx context.Context, req *m
 
 	summaries := make([]MergeRequestSummary, len(mrs))
 	for i, mr := range mrs {
-		authorName := ""
-		if mr.Author != nil {
-			authorName = mr.Author.Username
-		}
-		summaries[i] = MergeRequestSummary{
-			IID:          mr.IID,
-			Title:        mr.Title,
-			State:        mr.State,
-			SourceBranch: mr.SourceBranch,
-			TargetBranch: mr.TargetBranch,
-			WebURL:       mr.WebURL,
-			AuthorName:   authorName,
-		}
+		summaries[i] = toMergeRequestSummary(mr)
 	}
 
 	return nil, ListMergeRequestsOutput{MergeRequests: summaries}, nil
 }
 
 func getMergeRequestHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input GetMergeRequestInput) (*mcp.CallToolResult, GetMergeRequestOutput, error) {
+	sections, err := resolveDetailSections(input.Include)
+	if err != nil {
+		return nil, GetMergeRequestOutput{}, err
+	}
+
 	mr, err := client.GetMergeRequest(input.ProjectID, input.MergeRequestIID)
 	if err != nil {
 		return nil, GetMergeRequestOutput{}, err
 	}
 
-	authorName := ""
-	if mr.Author != nil {
-		authorName = mr.Author.Username
+	detail := toMergeRequestDetail(mr, sections)
+
+	if sections[includeApprovals] {
+		approvals, err := client.GetMergeRequestApprovals(input.ProjectID, input.MergeRequestIID)
+		if err != nil {
+			return nil, GetMergeRequestOutput{}, err
+		}
+		detail.Approvals = toApprovalSummary(approvals)
 	}
 
-	return nil, GetMergeRequestOutput{
-		IID:          mr.IID,
-		Title:        mr.Title,
-		Description:  mr.Description,
-		State:        mr.State,
-		SourceBranch: mr.SourceBranch,
-		TargetBranch: mr.TargetBranch,
-		WebURL:       mr.WebURL,
-		AuthorName:   authorName,
-	}, nil
+	return nil, detail, nil
 }
 
 func createMergeRequestHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CreateMergeRequestInput) (*mcp.CallToolResult, CreateMergeRequestOutput, error) {
@@ -281,10 +358,73 @@ func updateMergeRequestHandler(client *gitlab.Client, ctx context.Context, req *
 	}, nil
 }
 
+// mergeMergeRequestSummary は確認のためにマージの内容を要約する
+// squash とソースブランチの削除は、指定がなければ MR の設定に従う
+func mergeMergeRequestSummary(client *gitlab.Client, input MergeMergeRequestInput) (string, error) {
+	mr, err := client.GetMergeRequest(input.ProjectID, input.MergeRequestIID)
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{fmt.Sprintf("merge !%d '%s' into %s", mr.IID, mr.Title, mr.TargetBranch)}
+	squash := mr.Squash
+	if input.Squash != nil {
+		squash = *input.Squash
+	}
+	if squash {
+		parts = append(parts, "squash")
+	}
+	removeSourceBranch := mr.ForceRemoveSourceBranch
+	if input.ShouldRemoveSourceBranch != nil {
+		removeSourceBranch = *input.ShouldRemoveSourceBranch
+	}
+	if removeSourceBranch {
+		parts = append(parts, "delete branch")
+	}
+	if input.AutoMerge != nil && *input.AutoMerge {
+		parts = append(parts, "when the pipeline succeeds")
+	}
+	return strings.Join(parts, ", "), nil
+}
+
 func mergeMergeRequestHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input MergeMergeRequestInput) (*mcp.CallToolResult, MergeMergeRequestOutput, error) {
 	opts := &gitlab.MergeMergeRequestOptions{
 		Squash:                   input.Squash,
 		ShouldRemoveSourceBranch: input.ShouldRemoveSourceBranch,
+		MergeCommitMessage:       input.MergeCommitMessage,
+		SquashCommitMessage:      input.SquashCommitMessage,
+		SHA:                      input.SHA,
+		AutoMerge:                input.AutoMerge,
+	}
+
+	if input.Preflight {
+		mr, err := client.GetMergeRequest(input.ProjectID, input.MergeRequestIID)
+		if err != nil {
+			return nil, MergeMergeRequestOutput{}, err
+		}
+		approvals, err := client.GetMergeRequestApprovals(input.ProjectID, input.MergeRequestIID)
+		if err != nil {
+			return nil, MergeMergeRequestOutput{}, err
+		}
+
+		sha := ""
+		if input.SHA != nil {
+			sha = *input.SHA
+		}
+		autoMerge := input.AutoMerge != nil && *input.AutoMerge
+		if blockers := mergeBlockers(mr, approvals, sha, autoMerge); len(blockers) > 0 {
+			return nil, MergeMergeRequestOutput{
+				IID:      mr.IID,
+				State:    mr.State,
+				WebURL:   mr.WebURL,
+				Blockers: blockers,
+			}, nil
+		}
+
+		// チェックした HEAD 以降に push された変更をマージしないよう固定する
+		if opts.SHA == nil {
+			opts.SHA = &mr.SHA
+		}
 	}
 
 	mr, err := client.MergeMergeRequest(input.ProjectID, input.MergeRequestIID, opts)
@@ -293,13 +433,16 @@ func mergeMergeRequestHandler(client *gitlab.Client, ctx context.Context, req *m
 	}
 
 	return nil, MergeMergeRequestOutput{
-		IID:    mr.IID,
-		State:  mr.State,
-		WebURL: mr.WebURL,
+		IID:              mr.IID,
+		State:            mr.State,
+		WebURL:           mr.WebURL,
+		Merged:           mr.State == "merged",
+		AutoMergeEnabled: mr.MergeWhenPipelineSucceeds,
+		MergeCommitSHA:   mr.MergeCommitSHA,
 	}, nil
 }
 
-func getMergeRequestChangesHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input GetMergeRequestChangesInput) (*mcp.CallToolResult, GetMergeRequestChangesOutput, error) {
+func getMergeRequestChangesHandler(h *clientHolder, ctx context.Context, req *mcp.CallToolRequest, input GetMergeRequestChangesInput) (*mcp.CallToolResult, GetMergeRequestChangesOutput, error) {
 	var pagination *gitlab.PaginationOptions
 	if input.Page > 0 || input.PerPage > 0 {
 		pagination = &gitlab.PaginationOptions{
@@ -308,22 +451,104 @@ func getMergeRequestChangesHandler(client *gitlab.Client, ctx context.Context, r
 		}
 	}
 
-	diffs, err := client.GetMergeRequestChanges(input.ProjectID, input.MergeRequestIID, pagination)
+	diffs, err := h.client.GetMergeRequestChanges(input.ProjectID, input.MergeRequestIID, pagination)
 	if err != nil {
 		return nil, GetMergeRequestChangesOutput{}, err
 	}
 
-	changes := make([]ChangeInfo, len(diffs))
-	for i, diff := range diffs {
-		changes[i] = ChangeInfo{
-			OldPath:     diff.OldPath,
-			NewPath:     diff.NewPath,
-			Diff:        diff.Diff,
-			NewFile:     diff.NewFile,
-			RenamedFile: diff.RenamedFile,
-			DeletedFile: diff.DeletedFile,
+	var detector *generatedDetector
+	if input.SkipGenerated == nil || *input.SkipGenerated {
+		detector, err = newGeneratedDetector(h, input.ProjectID, input.MergeRequestIID)
+		if err != nil {
+			return nil, GetMergeRequestChangesOutput{}, err
 		}
 	}
 
-	return nil, GetMergeRequestChangesOutput{Changes: changes}, nil
+	include := compileGlobs(input.Include)
+	exclude := compileGlobs(input.Exclude)
+	remaining := defaultMaxTotalBytes
+	if input.MaxTotalBytes > 0 {
+		remaining = input.MaxTotalBytes
+	}
+
+	output := GetMergeRequestChangesOutput{
+		Changes:  []ChangeInfo{},
+		Manifest: []FileManifest{},
+	}
+	for _, diff := range diffs {
+		path := diffPath(diff)
+		if (len(include) > 0 && !matchAny(include, path)) || matchAny(exclude, path) {
+			continue
+		}
+
+		hunks := splitHunks(diff.Diff)
+		additions, deletions := countChanges(diff.Diff)
+		entry := FileManifest{
+			Path:      path,
+			Additions: additions,
+			Deletions: deletions,
+			Size:      len(diff.Diff),
+			Hunks:     len(hunks),
+		}
+		if diff.RenamedFile {
+			entry.OldPath = diff.OldPath
+		}
+
+		switch {
+		case input.ManifestOnly:
+		case detector != nil && detector.isGenerated(diff):
+			entry.Skipped = skipReasonGenerated
+		case remaining <= 0:
+			entry.Truncated = true
+			entry.Skipped = skipReasonBudget
+		default:
+			limit := remaining
+			if input.MaxBytesPerFile > 0 {
+				limit = min(limit, input.MaxBytesPerFile)
+			}
+			text, truncated := truncateHunks(hunks[min(input.HunkOffset, len(hunks)):], limit)
+			remaining -= len(text)
+			output.TotalBytes += len(text)
+			entry.Truncated = truncated || (input.HunkOffset > 0 && len(hunks) > 0)
+
+			output.Changes = append(output.Changes, ChangeInfo{
+				OldPath:     diff.OldPath,
+				NewPath:     diff.NewPath,
+				Diff:        text,
+				NewFile:     diff.NewFile,
+				RenamedFile: diff.RenamedFile,
+				DeletedFile: diff.DeletedFile,
+				Truncated:   entry.Truncated,
+			})
+		}
+
+		if entry.Truncated {
+			output.Truncated = true
+		}
+		output.Manifest = append(output.Manifest, entry)
+	}
+
+	return nil, output, nil
+}
+
+// newGeneratedDetector は設定のパターンと MR のソースの .gitattributes から生成ファイルの判定器を作成する
+// .gitattributes はリポジトリルートのもののみ参照する
+func newGeneratedDetector(h *clientHolder, projectID string, mrIID int) (*generatedDetector, error) {
+	detector := &generatedDetector{patterns: compileGlobs(h.generatedPatterns)}
+
+	mr, err := h.client.GetMergeRequest(projectID, mrIID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := h.client.GetRawFile(projectID, gitAttributesPath, mr.SHA)
+	if err != nil {
+		var mcpErr *gitlab.MCPError
+		if errors.As(err, &mcpErr) && mcpErr.Code == gitlab.ErrCodeNotFound {
+			return detector, nil
+		}
+		return nil, err
+	}
+	detector.attributes = parseGitAttributes(string(content))
+	return detector, nil
 }
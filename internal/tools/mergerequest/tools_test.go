@@ -174,6 +174,108 @@ func TestGetMergeRequestTool(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	detailedMR := map[string]any{
+		"iid":                           1,
+		"title":                         "Test MR",
+		"state":                         "opened",
+		"draft":                         true,
+		"sha":                           "head-sha",
+		"detailed_merge_status":         "ci_still_running",
+		"has_conflicts":                 false,
+		"blocking_discussions_resolved": true,
+		"changes_count":                 "3",
+		"head_pipeline":                 map[string]any{"id": 77, "status": "running", "sha": "head-sha"},
+		"assignees":                     []map[string]any{{"id": 10, "username": "alice", "name": "Alice"}},
+		"reviewers":                     []map[string]any{{"id": 11, "username": "bob", "name": "Bob"}},
+		"labels":                        []string{"bug", "backend"},
+		"milestone":                     map[string]any{"id": 5, "title": "v1.0", "state": "active", "due_date": "2026-11-01"},
+		"diff_refs":                     map[string]any{"base_sha": "base", "head_sha": "head-sha", "start_sha": "start"},
+	}
+
+	t.Run("returns all default sections", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/merge_requests/1", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(detailedMR)
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetMergeRequestInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+		_, output, err := getMergeRequestHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.Draft)
+		assert.Equal(t, "ci_still_running", output.DetailedMergeStatus)
+		require.NotNil(t, output.HasConflicts)
+		assert.False(t, *output.HasConflicts)
+		require.NotNil(t, output.BlockingDiscussionsResolved)
+		assert.True(t, *output.BlockingDiscussionsResolved)
+		assert.Equal(t, "3", output.ChangesCount)
+		assert.Equal(t, &PipelineSummary{ID: 77, Status: "running", SHA: "head-sha"}, output.HeadPipeline)
+		assert.Equal(t, []UserInfo{{ID: 10, Username: "alice", Name: "Alice"}}, output.Assignees)
+		assert.Equal(t, []UserInfo{{ID: 11, Username: "bob", Name: "Bob"}}, output.Reviewers)
+		assert.Equal(t, []string{"bug", "backend"}, output.Labels)
+		assert.Equal(t, &MilestoneInfo{ID: 5, Title: "v1.0", State: "active", DueDate: "2026-11-01"}, output.Milestone)
+		assert.Equal(t, &DiffRefs{BaseSHA: "base", HeadSHA: "head-sha", StartSHA: "start"}, output.DiffRefs)
+		assert.Nil(t, output.Approvals)
+	})
+
+	t.Run("returns only requested sections", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/v4/projects/test-project/merge_requests/1":
+				json.NewEncoder(w).Encode(detailedMR)
+			case "/api/v4/projects/test-project/merge_requests/1/approvals":
+				json.NewEncoder(w).Encode(map[string]any{
+					"approved":           false,
+					"approvals_required": 2,
+					"approvals_left":     1,
+					"approved_by":        []map[string]any{{"user": map[string]any{"id": 11, "username": "bob"}}},
+				})
+			default:
+				t.Errorf("unexpected request: %s", r.URL.Path)
+			}
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetMergeRequestInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			Include:         []string{"merge_status", "approvals"},
+		}
+
+		_, output, err := getMergeRequestHandler(client, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ci_still_running", output.DetailedMergeStatus)
+		assert.Nil(t, output.HeadPipeline)
+		assert.Nil(t, output.Reviewers)
+		assert.Nil(t, output.Labels)
+		assert.Nil(t, output.DiffRefs)
+		assert.Equal(t, &ApprovalSummary{ApprovalsRequired: 2, ApprovalsLeft: 1, ApprovedBy: []string{"bob"}}, output.Approvals)
+	})
+
+	t.Run("rejects unknown sections", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		})
+		defer cleanup()
+
+		input := GetMergeRequestInput{ProjectID: "test-project", MergeRequestIID: 1, Include: []string{"comments"}}
+
+		_, _, err := getMergeRequestHandler(client, context.Background(), nil, input)
+
+		var mcpErr *gitlab.MCPError
+		require.ErrorAs(t, err, &mcpErr)
+		assert.Equal(t, gitlab.ErrCodeBadRequest, mcpErr.Code)
+	})
 }
 
 func TestCreateMergeRequestTool(t *testing.T) {
@@ -311,25 +413,69 @@ func TestMergeMergeRequestTool(t *testing.T) {
 	})
 }
 
-func TestGetMergeRequestChangesTool(t *testing.T) {
-	t.Run("returns changes successfully", func(t *testing.T) {
-		handler := func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path == "/api/v4/projects/test-project/merge_requests/1/diffs" {
+func TestMergeMergeRequestSummary(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name  string
+		mr    map[string]any
+		input MergeMergeRequestInput
+		want  string
+	}{
+		{
+			name:  "squash and delete branch",
+			mr:    map[string]any{"id": 100, "iid": 42, "title": "Add billing", "target_branch": "main"},
+			input: MergeMergeRequestInput{Squash: &yes, ShouldRemoveSourceBranch: &yes},
+			want:  "merge !42 'Add billing' into main, squash, delete branch",
+		},
+		{
+			name:  "settings of the MR are used by default",
+			mr:    map[string]any{"id": 100, "iid": 42, "title": "Add billing", "target_branch": "main", "squash": true, "force_remove_source_branch": true},
+			input: MergeMergeRequestInput{},
+			want:  "merge !42 'Add billing' into main, squash, delete branch",
+		},
+		{
+			name:  "input overrides the MR settings",
+			mr:    map[string]any{"id": 100, "iid": 42, "title": "Add billing", "target_branch": "main", "squash": true},
+			input: MergeMergeRequestInput{Squash: &no, AutoMerge: &yes},
+			want:  "merge !42 'Add billing' into main, when the pipeline succeeds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v4/projects/test-project/merge_requests/42", r.URL.Path)
+				assert.Equal(t, "GET", r.Method)
+
 				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode([]map[string]any{
-					{
-						"old_path": "file.go",
-						"new_path": "file.go",
-						"diff":     "@@ -1,3 +1,4 @@\n+new line",
-						"new_file": false,
-					},
-				})
-				return
+				json.NewEncoder(w).Encode(tt.mr)
 			}
-			w.WriteHeader(http.StatusNotFound)
-		}
 
-		client, reg, cleanup := setupTestServer(t, handler)
+			client, _, cleanup := setupTestServer(t, handler)
+			defer cleanup()
+
+			tt.input.ProjectID = "test-project"
+			tt.input.MergeRequestIID = 42
+			summary, err := mergeMergeRequestSummary(client, tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, summary)
+		})
+	}
+}
+
+func TestGetMergeRequestChangesTool(t *testing.T) {
+	t.Run("returns changes successfully", func(t *testing.T) {
+		handler := changesHandler(t, []map[string]any{
+			{
+				"old_path": "file.go",
+				"new_path": "file.go",
+				"diff":     "@@ -1,3 +1,4 @@\n+new line",
+				"new_file": false,
+			},
+		}, "")
+
+		_, reg, cleanup := setupTestServer(t, handler)
 		defer cleanup()
 
 		assert.True(t, reg.IsRegistered("get_merge_request_changes"))
@@ -340,12 +486,198 @@ func TestGetMergeRequestChangesTool(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		_, output, err := getMergeRequestChangesHandler(client, ctx, nil, input)
+		_, output, err := getMergeRequestChangesHandler(holder, ctx, nil, input)
 
 		require.NoError(t, err)
 		assert.Len(t, output.Changes, 1)
 		assert.Equal(t, "file.go", output.Changes[0].NewPath)
+		assert.Equal(t, []FileManifest{
+			{Path: "file.go", Additions: 1, Size: 25, Hunks: 1},
+		}, output.Manifest)
+	})
+
+	t.Run("filters files by include and exclude globs", func(t *testing.T) {
+		handler := changesHandler(t, []map[string]any{
+			{"old_path": "cmd/main.go", "new_path": "cmd/main.go", "diff": "@@ -1 +1 @@\n-a\n+b\n"},
+			{"old_path": "cmd/main_test.go", "new_path": "cmd/main_test.go", "diff": "@@ -1 +1 @@\n-a\n+b\n"},
+			{"old_path": "README.md", "new_path": "README.md", "diff": "@@ -1 +1 @@\n-a\n+b\n"},
+		}, "")
+
+		_, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetMergeRequestChangesInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			Include:         []string{"cmd/**/*.go"},
+			Exclude:         []string{"*_test.go"},
+		}
+
+		_, output, err := getMergeRequestChangesHandler(holder, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		require.Len(t, output.Changes, 1)
+		assert.Equal(t, "cmd/main.go", output.Changes[0].NewPath)
+		require.Len(t, output.Manifest, 1)
+		assert.Equal(t, 1, output.Manifest[0].Additions)
+		assert.Equal(t, 1, output.Manifest[0].Deletions)
+	})
+
+	t.Run("skips generated files and honors gitattributes", func(t *testing.T) {
+		handler := changesHandler(t, []map[string]any{
+			{"old_path": "go.sum", "new_path": "go.sum", "diff": "@@ -1 +1 @@\n+x\n"},
+			{"old_path": "api/api.pb.go", "new_path": "api/api.pb.go", "diff": "@@ -1 +1 @@\n+x\n"},
+			{"old_path": "schema/out.json", "new_path": "schema/out.json", "diff": "@@ -1 +1 @@\n+x\n"},
+			{"old_path": "web/bundle.js", "new_path": "web/bundle.js", "diff": "@@ -1 +1 @@\n+x\n", "generated_file": true},
+			{"old_path": "main.go", "new_path": "main.go", "diff": "@@ -1 +1 @@\n+x\n"},
+		}, "schema/** linguist-generated\n*.pb.go -linguist-generated\n")
+
+		_, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetMergeRequestChangesInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+		_, output, err := getMergeRequestChangesHandler(holder, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		var returned []string
+		for _, c := range output.Changes {
+			returned = append(returned, c.NewPath)
+		}
+		assert.Equal(t, []string{"api/api.pb.go", "main.go"}, returned)
+		require.Len(t, output.Manifest, 5)
+		assert.Equal(t, skipReasonGenerated, output.Manifest[0].Skipped)
+		assert.Equal(t, skipReasonGenerated, output.Manifest[2].Skipped)
+		assert.Equal(t, skipReasonGenerated, output.Manifest[3].Skipped)
+	})
+
+	t.Run("returns generated files when skip_generated is false", func(t *testing.T) {
+		handler := changesHandler(t, []map[string]any{
+			{"old_path": "go.sum", "new_path": "go.sum", "diff": "@@ -1 +1 @@\n+x\n"},
+		}, "")
+
+		_, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		skip := false
+		input := GetMergeRequestChangesInput{ProjectID: "test-project", MergeRequestIID: 1, SkipGenerated: &skip}
+
+		_, output, err := getMergeRequestChangesHandler(holder, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.Len(t, output.Changes, 1)
 	})
+
+	t.Run("truncates at hunk boundaries within byte limits", func(t *testing.T) {
+		big := "@@ -1 +1 @@\n+aaaaaaaaaa\n@@ -10 +10 @@\n+bbbbbbbbbb\n"
+		handler := changesHandler(t, []map[string]any{
+			{"old_path": "a.go", "new_path": "a.go", "diff": big},
+			{"old_path": "b.go", "new_path": "b.go", "diff": big},
+			{"old_path": "c.go", "new_path": "c.go", "diff": big},
+		}, "")
+
+		_, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetMergeRequestChangesInput{
+			ProjectID:       "test-project",
+			MergeRequestIID: 1,
+			MaxBytesPerFile: 30,
+			MaxTotalBytes:   60,
+		}
+
+		_, output, err := getMergeRequestChangesHandler(holder, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.Truncated)
+		require.Len(t, output.Changes, 3)
+		assert.Equal(t, "@@ -1 +1 @@\n+aaaaaaaaaa\n", output.Changes[0].Diff)
+		assert.True(t, output.Changes[0].Truncated)
+		assert.Equal(t, "@@ -1 +1 @@\n+aaaaaaaaaa\n", output.Changes[1].Diff)
+		assert.Equal(t, "@@ -1 +1 @@\n", output.Changes[2].Diff)
+		assert.Equal(t, 60, output.TotalBytes)
+		assert.Equal(t, 2, output.Manifest[0].Hunks)
+	})
+
+	t.Run("skips files once the total budget is exhausted", func(t *testing.T) {
+		diff := "@@ -1 +1 @@\n+aaaaaaaaaa\n"
+		handler := changesHandler(t, []map[string]any{
+			{"old_path": "a.go", "new_path": "a.go", "diff": diff},
+			{"old_path": "b.go", "new_path": "b.go", "diff": diff},
+		}, "")
+
+		_, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetMergeRequestChangesInput{ProjectID: "test-project", MergeRequestIID: 1, MaxTotalBytes: len(diff)}
+
+		_, output, err := getMergeRequestChangesHandler(holder, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.Len(t, output.Changes, 1)
+		assert.Equal(t, skipReasonBudget, output.Manifest[1].Skipped)
+		assert.True(t, output.Manifest[1].Truncated)
+	})
+
+	t.Run("pages through hunks with hunk_offset", func(t *testing.T) {
+		handler := changesHandler(t, []map[string]any{
+			{"old_path": "a.go", "new_path": "a.go", "diff": "@@ -1 +1 @@\n+a\n@@ -10 +10 @@\n+b\n"},
+		}, "")
+
+		_, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetMergeRequestChangesInput{ProjectID: "test-project", MergeRequestIID: 1, HunkOffset: 1}
+
+		_, output, err := getMergeRequestChangesHandler(holder, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		require.Len(t, output.Changes, 1)
+		assert.Equal(t, "@@ -10 +10 @@\n+b\n", output.Changes[0].Diff)
+	})
+
+	t.Run("returns only the manifest", func(t *testing.T) {
+		handler := changesHandler(t, []map[string]any{
+			{"old_path": "old.go", "new_path": "new.go", "diff": "@@ -1 +1 @@\n-a\n+b\n", "renamed_file": true},
+		}, "")
+
+		_, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetMergeRequestChangesInput{ProjectID: "test-project", MergeRequestIID: 1, ManifestOnly: true}
+
+		_, output, err := getMergeRequestChangesHandler(holder, context.Background(), nil, input)
+
+		require.NoError(t, err)
+		assert.Empty(t, output.Changes)
+		require.Len(t, output.Manifest, 1)
+		assert.Equal(t, "new.go", output.Manifest[0].Path)
+		assert.Equal(t, "old.go", output.Manifest[0].OldPath)
+	})
+}
+
+// changesHandler は MR の差分と .gitattributes（空の場合は 404）を返すハンドラを作成する
+func changesHandler(t *testing.T, diffs []map[string]any, gitattributes string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/merge_requests/1/diffs":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(diffs)
+		case "/api/v4/projects/test-project/merge_requests/1":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"iid": 1, "sha": "head-sha"})
+		case "/api/v4/projects/test-project/repository/files/.gitattributes/raw":
+			assert.Equal(t, "head-sha", r.URL.Query().Get("ref"))
+			if gitattributes == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(gitattributes))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
 }
 
 func TestToolDisabled(t *testing.T) {
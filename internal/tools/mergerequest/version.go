@@ -0,0 +1,206 @@
+package mergerequest
+
+import (
+	"context"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ListMergeRequestVersionsInput は list_merge_request_versions の入力パラメータ
+type ListMergeRequestVersionsInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	Page            int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage         int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
+}
+
+// VersionInfo は MR の差分バージョン情報
+type VersionInfo struct {
+	ID             int64  `json:"id"`
+	HeadCommitSHA  string `json:"head_commit_sha"`
+	BaseCommitSHA  string `json:"base_commit_sha"`
+	StartCommitSHA string `json:"start_commit_sha"`
+	State          string `json:"state,omitempty"`
+	RealSize       string `json:"real_size,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+}
+
+// ListMergeRequestVersionsOutput は list_merge_request_versions の出力
+type ListMergeRequestVersionsOutput struct {
+	Versions []VersionInfo `json:"versions"`
+}
+
+// GetMergeRequestVersionInput は get_merge_request_version の入力パラメータ
+type GetMergeRequestVersionInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	VersionID       int    `json:"version_id" jsonschema:"minimum:1,description:Diff version ID"`
+}
+
+// CommitInfo はコミット情報
+type CommitInfo struct {
+	ID         string `json:"id"`
+	ShortID    string `json:"short_id"`
+	Title      string `json:"title"`
+	AuthorName string `json:"author_name,omitempty"`
+}
+
+// GetMergeRequestVersionOutput は get_merge_request_version の出力
+type GetMergeRequestVersionOutput struct {
+	VersionInfo
+	Commits []CommitInfo `json:"commits"`
+	Changes []ChangeInfo `json:"changes"`
+}
+
+// DiffSinceVersionInput は diff_since_version の入力パラメータ
+type DiffSinceVersionInput struct {
+	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
+	FromVersionID   int    `json:"from_version_id" jsonschema:"minimum:1,description:Version ID that was last reviewed"`
+	ToVersionID     int    `json:"to_version_id,omitempty" jsonschema:"minimum:1,description:Version ID to compare to (default: latest version)"`
+}
+
+// DiffSinceVersionOutput は diff_since_version の出力
+type DiffSinceVersionOutput struct {
+	FromVersion    VersionInfo  `json:"from_version"`
+	ToVersion      VersionInfo  `json:"to_version"`
+	Commits        []CommitInfo `json:"commits"`
+	Changes        []ChangeInfo `json:"changes"`
+	CompareTimeout bool         `json:"compare_timeout,omitempty"`
+}
+
+// registerVersionTools は MR の差分バージョン関連ツールを登録する
+func registerVersionTools(reg *registry.Registry) {
+	registry.RegisterTool(reg, "list_merge_request_versions",
+		"GitLab Merge Request の差分バージョン（プッシュごとのスナップショット）一覧を新しい順に取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input ListMergeRequestVersionsInput) (*mcp.CallToolResult, ListMergeRequestVersionsOutput, error) {
+			return listMergeRequestVersionsHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "get_merge_request_version",
+		"GitLab Merge Request の差分バージョンをコミットと差分付きで取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input GetMergeRequestVersionInput) (*mcp.CallToolResult, GetMergeRequestVersionOutput, error) {
+			return getMergeRequestVersionHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "diff_since_version",
+		"GitLab Merge Request の2つの差分バージョン間の変更（前回レビュー以降の差分）を取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input DiffSinceVersionInput) (*mcp.CallToolResult, DiffSinceVersionOutput, error) {
+			return diffSinceVersionHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+}
+
+func toVersionInfo(v *gogitlab.MergeRequestDiffVersion) VersionInfo {
+	createdAt := ""
+	if v.CreatedAt != nil {
+		createdAt = v.CreatedAt.String()
+	}
+	return VersionInfo{
+		ID:             v.ID,
+		HeadCommitSHA:  v.HeadCommitSHA,
+		BaseCommitSHA:  v.BaseCommitSHA,
+		StartCommitSHA: v.StartCommitSHA,
+		State:          v.State,
+		RealSize:       v.RealSize,
+		CreatedAt:      createdAt,
+	}
+}
+
+func toCommitInfos(commits []*gogitlab.Commit) []CommitInfo {
+	infos := make([]CommitInfo, len(commits))
+	for i, c := range commits {
+		infos[i] = CommitInfo{
+			ID:         c.ID,
+			ShortID:    c.ShortID,
+			Title:      c.Title,
+			AuthorName: c.AuthorName,
+		}
+	}
+	return infos
+}
+
+func toChangeInfos(diffs []*gogitlab.Diff) []ChangeInfo {
+	changes := make([]ChangeInfo, len(diffs))
+	for i, d := range diffs {
+		changes[i] = ChangeInfo{
+			OldPath:     d.OldPath,
+			NewPath:     d.NewPath,
+			Diff:        d.Diff,
+			NewFile:     d.NewFile,
+			RenamedFile: d.RenamedFile,
+			DeletedFile: d.DeletedFile,
+		}
+	}
+	return changes
+}
+
+func listMergeRequestVersionsHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListMergeRequestVersionsInput) (*mcp.CallToolResult, ListMergeRequestVersionsOutput, error) {
+	versions, err := client.ListMergeRequestDiffVersions(input.ProjectID, input.MergeRequestIID, &gitlab.PaginationOptions{
+		Page:    input.Page,
+		PerPage: input.PerPage,
+	})
+	if err != nil {
+		return nil, ListMergeRequestVersionsOutput{}, err
+	}
+
+	output := ListMergeRequestVersionsOutput{Versions: make([]VersionInfo, len(versions))}
+	for i, v := range versions {
+		output.Versions[i] = toVersionInfo(v)
+	}
+	return nil, output, nil
+}
+
+func getMergeRequestVersionHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input GetMergeRequestVersionInput) (*mcp.CallToolResult, GetMergeRequestVersionOutput, error) {
+	version, err := client.GetMergeRequestDiffVersion(input.ProjectID, input.MergeRequestIID, input.VersionID)
+	if err != nil {
+		return nil, GetMergeRequestVersionOutput{}, err
+	}
+
+	return nil, GetMergeRequestVersionOutput{
+		VersionInfo: toVersionInfo(version),
+		Commits:     toCommitInfos(version.Commits),
+		Changes:     toChangeInfos(version.Diffs),
+	}, nil
+}
+
+func diffSinceVersionHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DiffSinceVersionInput) (*mcp.CallToolResult, DiffSinceVersionOutput, error) {
+	from, err := client.GetMergeRequestDiffVersion(input.ProjectID, input.MergeRequestIID, input.FromVersionID)
+	if err != nil {
+		return nil, DiffSinceVersionOutput{}, err
+	}
+
+	var to *gogitlab.MergeRequestDiffVersion
+	if input.ToVersionID > 0 {
+		to, err = client.GetMergeRequestDiffVersion(input.ProjectID, input.MergeRequestIID, input.ToVersionID)
+	} else {
+		to, err = client.GetLatestMergeRequestDiffVersion(input.ProjectID, input.MergeRequestIID)
+	}
+	if err != nil {
+		return nil, DiffSinceVersionOutput{}, err
+	}
+
+	output := DiffSinceVersionOutput{
+		FromVersion: toVersionInfo(from),
+		ToVersion:   toVersionInfo(to),
+		Commits:     []CommitInfo{},
+		Changes:     []ChangeInfo{},
+	}
+
+	// 同じコミットを指している場合は差分なし
+	if from.HeadCommitSHA == to.HeadCommitSHA {
+		return nil, output, nil
+	}
+
+	compare, err := client.CompareCommits(input.ProjectID, from.HeadCommitSHA, to.HeadCommitSHA)
+	if err != nil {
+		return nil, DiffSinceVersionOutput{}, err
+	}
+
+	output.Commits = toCommitInfos(compare.Commits)
+	output.Changes = toChangeInfos(compare.Diffs)
+	output.CompareTimeout = compare.CompareTimeout
+	return nil, output, nil
+}
@@ -0,0 +1,118 @@
+package mergerequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionsHandler は2つの差分バージョンを持つ MR を返すハンドラを作成する
+func versionsHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/merge_requests/1/versions":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 2, "head_commit_sha": "bbb", "base_commit_sha": "base", "start_commit_sha": "base", "state": "collected", "created_at": "2024-01-02T10:00:00Z"},
+				{"id": 1, "head_commit_sha": "aaa", "base_commit_sha": "base", "start_commit_sha": "base", "state": "collected", "created_at": "2024-01-01T10:00:00Z"},
+			})
+		case "/api/v4/projects/test-project/merge_requests/1/versions/1":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id": 1, "head_commit_sha": "aaa", "base_commit_sha": "base", "start_commit_sha": "base",
+				"commits": []map[string]any{{"id": "aaa", "short_id": "aaa", "title": "Initial implementation", "author_name": "Author"}},
+				"diffs":   []map[string]any{{"old_path": "a.go", "new_path": "a.go", "new_file": true, "diff": "@@ -0,0 +1 @@\n+a\n"}},
+			})
+		case "/api/v4/projects/test-project/merge_requests/1/versions/2":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id": 2, "head_commit_sha": "bbb", "base_commit_sha": "base", "start_commit_sha": "base",
+			})
+		case "/api/v4/projects/test-project/repository/compare":
+			assert.Equal(t, "aaa", r.URL.Query().Get("from"))
+			assert.Equal(t, "bbb", r.URL.Query().Get("to"))
+			json.NewEncoder(w).Encode(map[string]any{
+				"commits": []map[string]any{{"id": "bbb", "short_id": "bbb", "title": "Address review comments"}},
+				"diffs":   []map[string]any{{"old_path": "a.go", "new_path": "a.go", "diff": "@@ -1 +1 @@\n-a\n+b\n"}},
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestListMergeRequestVersionsTool(t *testing.T) {
+	client, reg, cleanup := setupTestServer(t, versionsHandler(t))
+	defer cleanup()
+
+	assert.True(t, reg.IsRegistered("list_merge_request_versions"))
+
+	input := ListMergeRequestVersionsInput{ProjectID: "test-project", MergeRequestIID: 1}
+
+	ctx := context.Background()
+	_, output, err := listMergeRequestVersionsHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	require.Len(t, output.Versions, 2)
+	assert.Equal(t, int64(2), output.Versions[0].ID)
+	assert.Equal(t, "bbb", output.Versions[0].HeadCommitSHA)
+	assert.NotEmpty(t, output.Versions[0].CreatedAt)
+}
+
+func TestGetMergeRequestVersionTool(t *testing.T) {
+	client, reg, cleanup := setupTestServer(t, versionsHandler(t))
+	defer cleanup()
+
+	assert.True(t, reg.IsRegistered("get_merge_request_version"))
+
+	input := GetMergeRequestVersionInput{ProjectID: "test-project", MergeRequestIID: 1, VersionID: 1}
+
+	ctx := context.Background()
+	_, output, err := getMergeRequestVersionHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "aaa", output.HeadCommitSHA)
+	require.Len(t, output.Commits, 1)
+	assert.Equal(t, "Initial implementation", output.Commits[0].Title)
+	require.Len(t, output.Changes, 1)
+	assert.True(t, output.Changes[0].NewFile)
+}
+
+func TestDiffSinceVersionTool(t *testing.T) {
+	t.Run("compares with latest version by default", func(t *testing.T) {
+		client, reg, cleanup := setupTestServer(t, versionsHandler(t))
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("diff_since_version"))
+
+		input := DiffSinceVersionInput{ProjectID: "test-project", MergeRequestIID: 1, FromVersionID: 1}
+
+		ctx := context.Background()
+		_, output, err := diffSinceVersionHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), output.FromVersion.ID)
+		assert.Equal(t, int64(2), output.ToVersion.ID)
+		require.Len(t, output.Commits, 1)
+		assert.Equal(t, "Address review comments", output.Commits[0].Title)
+		require.Len(t, output.Changes, 1)
+		assert.Contains(t, output.Changes[0].Diff, "+b")
+	})
+
+	t.Run("returns empty diff for same version", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, versionsHandler(t))
+		defer cleanup()
+
+		input := DiffSinceVersionInput{ProjectID: "test-project", MergeRequestIID: 1, FromVersionID: 2, ToVersionID: 2}
+
+		ctx := context.Background()
+		_, output, err := diffSinceVersionHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Empty(t, output.Commits)
+		assert.Empty(t, output.Changes)
+	})
+}
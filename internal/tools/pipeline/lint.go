@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// LintCIConfigInput は lint_ci_config の入力パラメータ
+type LintCIConfigInput struct {
+	ProjectID        string  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Content          *string `json:"content,omitempty" jsonschema:"description:CI/CD YAML content to validate. If omitted, the .gitlab-ci.yml at ref is validated"`
+	Ref              *string `json:"ref,omitempty" jsonschema:"description:Branch or tag used to resolve includes and for pipeline simulation (default: project default branch)"`
+	SimulatePipeline bool    `json:"simulate_pipeline,omitempty" jsonschema:"description:Simulate pipeline creation for ref to list the jobs that would actually run"`
+}
+
+// CIIncludeInfo は展開された include の情報
+type CIIncludeInfo struct {
+	Type     string `json:"type"`
+	Location string `json:"location"`
+}
+
+// CIJobInfo は検証結果に含まれるジョブ情報
+type CIJobInfo struct {
+	Name         string   `json:"name"`
+	Stage        string   `json:"stage"`
+	When         string   `json:"when,omitempty"`
+	AllowFailure bool     `json:"allow_failure"`
+	Environment  string   `json:"environment,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// LintCIConfigOutput は lint_ci_config の出力
+type LintCIConfigOutput struct {
+	Valid      bool            `json:"valid"`
+	Errors     []string        `json:"errors"`
+	Warnings   []string        `json:"warnings"`
+	MergedYAML string          `json:"merged_yaml,omitempty"`
+	Includes   []CIIncludeInfo `json:"includes,omitempty"`
+	Jobs       []CIJobInfo     `json:"jobs"`
+}
+
+// registerLintTools は CI 設定検証ツールを登録する
+func registerLintTools(reg *registry.Registry) {
+	registry.RegisterTool(reg, "lint_ci_config",
+		"GitLab CI/CD 設定を検証し、エラー・警告・include 展開後の YAML・実行されるジョブ一覧を返します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input LintCIConfigInput) (*mcp.CallToolResult, LintCIConfigOutput, error) {
+			return lintCIConfigHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+}
+
+func lintCIConfigHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input LintCIConfigInput) (*mcp.CallToolResult, LintCIConfigOutput, error) {
+	opts := &gitlab.LintCIConfigOptions{
+		Content: input.Content,
+		Ref:     input.Ref,
+		DryRun:  input.SimulatePipeline,
+	}
+
+	result, err := client.LintCIConfig(input.ProjectID, opts)
+	if err != nil {
+		return nil, LintCIConfigOutput{}, err
+	}
+
+	errs := result.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+	warnings := result.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+
+	includes := make([]CIIncludeInfo, len(result.Includes))
+	for i, inc := range result.Includes {
+		includes[i] = CIIncludeInfo{
+			Type:     inc.Type,
+			Location: inc.Location,
+		}
+	}
+
+	jobs := make([]CIJobInfo, len(result.Jobs))
+	for i, j := range result.Jobs {
+		jobs[i] = CIJobInfo{
+			Name:         j.Name,
+			Stage:        j.Stage,
+			When:         j.When,
+			AllowFailure: j.AllowFailure,
+			Environment:  j.Environment,
+			Tags:         j.TagList,
+		}
+	}
+
+	return nil, LintCIConfigOutput{
+		Valid:      result.Valid,
+		Errors:     errs,
+		Warnings:   warnings,
+		MergedYAML: result.MergedYaml,
+		Includes:   includes,
+		Jobs:       jobs,
+	}, nil
+}
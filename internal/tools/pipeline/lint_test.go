@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintCIConfigTool(t *testing.T) {
+	t.Run("validates content and returns merged yaml and jobs", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/ci/lint", r.URL.Path)
+			assert.Equal(t, "POST", r.Method)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"valid":       true,
+				"errors":      []string{},
+				"warnings":    []string{},
+				"merged_yaml": "---\nbuild:\n  script:\n  - make\ntest:\n  script:\n  - make test\n",
+				"includes": []map[string]any{
+					{"type": "local", "location": "ci/test.yml"},
+				},
+				"jobs": []map[string]any{
+					{"name": "build", "stage": "build", "when": "on_success", "tag_list": []string{"docker"}},
+					{"name": "test", "stage": "test", "when": "on_success"},
+				},
+			})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("lint_ci_config"))
+
+		content := "include: ci/test.yml\nbuild:\n  script: make\n"
+		input := LintCIConfigInput{
+			ProjectID:        "test-project",
+			Content:          &content,
+			SimulatePipeline: true,
+		}
+
+		ctx := context.Background()
+		_, output, err := lintCIConfigHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.Valid)
+		assert.Empty(t, output.Errors)
+		assert.Contains(t, output.MergedYAML, "make test")
+		require.Len(t, output.Includes, 1)
+		assert.Equal(t, "ci/test.yml", output.Includes[0].Location)
+		require.Len(t, output.Jobs, 2)
+		assert.Equal(t, []string{"docker"}, output.Jobs[0].Tags)
+	})
+
+	t.Run("returns validation errors", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"valid":  false,
+				"errors": []string{"jobs:test config should implement a script: or a trigger: keyword"},
+			})
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		ref := "feature"
+		input := LintCIConfigInput{ProjectID: "test-project", Ref: &ref}
+
+		ctx := context.Background()
+		_, output, err := lintCIConfigHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.False(t, output.Valid)
+		assert.Len(t, output.Errors, 1)
+		assert.NotNil(t, output.Warnings)
+		assert.Empty(t, output.Jobs)
+	})
+}
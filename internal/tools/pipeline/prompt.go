@@ -0,0 +1,118 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// triage_failed_pipeline に含めるログの量
+const (
+	// triageMaxJobLogs はログを含める失敗ジョブの最大数
+	triageMaxJobLogs = 5
+	// triageLogTailLines は各ジョブのログの末尾から含める行数
+	triageLogTailLines = 200
+)
+
+// triageInstructions は triage_failed_pipeline のモデルへの指示
+const triageInstructions = `You are triaging the failed GitLab CI/CD pipeline below. For each failed job:
+1. Identify the first real error in the log (not the cascade of errors after it) and quote the relevant lines.
+2. Classify the root cause: code defect, failing test, flaky test or infrastructure, dependency or network issue, or CI configuration error.
+3. Propose a concrete fix, or say whether simply retrying the job is likely to succeed.
+
+Jobs marked "allowed to fail" do not fail the pipeline; mention them only if they point to the same problem. Finish with a short summary of the next action. Use get_job_log if you need more of a log than the tail shown here, and retry_pipeline_job only after the user agrees.`
+
+// logControlPattern は GitLab のジョブログに含まれる ANSI エスケープシーケンスと折りたたみセクションの制御行
+var logControlPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]|section_(?:start|end):\d+:[^\r\n]*?\r`)
+
+// registerPrompts はパイプラインのプロンプトを登録する
+func registerPrompts(reg *registry.Registry) {
+	registry.RegisterPrompt(reg, &mcp.Prompt{
+		Name:        "triage_failed_pipeline",
+		Title:       "Triage Failed Pipeline",
+		Description: "失敗したパイプラインのジョブとログの末尾をもとに原因と対処を調べる",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "project_id", Description: "Project ID or URL-encoded path", Required: true},
+			{Name: "pipeline_id", Description: "Pipeline ID", Required: true},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+		return triageFailedPipelinePrompt(holder.client, ctx, args)
+	})
+}
+
+func triageFailedPipelinePrompt(client *gitlab.Client, ctx context.Context, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+	pipelineID, err := args.Int("pipeline_id")
+	if err != nil {
+		return nil, err
+	}
+	projectID := args["project_id"]
+
+	_, pipeline, err := getPipelineHandler(client, ctx, nil, GetPipelineInput{ProjectID: projectID, PipelineID: pipelineID})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := client.ListPipelineJobs(projectID, pipelineID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(triageInstructions)
+	fmt.Fprintf(&b, "\n\n# Pipeline #%d\n\n", pipeline.ID)
+	fmt.Fprintf(&b, "- Status: %s\n- Ref: %s\n- Commit: %s\n- Source: %s\n- URL: %s\n", pipeline.Status, pipeline.Ref, pipeline.SHA, pipeline.Source, pipeline.WebURL)
+
+	var failed []*gogitlab.Job
+	b.WriteString("\n## Jobs\n\n")
+	for _, j := range jobs {
+		fmt.Fprintf(&b, "- %s / %s: %s", j.Stage, j.Name, j.Status)
+		if j.Status == "failed" {
+			if j.FailureReason != "" {
+				fmt.Fprintf(&b, " (%s)", j.FailureReason)
+			}
+			if j.AllowFailure {
+				b.WriteString(" [allowed to fail]")
+			}
+			failed = append(failed, j)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(failed) == 0 {
+		b.WriteString("\nNo jobs have failed in this pipeline.\n")
+	}
+	for i, j := range failed {
+		if i == triageMaxJobLogs {
+			fmt.Fprintf(&b, "\nLogs of the remaining %d failed jobs are omitted.\n", len(failed)-triageMaxJobLogs)
+			break
+		}
+		log, err := client.GetJobTrace(projectID, int(j.ID))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "\n## Log of job %d (%s), last %d lines\n\n```\n%s\n```\n", j.ID, j.Name, triageLogTailLines, tailLines(cleanJobLog(log), triageLogTailLines))
+	}
+
+	return registry.UserPrompt(fmt.Sprintf("Triage of pipeline #%d", pipeline.ID), b.String()), nil
+}
+
+// cleanJobLog はジョブログから色付けと折りたたみセクションの制御文字を取り除く
+func cleanJobLog(log string) string {
+	log = logControlPattern.ReplaceAllString(log, "")
+	return strings.ReplaceAll(log, "\r\n", "\n")
+}
+
+// tailLines は text の末尾 n 行を返す
+func tailLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
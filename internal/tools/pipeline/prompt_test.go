@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriageFailedPipelinePrompt(t *testing.T) {
+	var longLog strings.Builder
+	for i := 1; i <= 300; i++ {
+		fmt.Fprintf(&longLog, "line %d\n", i)
+	}
+	longLog.WriteString("\x1b[31;1mERROR: tests failed\x1b[0;m\n")
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/pipelines/100":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"id": 100, "status": "failed", "ref": "main", "sha": "abc123", "source": "push",
+			})
+		case "/api/v4/projects/test-project/pipelines/100/jobs":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 1, "name": "build", "stage": "build", "status": "success"},
+				{"id": 2, "name": "test", "stage": "test", "status": "failed", "failure_reason": "script_failure"},
+				{"id": 3, "name": "lint", "stage": "test", "status": "failed", "allow_failure": true},
+			})
+		case "/api/v4/projects/test-project/jobs/2/trace":
+			w.Write([]byte(longLog.String()))
+		case "/api/v4/projects/test-project/jobs/3/trace":
+			w.Write([]byte("section_start:1700000000:lint\r\x1b[0Krunning lint\nwarning: unused variable\n"))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	res, err := triageFailedPipelinePrompt(client, context.Background(), registry.PromptArgs{
+		"project_id":  "test-project",
+		"pipeline_id": "100",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+
+	content, ok := res.Messages[0].Content.(*mcp.TextContent)
+	require.True(t, ok)
+
+	assert.Contains(t, content.Text, "# Pipeline #100")
+	assert.Contains(t, content.Text, "- build / build: success\n")
+	assert.Contains(t, content.Text, "- test / test: failed (script_failure)\n")
+	assert.Contains(t, content.Text, "- test / lint: failed [allowed to fail]\n")
+	assert.Contains(t, content.Text, "## Log of job 2 (test), last 200 lines")
+	assert.Contains(t, content.Text, "line 300\nERROR: tests failed\n```")
+	assert.NotContains(t, content.Text, "line 100\n")
+	assert.Contains(t, content.Text, "running lint\nwarning: unused variable")
+	assert.NotContains(t, content.Text, "\x1b")
+	assert.NotContains(t, content.Text, "section_start")
+}
+
+func TestTailLines(t *testing.T) {
+	assert.Equal(t, "b\nc", tailLines("a\nb\nc\n", 2))
+	assert.Equal(t, "a\nb", tailLines("a\nb", 5))
+}
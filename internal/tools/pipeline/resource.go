@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// パイプライン・ジョブログのリソースの URI テンプレート
+// project はプロジェクト ID または URL エンコードしたパス（例: group%2Fproject）
+const (
+	PipelineURITemplate = "gitlab://{project}/pipelines/{id}"
+	JobLogURITemplate   = "gitlab://{project}/jobs/{id}/log"
+)
+
+// resourceJobsPerPage はパイプラインのリソースに含めるジョブの最大数
+const resourceJobsPerPage = 100
+
+// PipelineResource はパイプラインのリソースの内容
+type PipelineResource struct {
+	Pipeline PipelineDetail `json:"pipeline"`
+	Jobs     []JobInfo      `json:"jobs"`
+}
+
+// registerResources はパイプライン・ジョブログのリソーステンプレートを登録する
+func registerResources(reg *registry.Registry) {
+	registry.RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "pipeline",
+		Title:       "GitLab Pipeline",
+		Description: "GitLab パイプラインの詳細とジョブ一覧",
+		MIMEType:    "application/json",
+		URITemplate: PipelineURITemplate,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params registry.ResourceParams) (*mcp.ReadResourceResult, error) {
+		id, err := params.Int("id")
+		if err != nil {
+			return nil, err
+		}
+		_, detail, err := getPipelineHandler(holder.client, ctx, nil, GetPipelineInput{
+			ProjectID:  params["project"],
+			PipelineID: id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, jobs, err := getJobsHandler(holder.client, ctx, nil, GetJobsInput{
+			ProjectID:  params["project"],
+			PipelineID: id,
+			PerPage:    resourceJobsPerPage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return registry.JSONResource(req.Params.URI, PipelineResource{Pipeline: detail, Jobs: jobs.Jobs})
+	}, registry.WithSubscription(func(ctx context.Context, params registry.ResourceParams) (string, error) {
+		return pipelineVersion(holder.client, params)
+	}))
+
+	registry.RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "job_log",
+		Title:       "GitLab Job Log",
+		Description: "GitLab CI/CD ジョブのログ",
+		MIMEType:    "text/plain",
+		URITemplate: JobLogURITemplate,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params registry.ResourceParams) (*mcp.ReadResourceResult, error) {
+		id, err := params.Int("id")
+		if err != nil {
+			return nil, err
+		}
+		log, err := holder.client.GetJobTrace(params["project"], id)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: req.Params.URI, MIMEType: "text/plain", Text: log}},
+		}, nil
+	}, registry.WithSubscription(func(ctx context.Context, params registry.ResourceParams) (string, error) {
+		return jobVersion(holder.client, params)
+	}))
+}
+
+// pipelineVersion は購読中のパイプラインの変更を検出するためのバージョン（updated_at と状態）を返す
+func pipelineVersion(client *gitlab.Client, params registry.ResourceParams) (string, error) {
+	id, err := params.Int("id")
+	if err != nil {
+		return "", err
+	}
+	pipeline, err := client.GetPipeline(params["project"], id)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v|%s", pipeline.UpdatedAt, pipeline.Status), nil
+}
+
+// jobVersion は購読中のジョブログの変更を検出するためのバージョン（ジョブの状態と終了時刻）を返す
+// 実行中のログの追記ごとには通知せず、ジョブの状態が変わったときに通知する
+func jobVersion(client *gitlab.Client, params registry.ResourceParams) (string, error) {
+	id, err := params.Int("id")
+	if err != nil {
+		return "", err
+	}
+	job, err := client.GetJob(params["project"], id)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s|%v", job.Status, job.FinishedAt), nil
+}
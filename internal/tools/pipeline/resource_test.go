@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineVersion(t *testing.T) {
+	status := "running"
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipelines/100", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":         100,
+			"status":     status,
+			"updated_at": "2026-10-01T10:00:00Z",
+		})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	params := registry.ResourceParams{"project": "test-project", "id": "100"}
+	running, err := pipelineVersion(client, params)
+	require.NoError(t, err)
+
+	status = "failed"
+	failed, err := pipelineVersion(client, params)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, running, failed)
+}
+
+func TestJobVersion(t *testing.T) {
+	job := map[string]any{"id": 7, "status": "running"}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/jobs/7", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(job)
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	params := registry.ResourceParams{"project": "test-project", "id": "7"}
+	running, err := jobVersion(client, params)
+	require.NoError(t, err)
+
+	again, err := jobVersion(client, params)
+	require.NoError(t, err)
+	assert.Equal(t, running, again)
+
+	job["status"] = "success"
+	job["finished_at"] = "2026-10-01T10:05:00Z"
+	finished, err := jobVersion(client, params)
+	require.NoError(t, err)
+	assert.NotEqual(t, running, finished)
+}
@@ -0,0 +1,459 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ListPipelineSchedulesInput は list_pipeline_schedules の入力パラメータ
+type ListPipelineSchedulesInput struct {
+	ProjectID string  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Scope     *string `json:"scope,omitempty" jsonschema:"enum:active,enum:inactive,description:Schedule scope filter"`
+	Page      int     `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage   int     `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
+}
+
+// ScheduleVariableInfo はパイプラインスケジュール変数の情報
+type ScheduleVariableInfo struct {
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	VariableType string `json:"variable_type,omitempty"`
+}
+
+// LastPipelineInfo はスケジュールが最後に実行したパイプラインの情報
+type LastPipelineInfo struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+	Ref    string `json:"ref"`
+	SHA    string `json:"sha"`
+	WebURL string `json:"web_url,omitempty"`
+}
+
+// PipelineScheduleInfo はパイプラインスケジュール情報
+type PipelineScheduleInfo struct {
+	ID           int64                  `json:"id"`
+	Description  string                 `json:"description"`
+	Ref          string                 `json:"ref"`
+	Cron         string                 `json:"cron"`
+	CronTimezone string                 `json:"cron_timezone"`
+	Active       bool                   `json:"active"`
+	NextRunAt    string                 `json:"next_run_at,omitempty"`
+	OwnerName    string                 `json:"owner_name,omitempty"`
+	LastPipeline *LastPipelineInfo      `json:"last_pipeline,omitempty"`
+	Variables    []ScheduleVariableInfo `json:"variables,omitempty"`
+}
+
+// ListPipelineSchedulesOutput は list_pipeline_schedules の出力
+type ListPipelineSchedulesOutput struct {
+	Schedules []PipelineScheduleInfo `json:"schedules"`
+}
+
+// GetPipelineScheduleInput は get_pipeline_schedule の入力パラメータ
+type GetPipelineScheduleInput struct {
+	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	ScheduleID int    `json:"schedule_id" jsonschema:"minimum:1,description:Pipeline schedule ID"`
+}
+
+// GetPipelineScheduleOutput は get_pipeline_schedule の出力
+type GetPipelineScheduleOutput = PipelineScheduleInfo
+
+// ScheduleVariableInput はパイプラインスケジュール変数の入力
+type ScheduleVariableInput struct {
+	Key          string  `json:"key" jsonschema:"description:Variable key"`
+	Value        string  `json:"value" jsonschema:"description:Variable value"`
+	VariableType *string `json:"variable_type,omitempty" jsonschema:"enum:env_var,enum:file,description:Variable type (default: env_var)"`
+}
+
+// CreatePipelineScheduleInput は create_pipeline_schedule の入力パラメータ
+type CreatePipelineScheduleInput struct {
+	ProjectID    string                  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Description  string                  `json:"description" jsonschema:"description:Schedule description"`
+	Ref          string                  `json:"ref" jsonschema:"description:Branch or tag name to run the pipeline for"`
+	Cron         string                  `json:"cron" jsonschema:"description:Cron expression (e.g. 0 2 * * *)"`
+	CronTimezone *string                 `json:"cron_timezone,omitempty" jsonschema:"description:Timezone for the cron expression (default: UTC)"`
+	Active       *bool                   `json:"active,omitempty" jsonschema:"description:Whether the schedule is active (default: true)"`
+	Variables    []ScheduleVariableInput `json:"variables,omitempty" jsonschema:"description:Variables passed to scheduled pipelines"`
+	registry.DryRunOption
+}
+
+// CreatePipelineScheduleOutput は create_pipeline_schedule の出力
+type CreatePipelineScheduleOutput = PipelineScheduleInfo
+
+// UpdatePipelineScheduleInput は update_pipeline_schedule の入力パラメータ
+type UpdatePipelineScheduleInput struct {
+	ProjectID    string  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	ScheduleID   int     `json:"schedule_id" jsonschema:"minimum:1,description:Pipeline schedule ID"`
+	Description  *string `json:"description,omitempty" jsonschema:"description:New description"`
+	Ref          *string `json:"ref,omitempty" jsonschema:"description:New branch or tag name"`
+	Cron         *string `json:"cron,omitempty" jsonschema:"description:New cron expression"`
+	CronTimezone *string `json:"cron_timezone,omitempty" jsonschema:"description:New timezone for the cron expression"`
+	Active       *bool   `json:"active,omitempty" jsonschema:"description:Activate or deactivate the schedule"`
+	registry.DryRunOption
+}
+
+// UpdatePipelineScheduleOutput は update_pipeline_schedule の出力
+type UpdatePipelineScheduleOutput = PipelineScheduleInfo
+
+// TakePipelineScheduleOwnershipInput は take_pipeline_schedule_ownership の入力パラメータ
+type TakePipelineScheduleOwnershipInput struct {
+	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	ScheduleID int    `json:"schedule_id" jsonschema:"minimum:1,description:Pipeline schedule ID"`
+	registry.DryRunOption
+}
+
+// TakePipelineScheduleOwnershipOutput は take_pipeline_schedule_ownership の出力
+type TakePipelineScheduleOwnershipOutput = PipelineScheduleInfo
+
+// RunPipelineScheduleInput は run_pipeline_schedule の入力パラメータ
+type RunPipelineScheduleInput struct {
+	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	ScheduleID int    `json:"schedule_id" jsonschema:"minimum:1,description:Pipeline schedule ID"`
+	registry.DryRunOption
+}
+
+// RunPipelineScheduleOutput は run_pipeline_schedule の出力
+type RunPipelineScheduleOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// DeletePipelineScheduleInput は delete_pipeline_schedule の入力パラメータ
+type DeletePipelineScheduleInput struct {
+	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	ScheduleID int    `json:"schedule_id" jsonschema:"minimum:1,description:Pipeline schedule ID"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// DeletePipelineScheduleOutput は delete_pipeline_schedule の出力
+type DeletePipelineScheduleOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// SetPipelineScheduleVariableInput は create/update_pipeline_schedule_variable の入力パラメータ
+type SetPipelineScheduleVariableInput struct {
+	ProjectID    string  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	ScheduleID   int     `json:"schedule_id" jsonschema:"minimum:1,description:Pipeline schedule ID"`
+	Key          string  `json:"key" jsonschema:"description:Variable key"`
+	Value        string  `json:"value" jsonschema:"description:Variable value"`
+	VariableType *string `json:"variable_type,omitempty" jsonschema:"enum:env_var,enum:file,description:Variable type"`
+	registry.DryRunOption
+}
+
+// SetPipelineScheduleVariableOutput は create/update_pipeline_schedule_variable の出力
+type SetPipelineScheduleVariableOutput = ScheduleVariableInfo
+
+// DeletePipelineScheduleVariableInput は delete_pipeline_schedule_variable の入力パラメータ
+type DeletePipelineScheduleVariableInput struct {
+	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	ScheduleID int    `json:"schedule_id" jsonschema:"minimum:1,description:Pipeline schedule ID"`
+	Key        string `json:"key" jsonschema:"description:Variable key to delete"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// DeletePipelineScheduleVariableOutput は delete_pipeline_schedule_variable の出力
+type DeletePipelineScheduleVariableOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// registerScheduleTools はパイプラインスケジュール関連ツールを登録する
+func registerScheduleTools(reg *registry.Registry) {
+	registry.RegisterTool(reg, "list_pipeline_schedules",
+		"GitLab プロジェクトのパイプラインスケジュール一覧を取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input ListPipelineSchedulesInput) (*mcp.CallToolResult, ListPipelineSchedulesOutput, error) {
+			return listPipelineSchedulesHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "get_pipeline_schedule",
+		"GitLab パイプラインスケジュールの詳細情報（変数、最終実行パイプラインを含む）を取得します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input GetPipelineScheduleInput) (*mcp.CallToolResult, GetPipelineScheduleOutput, error) {
+			return getPipelineScheduleHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "create_pipeline_schedule",
+		"GitLab で新しいパイプラインスケジュールを作成します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input CreatePipelineScheduleInput) (*mcp.CallToolResult, CreatePipelineScheduleOutput, error) {
+			return createPipelineScheduleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "update_pipeline_schedule",
+		"GitLab パイプラインスケジュールを更新します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input UpdatePipelineScheduleInput) (*mcp.CallToolResult, UpdatePipelineScheduleOutput, error) {
+			return updatePipelineScheduleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "take_pipeline_schedule_ownership",
+		"GitLab パイプラインスケジュールの所有者を自分に変更します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input TakePipelineScheduleOwnershipInput) (*mcp.CallToolResult, TakePipelineScheduleOwnershipOutput, error) {
+			return takePipelineScheduleOwnershipHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "run_pipeline_schedule",
+		"GitLab パイプラインスケジュールを即時実行します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input RunPipelineScheduleInput) (*mcp.CallToolResult, RunPipelineScheduleOutput, error) {
+			return runPipelineScheduleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "delete_pipeline_schedule",
+		"GitLab パイプラインスケジュールを削除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input DeletePipelineScheduleInput) (*mcp.CallToolResult, DeletePipelineScheduleOutput, error) {
+			return deletePipelineScheduleHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeletePipelineScheduleInput) (string, error) {
+			return deletePipelineScheduleSummary(holder.client, input)
+		}))
+
+	registry.RegisterTool(reg, "create_pipeline_schedule_variable",
+		"GitLab パイプラインスケジュールに変数を追加します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input SetPipelineScheduleVariableInput) (*mcp.CallToolResult, SetPipelineScheduleVariableOutput, error) {
+			return createPipelineScheduleVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "update_pipeline_schedule_variable",
+		"GitLab パイプラインスケジュールの変数を更新します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input SetPipelineScheduleVariableInput) (*mcp.CallToolResult, SetPipelineScheduleVariableOutput, error) {
+			return updatePipelineScheduleVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "delete_pipeline_schedule_variable",
+		"GitLab パイプラインスケジュールの変数を削除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input DeletePipelineScheduleVariableInput) (*mcp.CallToolResult, DeletePipelineScheduleVariableOutput, error) {
+			return deletePipelineScheduleVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeletePipelineScheduleVariableInput) (string, error) {
+			return deletePipelineScheduleVariableSummary(holder.client, input)
+		}))
+}
+
+// toPipelineScheduleInfo は GitLab のスケジュールを出力用の構造体に変換する
+func toPipelineScheduleInfo(s *gogitlab.PipelineSchedule) PipelineScheduleInfo {
+	nextRunAt := ""
+	if s.NextRunAt != nil {
+		nextRunAt = s.NextRunAt.String()
+	}
+	ownerName := ""
+	if s.Owner != nil {
+		ownerName = s.Owner.Username
+	}
+
+	info := PipelineScheduleInfo{
+		ID:           s.ID,
+		Description:  s.Description,
+		Ref:          s.Ref,
+		Cron:         s.Cron,
+		CronTimezone: s.CronTimezone,
+		Active:       s.Active,
+		NextRunAt:    nextRunAt,
+		OwnerName:    ownerName,
+	}
+
+	if s.LastPipeline != nil {
+		info.LastPipeline = &LastPipelineInfo{
+			ID:     s.LastPipeline.ID,
+			Status: s.LastPipeline.Status,
+			Ref:    s.LastPipeline.Ref,
+			SHA:    s.LastPipeline.SHA,
+			WebURL: s.LastPipeline.WebURL,
+		}
+	}
+
+	for _, v := range s.Variables {
+		info.Variables = append(info.Variables, toScheduleVariableInfo(v))
+	}
+
+	return info
+}
+
+// toScheduleVariableInfo は GitLab のパイプライン変数を出力用の構造体に変換する
+func toScheduleVariableInfo(v *gogitlab.PipelineVariable) ScheduleVariableInfo {
+	return ScheduleVariableInfo{
+		Key:          v.Key,
+		Value:        v.Value,
+		VariableType: string(v.VariableType),
+	}
+}
+
+func listPipelineSchedulesHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListPipelineSchedulesInput) (*mcp.CallToolResult, ListPipelineSchedulesOutput, error) {
+	opts := &gitlab.ListPipelineSchedulesOptions{
+		Scope:   input.Scope,
+		Page:    input.Page,
+		PerPage: input.PerPage,
+	}
+
+	schedules, err := client.ListPipelineSchedules(input.ProjectID, opts)
+	if err != nil {
+		return nil, ListPipelineSchedulesOutput{}, err
+	}
+
+	infos := make([]PipelineScheduleInfo, len(schedules))
+	for i, s := range schedules {
+		infos[i] = toPipelineScheduleInfo(s)
+	}
+
+	return nil, ListPipelineSchedulesOutput{Schedules: infos}, nil
+}
+
+func getPipelineScheduleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input GetPipelineScheduleInput) (*mcp.CallToolResult, GetPipelineScheduleOutput, error) {
+	s, err := client.GetPipelineSchedule(input.ProjectID, input.ScheduleID)
+	if err != nil {
+		return nil, GetPipelineScheduleOutput{}, err
+	}
+
+	return nil, toPipelineScheduleInfo(s), nil
+}
+
+func createPipelineScheduleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CreatePipelineScheduleInput) (*mcp.CallToolResult, CreatePipelineScheduleOutput, error) {
+	opts := &gitlab.CreatePipelineScheduleOptions{
+		Description:  input.Description,
+		Ref:          input.Ref,
+		Cron:         input.Cron,
+		CronTimezone: input.CronTimezone,
+		Active:       input.Active,
+	}
+
+	s, err := client.CreatePipelineSchedule(input.ProjectID, opts)
+	if err != nil {
+		return nil, CreatePipelineScheduleOutput{}, err
+	}
+
+	info := toPipelineScheduleInfo(s)
+
+	// スケジュール変数は作成後に個別に追加する
+	for _, v := range input.Variables {
+		variable, err := client.CreatePipelineScheduleVariable(input.ProjectID, int(s.ID), &gitlab.PipelineScheduleVariableOptions{
+			Key:          v.Key,
+			Value:        v.Value,
+			VariableType: v.VariableType,
+		})
+		if err != nil {
+			return nil, CreatePipelineScheduleOutput{}, rollbackPipelineSchedule(client, input.ProjectID, int(s.ID), v.Key, err)
+		}
+		info.Variables = append(info.Variables, toScheduleVariableInfo(variable))
+	}
+
+	return nil, info, nil
+}
+
+// rollbackPipelineSchedule は変数を追加できなかったスケジュールを削除し、変数の追加のエラーを返す
+// 削除にも失敗した場合は、残ったスケジュールの ID をエラーメッセージに含める
+func rollbackPipelineSchedule(client *gitlab.Client, projectID string, scheduleID int, key string, cause error) error {
+	mcpErr := *gitlab.FromError(cause)
+	if err := client.DeletePipelineSchedule(projectID, scheduleID); err != nil {
+		mcpErr.Message = gitlab.Msg(gitlab.MsgScheduleOrphaned, key, scheduleID, mcpErr.Message)
+	} else {
+		mcpErr.Message = gitlab.Msg(gitlab.MsgScheduleRolledBack, key, mcpErr.Message)
+	}
+	return &mcpErr
+}
+
+func updatePipelineScheduleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input UpdatePipelineScheduleInput) (*mcp.CallToolResult, UpdatePipelineScheduleOutput, error) {
+	opts := &gitlab.UpdatePipelineScheduleOptions{
+		Description:  input.Description,
+		Ref:          input.Ref,
+		Cron:         input.Cron,
+		CronTimezone: input.CronTimezone,
+		Active:       input.Active,
+	}
+
+	s, err := client.UpdatePipelineSchedule(input.ProjectID, input.ScheduleID, opts)
+	if err != nil {
+		return nil, UpdatePipelineScheduleOutput{}, err
+	}
+
+	return nil, toPipelineScheduleInfo(s), nil
+}
+
+func takePipelineScheduleOwnershipHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input TakePipelineScheduleOwnershipInput) (*mcp.CallToolResult, TakePipelineScheduleOwnershipOutput, error) {
+	s, err := client.TakeOwnershipOfPipelineSchedule(input.ProjectID, input.ScheduleID)
+	if err != nil {
+		return nil, TakePipelineScheduleOwnershipOutput{}, err
+	}
+
+	return nil, toPipelineScheduleInfo(s), nil
+}
+
+func runPipelineScheduleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input RunPipelineScheduleInput) (*mcp.CallToolResult, RunPipelineScheduleOutput, error) {
+	err := client.RunPipelineSchedule(input.ProjectID, input.ScheduleID)
+	if err != nil {
+		return nil, RunPipelineScheduleOutput{}, err
+	}
+
+	return nil, RunPipelineScheduleOutput{
+		Success: true,
+		Message: "Pipeline schedule triggered successfully",
+	}, nil
+}
+
+// deletePipelineScheduleSummary は確認のために削除するスケジュールを要約する
+func deletePipelineScheduleSummary(client *gitlab.Client, input DeletePipelineScheduleInput) (string, error) {
+	s, err := client.GetPipelineSchedule(input.ProjectID, input.ScheduleID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("delete pipeline schedule %d '%s' on %s (%s)", s.ID, s.Description, s.Ref, s.Cron), nil
+}
+
+func deletePipelineScheduleHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeletePipelineScheduleInput) (*mcp.CallToolResult, DeletePipelineScheduleOutput, error) {
+	err := client.DeletePipelineSchedule(input.ProjectID, input.ScheduleID)
+	if err != nil {
+		return nil, DeletePipelineScheduleOutput{}, err
+	}
+
+	return nil, DeletePipelineScheduleOutput{
+		Success: true,
+		Message: "Pipeline schedule deleted successfully",
+	}, nil
+}
+
+func createPipelineScheduleVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input SetPipelineScheduleVariableInput) (*mcp.CallToolResult, SetPipelineScheduleVariableOutput, error) {
+	v, err := client.CreatePipelineScheduleVariable(input.ProjectID, input.ScheduleID, &gitlab.PipelineScheduleVariableOptions{
+		Key:          input.Key,
+		Value:        input.Value,
+		VariableType: input.VariableType,
+	})
+	if err != nil {
+		return nil, SetPipelineScheduleVariableOutput{}, err
+	}
+
+	return nil, toScheduleVariableInfo(v), nil
+}
+
+func updatePipelineScheduleVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input SetPipelineScheduleVariableInput) (*mcp.CallToolResult, SetPipelineScheduleVariableOutput, error) {
+	v, err := client.UpdatePipelineScheduleVariable(input.ProjectID, input.ScheduleID, &gitlab.PipelineScheduleVariableOptions{
+		Key:          input.Key,
+		Value:        input.Value,
+		VariableType: input.VariableType,
+	})
+	if err != nil {
+		return nil, SetPipelineScheduleVariableOutput{}, err
+	}
+
+	return nil, toScheduleVariableInfo(v), nil
+}
+
+// deletePipelineScheduleVariableSummary は確認のためにスケジュールから削除する変数を要約する（値は示さない）
+func deletePipelineScheduleVariableSummary(client *gitlab.Client, input DeletePipelineScheduleVariableInput) (string, error) {
+	s, err := client.GetPipelineSchedule(input.ProjectID, input.ScheduleID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("delete variable %s from pipeline schedule %d '%s'", input.Key, s.ID, s.Description), nil
+}
+
+func deletePipelineScheduleVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeletePipelineScheduleVariableInput) (*mcp.CallToolResult, DeletePipelineScheduleVariableOutput, error) {
+	err := client.DeletePipelineScheduleVariable(input.ProjectID, input.ScheduleID, input.Key)
+	if err != nil {
+		return nil, DeletePipelineScheduleVariableOutput{}, err
+	}
+
+	return nil, DeletePipelineScheduleVariableOutput{
+		Success: true,
+		Message: "Pipeline schedule variable deleted successfully",
+	}, nil
+}
@@ -0,0 +1,417 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPipelineSchedulesTool(t *testing.T) {
+	t.Run("returns schedules list successfully", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules", r.URL.Path)
+			assert.Equal(t, "GET", r.Method)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]map[string]any{
+				{
+					"id":            1,
+					"description":   "Nightly build",
+					"ref":           "main",
+					"cron":          "0 2 * * *",
+					"cron_timezone": "UTC",
+					"active":        true,
+					"next_run_at":   "2024-01-02T02:00:00Z",
+					"owner":         map[string]any{"id": 1, "username": "scheduler"},
+				},
+			})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("list_pipeline_schedules"))
+
+		input := ListPipelineSchedulesInput{ProjectID: "test-project"}
+
+		ctx := context.Background()
+		_, output, err := listPipelineSchedulesHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		require.Len(t, output.Schedules, 1)
+		assert.Equal(t, int64(1), output.Schedules[0].ID)
+		assert.Equal(t, "Nightly build", output.Schedules[0].Description)
+		assert.Equal(t, "scheduler", output.Schedules[0].OwnerName)
+		assert.NotEmpty(t, output.Schedules[0].NextRunAt)
+	})
+}
+
+func TestGetPipelineScheduleTool(t *testing.T) {
+	t.Run("returns schedule with variables and last pipeline", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/1", r.URL.Path)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":            1,
+				"description":   "Nightly build",
+				"ref":           "main",
+				"cron":          "0 2 * * *",
+				"cron_timezone": "UTC",
+				"active":        true,
+				"last_pipeline": map[string]any{"id": 100, "sha": "abc123", "ref": "main", "status": "failed"},
+				"variables": []map[string]any{
+					{"key": "DEPLOY_ENV", "value": "staging", "variable_type": "env_var"},
+				},
+			})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("get_pipeline_schedule"))
+
+		input := GetPipelineScheduleInput{ProjectID: "test-project", ScheduleID: 1}
+
+		ctx := context.Background()
+		_, output, err := getPipelineScheduleHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		require.NotNil(t, output.LastPipeline)
+		assert.Equal(t, int64(100), output.LastPipeline.ID)
+		assert.Equal(t, "failed", output.LastPipeline.Status)
+		require.Len(t, output.Variables, 1)
+		assert.Equal(t, "DEPLOY_ENV", output.Variables[0].Key)
+		assert.Equal(t, "env_var", output.Variables[0].VariableType)
+	})
+
+	t.Run("returns error for non-existent schedule", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "404 Not found"})
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := GetPipelineScheduleInput{ProjectID: "test-project", ScheduleID: 999}
+
+		ctx := context.Background()
+		_, _, err := getPipelineScheduleHandler(client, ctx, nil, input)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCreatePipelineScheduleTool(t *testing.T) {
+	t.Run("creates schedule and its variables", func(t *testing.T) {
+		var createdVariables []string
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules" && r.Method == "POST":
+				json.NewEncoder(w).Encode(map[string]any{
+					"id":          5,
+					"description": "Nightly build",
+					"ref":         "main",
+					"cron":        "0 2 * * *",
+					"active":      true,
+				})
+			case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/5/variables" && r.Method == "POST":
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				createdVariables = append(createdVariables, body["key"].(string))
+				json.NewEncoder(w).Encode(map[string]any{
+					"key":           body["key"],
+					"value":         body["value"],
+					"variable_type": "env_var",
+				})
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("create_pipeline_schedule"))
+
+		input := CreatePipelineScheduleInput{
+			ProjectID:   "test-project",
+			Description: "Nightly build",
+			Ref:         "main",
+			Cron:        "0 2 * * *",
+			Variables: []ScheduleVariableInput{
+				{Key: "DEPLOY_ENV", Value: "staging"},
+				{Key: "RUN_E2E", Value: "true"},
+			},
+		}
+
+		ctx := context.Background()
+		_, output, err := createPipelineScheduleHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), output.ID)
+		assert.Equal(t, []string{"DEPLOY_ENV", "RUN_E2E"}, createdVariables)
+		assert.Len(t, output.Variables, 2)
+	})
+
+	for _, tt := range []struct {
+		name         string
+		deleteStatus int
+		wantMessage  string
+	}{
+		{name: "deletes the schedule when a variable fails", deleteStatus: http.StatusNoContent, wantMessage: "deleted again"},
+		{name: "reports the schedule ID when the deletion fails", deleteStatus: http.StatusForbidden, wantMessage: "pipeline schedule 5 failed"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules" && r.Method == "POST":
+					json.NewEncoder(w).Encode(map[string]any{"id": 5, "description": "Nightly build"})
+				case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/5/variables" && r.Method == "POST":
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(map[string]any{"message": map[string][]string{"key": {"is invalid"}}})
+				case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/5" && r.Method == "DELETE":
+					deleted = true
+					w.WriteHeader(tt.deleteStatus)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}
+
+			client, _, cleanup := setupTestServer(t, handler)
+			defer cleanup()
+
+			input := CreatePipelineScheduleInput{
+				ProjectID:   "test-project",
+				Description: "Nightly build",
+				Ref:         "main",
+				Cron:        "0 2 * * *",
+				Variables:   []ScheduleVariableInput{{Key: "BAD KEY", Value: "x"}},
+			}
+
+			_, _, err := createPipelineScheduleHandler(client, context.Background(), nil, input)
+
+			require.ErrorIs(t, err, gitlab.ErrBadRequest)
+			assert.True(t, deleted)
+			assert.Contains(t, err.Error(), "BAD KEY")
+			assert.Contains(t, err.Error(), tt.wantMessage)
+		})
+	}
+}
+
+func TestUpdatePipelineScheduleTool(t *testing.T) {
+	t.Run("updates schedule successfully", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/1", r.URL.Path)
+			assert.Equal(t, "PUT", r.Method)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":     1,
+				"cron":   "0 4 * * *",
+				"active": false,
+			})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("update_pipeline_schedule"))
+
+		cron := "0 4 * * *"
+		active := false
+		input := UpdatePipelineScheduleInput{
+			ProjectID:  "test-project",
+			ScheduleID: 1,
+			Cron:       &cron,
+			Active:     &active,
+		}
+
+		ctx := context.Background()
+		_, output, err := updatePipelineScheduleHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "0 4 * * *", output.Cron)
+		assert.False(t, output.Active)
+	})
+}
+
+func TestTakePipelineScheduleOwnershipTool(t *testing.T) {
+	t.Run("takes ownership successfully", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/1/take_ownership", r.URL.Path)
+			assert.Equal(t, "POST", r.Method)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":    1,
+				"owner": map[string]any{"id": 2, "username": "me"},
+			})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("take_pipeline_schedule_ownership"))
+
+		input := TakePipelineScheduleOwnershipInput{ProjectID: "test-project", ScheduleID: 1}
+
+		ctx := context.Background()
+		_, output, err := takePipelineScheduleOwnershipHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "me", output.OwnerName)
+	})
+}
+
+func TestRunPipelineScheduleTool(t *testing.T) {
+	t.Run("runs schedule successfully", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/1/play", r.URL.Path)
+			assert.Equal(t, "POST", r.Method)
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]string{"message": "201 Created"})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("run_pipeline_schedule"))
+
+		input := RunPipelineScheduleInput{ProjectID: "test-project", ScheduleID: 1}
+
+		ctx := context.Background()
+		_, output, err := runPipelineScheduleHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.Success)
+	})
+}
+
+func TestDeletePipelineScheduleTool(t *testing.T) {
+	t.Run("deletes schedule successfully", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/1", r.URL.Path)
+			assert.Equal(t, "DELETE", r.Method)
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("delete_pipeline_schedule"))
+
+		input := DeletePipelineScheduleInput{ProjectID: "test-project", ScheduleID: 1}
+
+		ctx := context.Background()
+		_, output, err := deletePipelineScheduleHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.Success)
+	})
+}
+
+func TestPipelineScheduleVariableTools(t *testing.T) {
+	t.Run("creates, updates and deletes variables", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/1/variables" && r.Method == "POST":
+				json.NewEncoder(w).Encode(map[string]any{"key": "TARGET", "value": "a", "variable_type": "file"})
+			case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/1/variables/TARGET" && r.Method == "PUT":
+				json.NewEncoder(w).Encode(map[string]any{"key": "TARGET", "value": "b", "variable_type": "file"})
+			case r.URL.Path == "/api/v4/projects/test-project/pipeline_schedules/1/variables/TARGET" && r.Method == "DELETE":
+				json.NewEncoder(w).Encode(map[string]any{"key": "TARGET", "value": "b"})
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("create_pipeline_schedule_variable"))
+		assert.True(t, reg.IsRegistered("update_pipeline_schedule_variable"))
+		assert.True(t, reg.IsRegistered("delete_pipeline_schedule_variable"))
+
+		ctx := context.Background()
+		variableType := "file"
+		_, created, err := createPipelineScheduleVariableHandler(client, ctx, nil, SetPipelineScheduleVariableInput{
+			ProjectID:    "test-project",
+			ScheduleID:   1,
+			Key:          "TARGET",
+			Value:        "a",
+			VariableType: &variableType,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "a", created.Value)
+		assert.Equal(t, "file", created.VariableType)
+
+		_, updated, err := updatePipelineScheduleVariableHandler(client, ctx, nil, SetPipelineScheduleVariableInput{
+			ProjectID:  "test-project",
+			ScheduleID: 1,
+			Key:        "TARGET",
+			Value:      "b",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "b", updated.Value)
+
+		_, deleted, err := deletePipelineScheduleVariableHandler(client, ctx, nil, DeletePipelineScheduleVariableInput{
+			ProjectID:  "test-project",
+			ScheduleID: 1,
+			Key:        "TARGET",
+		})
+		require.NoError(t, err)
+		assert.True(t, deleted.Success)
+	})
+}
+
+func TestDeletePipelineScheduleSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/5", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 5, "description": "Nightly", "ref": "main", "cron": "0 1 * * *"})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := deletePipelineScheduleSummary(client, DeletePipelineScheduleInput{ProjectID: "test-project", ScheduleID: 5})
+
+	require.NoError(t, err)
+	assert.Equal(t, "delete pipeline schedule 5 'Nightly' on main (0 1 * * *)", summary)
+}
+
+func TestDeletePipelineScheduleVariableSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipeline_schedules/5", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 5, "description": "Nightly"})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := deletePipelineScheduleVariableSummary(client, DeletePipelineScheduleVariableInput{ProjectID: "test-project", ScheduleID: 5, Key: "DEPLOY_ENV"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "delete variable DEPLOY_ENV from pipeline schedule 5 'Nightly'", summary)
+}
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/kqns91/gitlab-mcp/internal/gitlab"
 	"github.com/kqns91/gitlab-mcp/internal/registry"
@@ -11,7 +12,7 @@ import (
 // ListPipelinesInput は list_merge_request_pipelines の入力パラメータ
 type ListPipelinesInput struct {
 	ProjectID       string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"description:Merge Request IID"`
+	MergeRequestIID int    `json:"merge_request_iid" jsonschema:"minimum:1,description:Merge Request IID"`
 }
 
 // PipelineInfo はパイプライン情報
@@ -32,17 +33,20 @@ type ListPipelinesOutput struct {
 // GetJobsInput は get_pipeline_jobs の入力パラメータ
 type GetJobsInput struct {
 	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	PipelineID int    `json:"pipeline_id" jsonschema:"description:Pipeline ID"`
-	Page       int    `json:"page,omitempty" jsonschema:"description:Page number (default: 1)"`
-	PerPage    int    `json:"per_page,omitempty" jsonschema:"description:Number of items per page (default: 100, max: 100)"`
+	PipelineID int    `json:"pipeline_id" jsonschema:"minimum:1,description:Pipeline ID"`
+	Page       int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage    int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
 }
 
 // JobInfo はジョブ情報
 type JobInfo struct {
-	ID     int64  `json:"id"`
-	Name   string `json:"name"`
-	Stage  string `json:"stage"`
-	Status string `json:"status"`
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Stage        string `json:"stage"`
+	Status       string `json:"status"`
+	When         string `json:"when,omitempty"`
+	AllowFailure bool   `json:"allow_failure"`
+	Manual       bool   `json:"manual"`
 }
 
 // GetJobsOutput は get_pipeline_jobs の出力
@@ -57,8 +61,8 @@ type ListProjectPipelinesInput struct {
 	Ref       *string `json:"ref,omitempty" jsonschema:"description:Branch or tag name filter"`
 	SHA       *string `json:"sha,omitempty" jsonschema:"description:Commit SHA filter"`
 	Source    *string `json:"source,omitempty" jsonschema:"description:Pipeline source filter (e.g. push, web, trigger)"`
-	Page      int     `json:"page,omitempty" jsonschema:"description:Page number (default: 1)"`
-	PerPage   int     `json:"per_page,omitempty" jsonschema:"description:Number of items per page (default: 100, max: 100)"`
+	Page      int     `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage   int     `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
 }
 
 // ListProjectPipelinesOutput は list_project_pipelines の出力
@@ -69,7 +73,7 @@ type ListProjectPipelinesOutput struct {
 // GetPipelineInput は get_pipeline の入力パラメータ
 type GetPipelineInput struct {
 	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	PipelineID int    `json:"pipeline_id" jsonschema:"description:Pipeline ID"`
+	PipelineID int    `json:"pipeline_id" jsonschema:"minimum:1,description:Pipeline ID"`
 }
 
 // PipelineDetail はパイプライン詳細情報
@@ -94,6 +98,7 @@ type CreatePipelineInput struct {
 	ProjectID string                  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
 	Ref       string                  `json:"ref" jsonschema:"description:Branch or tag name to create the pipeline for"`
 	Variables []PipelineVariableInput `json:"variables,omitempty" jsonschema:"description:Pipeline variables"`
+	registry.DryRunOption
 }
 
 // PipelineVariableInput はパイプライン変数の入力
@@ -112,7 +117,8 @@ type CreatePipelineOutput struct {
 // RetryPipelineInput は retry_pipeline の入力パラメータ
 type RetryPipelineInput struct {
 	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	PipelineID int    `json:"pipeline_id" jsonschema:"description:Pipeline ID"`
+	PipelineID int    `json:"pipeline_id" jsonschema:"minimum:1,description:Pipeline ID"`
+	registry.DryRunOption
 }
 
 // RetryPipelineOutput は retry_pipeline の出力
@@ -125,7 +131,9 @@ type RetryPipelineOutput struct {
 // CancelPipelineInput は cancel_pipeline の入力パラメータ
 type CancelPipelineInput struct {
 	ProjectID  string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	PipelineID int    `json:"pipeline_id" jsonschema:"description:Pipeline ID"`
+	PipelineID int    `json:"pipeline_id" jsonschema:"minimum:1,description:Pipeline ID"`
+	registry.Confirmation
+	registry.DryRunOption
 }
 
 // CancelPipelineOutput は cancel_pipeline の出力
@@ -138,7 +146,7 @@ type CancelPipelineOutput struct {
 // GetPipelineJobInput は get_pipeline_job の入力パラメータ
 type GetPipelineJobInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	JobID     int    `json:"job_id" jsonschema:"description:Job ID"`
+	JobID     int    `json:"job_id" jsonschema:"minimum:1,description:Job ID"`
 }
 
 // JobDetail はジョブ詳細情報
@@ -162,7 +170,7 @@ type GetPipelineJobOutput = JobDetail
 // GetJobLogInput は get_job_log の入力パラメータ
 type GetJobLogInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	JobID     int    `json:"job_id" jsonschema:"description:Job ID"`
+	JobID     int    `json:"job_id" jsonschema:"minimum:1,description:Job ID"`
 }
 
 // GetJobLogOutput は get_job_log の出力
@@ -173,7 +181,8 @@ type GetJobLogOutput struct {
 // RetryPipelineJobInput は retry_pipeline_job の入力パラメータ
 type RetryPipelineJobInput struct {
 	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
-	JobID     int    `json:"job_id" jsonschema:"description:Job ID"`
+	JobID     int    `json:"job_id" jsonschema:"minimum:1,description:Job ID"`
+	registry.DryRunOption
 }
 
 // RetryPipelineJobOutput は retry_pipeline_job の出力
@@ -184,6 +193,53 @@ type RetryPipelineJobOutput struct {
 	WebURL string `json:"web_url"`
 }
 
+// PlayJobInput は play_job の入力パラメータ
+type PlayJobInput struct {
+	ProjectID string                  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	JobID     int                     `json:"job_id" jsonschema:"minimum:1,description:Manual job ID"`
+	Variables []PipelineVariableInput `json:"variables,omitempty" jsonschema:"description:Job variables passed to the manual job"`
+	registry.DryRunOption
+}
+
+// PlayJobOutput は play_job の出力
+type PlayJobOutput struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	WebURL string `json:"web_url"`
+}
+
+// CancelJobInput は cancel_job の入力パラメータ
+type CancelJobInput struct {
+	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	JobID     int    `json:"job_id" jsonschema:"minimum:1,description:Job ID"`
+	registry.DryRunOption
+}
+
+// CancelJobOutput は cancel_job の出力
+type CancelJobOutput struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	WebURL string `json:"web_url"`
+}
+
+// EraseJobInput は erase_job の入力パラメータ
+type EraseJobInput struct {
+	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	JobID     int    `json:"job_id" jsonschema:"minimum:1,description:Job ID"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// EraseJobOutput は erase_job の出力
+type EraseJobOutput struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	ErasedAt string `json:"erased_at,omitempty"`
+}
+
 // clientHolder holds the GitLab client for handlers
 type clientHolder struct {
 	client *gitlab.Client
@@ -199,61 +255,102 @@ func Register(reg *registry.Registry, client *gitlab.Client) {
 		"GitLab Merge Request に関連するパイプライン一覧を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ListPipelinesInput) (*mcp.CallToolResult, ListPipelinesOutput, error) {
 			return listPipelinesHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "get_pipeline_jobs",
 		"GitLab パイプラインのジョブ一覧を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input GetJobsInput) (*mcp.CallToolResult, GetJobsOutput, error) {
 			return getJobsHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "list_project_pipelines",
 		"GitLab プロジェクトのパイプライン一覧を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input ListProjectPipelinesInput) (*mcp.CallToolResult, ListProjectPipelinesOutput, error) {
 			return listProjectPipelinesHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "get_pipeline",
 		"GitLab パイプラインの詳細情報を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input GetPipelineInput) (*mcp.CallToolResult, GetPipelineOutput, error) {
 			return getPipelineHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "create_pipeline",
 		"GitLab で新しいパイプラインを作成します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input CreatePipelineInput) (*mcp.CallToolResult, CreatePipelineOutput, error) {
-			return createPipelineHandler(holder.client, ctx, req, input)
+			return createPipelineHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "retry_pipeline",
 		"GitLab パイプラインの失敗したジョブを再試行します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input RetryPipelineInput) (*mcp.CallToolResult, RetryPipelineOutput, error) {
-			return retryPipelineHandler(holder.client, ctx, req, input)
+			return retryPipelineHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
 
 	registry.RegisterTool(reg, "cancel_pipeline",
 		"GitLab パイプラインをキャンセルします",
 		func(ctx context.Context, req *mcp.CallToolRequest, input CancelPipelineInput) (*mcp.CallToolResult, CancelPipelineOutput, error) {
-			return cancelPipelineHandler(holder.client, ctx, req, input)
-		})
+			return cancelPipelineHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithConfirmation(func(ctx context.Context, input CancelPipelineInput) (string, error) {
+			return cancelPipelineSummary(holder.client, input)
+		}))
 
 	registry.RegisterTool(reg, "get_pipeline_job",
 		"GitLab ジョブの詳細情報を取得します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input GetPipelineJobInput) (*mcp.CallToolResult, GetPipelineJobOutput, error) {
 			return getPipelineJobHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "get_job_log",
 		"GitLab ジョブのログを取得します（最大100KB）",
 		func(ctx context.Context, req *mcp.CallToolRequest, input GetJobLogInput) (*mcp.CallToolResult, GetJobLogOutput, error) {
 			return getJobLogHandler(holder.client, ctx, req, input)
-		})
+		}, registry.WithReadOnly())
 
 	registry.RegisterTool(reg, "retry_pipeline_job",
 		"GitLab ジョブを再試行します",
 		func(ctx context.Context, req *mcp.CallToolRequest, input RetryPipelineJobInput) (*mcp.CallToolResult, RetryPipelineJobOutput, error) {
-			return retryPipelineJobHandler(holder.client, ctx, req, input)
+			return retryPipelineJobHandler(holder.client.WithDryRun(ctx), ctx, req, input)
 		})
+
+	registry.RegisterTool(reg, "play_job",
+		"GitLab の手動ジョブ（when: manual）を実行します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input PlayJobInput) (*mcp.CallToolResult, PlayJobOutput, error) {
+			return playJobHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "cancel_job",
+		"GitLab ジョブをキャンセルします",
+		func(ctx context.Context, req *mcp.CallToolRequest, input CancelJobInput) (*mcp.CallToolResult, CancelJobOutput, error) {
+			return cancelJobHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		})
+
+	registry.RegisterTool(reg, "erase_job",
+		"GitLab ジョブのログとアーティファクトを削除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input EraseJobInput) (*mcp.CallToolResult, EraseJobOutput, error) {
+			return eraseJobHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input EraseJobInput) (string, error) {
+			return eraseJobSummary(holder.client, input)
+		}))
+
+	registerWaitTools(reg)
+	registerLintTools(reg)
+	registerScheduleTools(reg)
+	registerResources(reg)
+	registerPrompts(reg)
+}
+
+// jobWhen はジョブのステータスから when の値を推定する
+// GitLab の Jobs API は when を返さないため、手動・遅延ジョブのみ判別できる
+func jobWhen(status string) string {
+	switch status {
+	case "manual":
+		return "manual"
+	case "scheduled":
+		return "delayed"
+	default:
+		return ""
+	}
 }
 
 func listPipelinesHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input ListPipelinesInput) (*mcp.CallToolResult, ListPipelinesOutput, error) {
@@ -298,10 +395,13 @@ func getJobsHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToo
 	infos := make([]JobInfo, len(jobs))
 	for i, j := range jobs {
 		infos[i] = JobInfo{
-			ID:     int64(j.ID),
-			Name:   j.Name,
-			Stage:  j.Stage,
-			Status: j.Status,
+			ID:           int64(j.ID),
+			Name:         j.Name,
+			Stage:        j.Stage,
+			Status:       j.Status,
+			When:         jobWhen(j.Status),
+			AllowFailure: j.AllowFailure,
+			Manual:       j.Status == "manual",
 		}
 	}
 
@@ -414,6 +514,15 @@ func retryPipelineHandler(client *gitlab.Client, ctx context.Context, req *mcp.C
 	}, nil
 }
 
+// cancelPipelineSummary は確認のためにキャンセルするパイプラインを要約する
+func cancelPipelineSummary(client *gitlab.Client, input CancelPipelineInput) (string, error) {
+	p, err := client.GetPipeline(input.ProjectID, input.PipelineID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("cancel pipeline #%d on %s (%s)", p.ID, p.Ref, p.Status), nil
+}
+
 func cancelPipelineHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CancelPipelineInput) (*mcp.CallToolResult, CancelPipelineOutput, error) {
 	p, err := client.CancelPipeline(input.ProjectID, input.PipelineID)
 	if err != nil {
@@ -483,3 +592,67 @@ func retryPipelineJobHandler(client *gitlab.Client, ctx context.Context, req *mc
 		WebURL: j.WebURL,
 	}, nil
 }
+
+func playJobHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input PlayJobInput) (*mcp.CallToolResult, PlayJobOutput, error) {
+	vars := make([]gitlab.PipelineVariable, len(input.Variables))
+	for i, v := range input.Variables {
+		vars[i] = gitlab.PipelineVariable{
+			Key:   v.Key,
+			Value: v.Value,
+		}
+	}
+
+	j, err := client.PlayJob(input.ProjectID, input.JobID, vars)
+	if err != nil {
+		return nil, PlayJobOutput{}, err
+	}
+
+	return nil, PlayJobOutput{
+		ID:     int64(j.ID),
+		Name:   j.Name,
+		Status: j.Status,
+		WebURL: j.WebURL,
+	}, nil
+}
+
+func cancelJobHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CancelJobInput) (*mcp.CallToolResult, CancelJobOutput, error) {
+	j, err := client.CancelJob(input.ProjectID, input.JobID)
+	if err != nil {
+		return nil, CancelJobOutput{}, err
+	}
+
+	return nil, CancelJobOutput{
+		ID:     int64(j.ID),
+		Name:   j.Name,
+		Status: j.Status,
+		WebURL: j.WebURL,
+	}, nil
+}
+
+// eraseJobSummary は確認のためにログとアーティファクトを削除するジョブを要約する
+func eraseJobSummary(client *gitlab.Client, input EraseJobInput) (string, error) {
+	j, err := client.GetJob(input.ProjectID, input.JobID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("erase the log and artifacts of job #%d '%s' on %s (%s)", j.ID, j.Name, j.Ref, j.Status), nil
+}
+
+func eraseJobHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input EraseJobInput) (*mcp.CallToolResult, EraseJobOutput, error) {
+	j, err := client.EraseJob(input.ProjectID, input.JobID)
+	if err != nil {
+		return nil, EraseJobOutput{}, err
+	}
+
+	erasedAt := ""
+	if j.ErasedAt != nil {
+		erasedAt = j.ErasedAt.String()
+	}
+
+	return nil, EraseJobOutput{
+		ID:       int64(j.ID),
+		Name:     j.Name,
+		Status:   j.Status,
+		ErasedAt: erasedAt,
+	}, nil
+}
@@ -340,6 +340,24 @@ func TestCancelPipelineTool(t *testing.T) {
 	})
 }
 
+func TestCancelPipelineSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/pipelines/200", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 200, "ref": "main", "status": "running"})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := cancelPipelineSummary(client, CancelPipelineInput{ProjectID: "test-project", PipelineID: 200})
+
+	require.NoError(t, err)
+	assert.Equal(t, "cancel pipeline #200 on main (running)", summary)
+}
+
 func TestGetPipelineJobTool(t *testing.T) {
 	t.Run("returns job detail successfully", func(t *testing.T) {
 		handler := func(w http.ResponseWriter, r *http.Request) {
@@ -452,6 +470,9 @@ func TestNewToolRegistration(t *testing.T) {
 		"get_pipeline_job",
 		"get_job_log",
 		"retry_pipeline_job",
+		"play_job",
+		"cancel_job",
+		"erase_job",
 	}
 
 	for _, tool := range newTools {
@@ -482,3 +503,163 @@ func TestToolDisabled(t *testing.T) {
 		assert.False(t, reg.IsToolEnabled("list_merge_request_pipelines"))
 	})
 }
+
+func TestGetPipelineJobsTool_ManualJobs(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 1, "name": "build", "stage": "build", "status": "success", "allow_failure": false},
+			{"id": 2, "name": "deploy", "stage": "deploy", "status": "manual", "allow_failure": true},
+			{"id": 3, "name": "cleanup", "stage": "deploy", "status": "scheduled"},
+		})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	input := GetJobsInput{
+		ProjectID:  "test-project",
+		PipelineID: 100,
+	}
+
+	ctx := context.Background()
+	_, output, err := getJobsHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	require.Len(t, output.Jobs, 3)
+	assert.False(t, output.Jobs[0].Manual)
+	assert.Empty(t, output.Jobs[0].When)
+	assert.True(t, output.Jobs[1].Manual)
+	assert.True(t, output.Jobs[1].AllowFailure)
+	assert.Equal(t, "manual", output.Jobs[1].When)
+	assert.False(t, output.Jobs[2].Manual)
+	assert.Equal(t, "delayed", output.Jobs[2].When)
+}
+
+func TestPlayJobTool(t *testing.T) {
+	t.Run("plays manual job with variables", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/jobs/20/play", r.URL.Path)
+			assert.Equal(t, "POST", r.Method)
+
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Len(t, body["job_variables_attributes"], 1)
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":      20,
+				"name":    "deploy",
+				"status":  "pending",
+				"web_url": "https://gitlab.example.com/project/-/jobs/20",
+			})
+		}
+
+		client, reg, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("play_job"))
+
+		input := PlayJobInput{
+			ProjectID: "test-project",
+			JobID:     20,
+			Variables: []PipelineVariableInput{
+				{Key: "DEPLOY_TARGET", Value: "production"},
+			},
+		}
+
+		ctx := context.Background()
+		_, output, err := playJobHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), output.ID)
+		assert.Equal(t, "pending", output.Status)
+	})
+
+	t.Run("returns error for non-playable job", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"message": "400 Unplayable Job"})
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := PlayJobInput{ProjectID: "test-project", JobID: 20}
+
+		ctx := context.Background()
+		_, _, err := playJobHandler(client, ctx, nil, input)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCancelJobTool(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/jobs/10/cancel", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":     10,
+			"name":   "build",
+			"status": "canceled",
+		})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	input := CancelJobInput{ProjectID: "test-project", JobID: 10}
+
+	ctx := context.Background()
+	_, output, err := cancelJobHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "canceled", output.Status)
+}
+
+func TestEraseJobTool(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/jobs/10/erase", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":        10,
+			"name":      "build",
+			"status":    "success",
+			"erased_at": "2024-01-01T10:00:00Z",
+		})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	input := EraseJobInput{ProjectID: "test-project", JobID: 10}
+
+	ctx := context.Background()
+	_, output, err := eraseJobHandler(client, ctx, nil, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), output.ID)
+	assert.NotEmpty(t, output.ErasedAt)
+}
+
+func TestEraseJobSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/jobs/300", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": 300, "name": "build", "ref": "main", "status": "failed"})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	summary, err := eraseJobSummary(client, EraseJobInput{ProjectID: "test-project", JobID: 300})
+
+	require.NoError(t, err)
+	assert.Equal(t, "erase the log and artifacts of job #300 'build' on main (failed)", summary)
+}
@@ -0,0 +1,250 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+const (
+	// defaultWaitTimeout は wait_for_pipeline のデフォルトのタイムアウト
+	defaultWaitTimeout = 10 * time.Minute
+	// maxWaitTimeout は wait_for_pipeline のタイムアウトの上限
+	maxWaitTimeout = time.Hour
+)
+
+// ポーリング間隔（テストで短縮できるよう変数にしている）
+var (
+	initialPollInterval = 5 * time.Second
+	maxPollInterval     = 60 * time.Second
+)
+
+// WaitForPipelineInput は wait_for_pipeline の入力パラメータ
+type WaitForPipelineInput struct {
+	ProjectID      string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	PipelineID     int    `json:"pipeline_id" jsonschema:"minimum:1,description:Pipeline ID"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" jsonschema:"minimum:1,maximum:3600,description:Maximum time to wait in seconds (default: 600, max: 3600)"`
+}
+
+// StageStatus はステージごとの集計ステータス
+type StageStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// WaitForPipelineOutput は wait_for_pipeline の出力
+type WaitForPipelineOutput struct {
+	ID             int64         `json:"id"`
+	Status         string        `json:"status"`
+	WebURL         string        `json:"web_url"`
+	TimedOut       bool          `json:"timed_out"`
+	ElapsedSeconds int64         `json:"elapsed_seconds"`
+	Stages         []StageStatus `json:"stages"`
+	FailedJobs     []JobInfo     `json:"failed_jobs,omitempty"`
+}
+
+// registerWaitTools はパイプライン待機ツールを登録する
+func registerWaitTools(reg *registry.Registry) {
+	registry.RegisterTool(reg, "wait_for_pipeline",
+		"GitLab パイプラインが完了するまで待機します（進捗通知あり、タイムアウト指定可能）",
+		func(ctx context.Context, req *mcp.CallToolRequest, input WaitForPipelineInput) (*mcp.CallToolResult, WaitForPipelineOutput, error) {
+			return waitForPipelineHandler(holder.client, ctx, req, input)
+		}, registry.WithReadOnly())
+}
+
+// isTerminalPipelineStatus はパイプラインがこれ以上自動で進行しないステータスかを返す
+func isTerminalPipelineStatus(status string) bool {
+	switch status {
+	case "success", "failed", "canceled", "skipped", "manual":
+		return true
+	default:
+		return false
+	}
+}
+
+// isFinishedJobStatus はジョブが完了しているステータスかを返す
+func isFinishedJobStatus(status string) bool {
+	switch status {
+	case "success", "failed", "canceled", "skipped", "manual":
+		return true
+	default:
+		return false
+	}
+}
+
+// summarizeStages はジョブ一覧からステージごとのステータスを集計する
+// ステージの順序はジョブ ID の昇順で最初に出現した順とする
+func summarizeStages(jobs []*gogitlab.Job) []StageStatus {
+	sorted := make([]*gogitlab.Job, len(jobs))
+	copy(sorted, jobs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var order []string
+	byStage := make(map[string][]*gogitlab.Job)
+	for _, j := range sorted {
+		if _, ok := byStage[j.Stage]; !ok {
+			order = append(order, j.Stage)
+		}
+		byStage[j.Stage] = append(byStage[j.Stage], j)
+	}
+
+	stages := make([]StageStatus, len(order))
+	for i, name := range order {
+		stages[i] = StageStatus{Name: name, Status: stageStatus(byStage[name])}
+	}
+	return stages
+}
+
+// stageStatus はステージ内のジョブのステータスを1つに集約する
+// GitLab と同様に、成功したジョブがない（すべてスキップされた、またはジョブがない）ステージは skipped とする
+func stageStatus(jobs []*gogitlab.Job) string {
+	var running, failed, pending, canceled, manual, success bool
+	for _, j := range jobs {
+		switch j.Status {
+		case "success":
+			success = true
+		case "running":
+			running = true
+		case "failed":
+			// 失敗を許容するジョブの失敗は警告付きの成功として扱う
+			if j.AllowFailure {
+				success = true
+			} else {
+				failed = true
+			}
+		case "created", "waiting_for_resource", "preparing", "pending", "scheduled":
+			pending = true
+		case "canceled":
+			canceled = true
+		case "manual":
+			manual = true
+		}
+	}
+
+	switch {
+	case running:
+		return "running"
+	case failed:
+		return "failed"
+	case pending:
+		return "pending"
+	case canceled:
+		return "canceled"
+	case manual:
+		return "manual"
+	case success:
+		return "success"
+	default:
+		return "skipped"
+	}
+}
+
+// formatStages は進捗通知用にステージのステータスを文字列化する
+func formatStages(stages []StageStatus) string {
+	parts := make([]string, len(stages))
+	for i, s := range stages {
+		parts[i] = fmt.Sprintf("%s: %s", s.Name, s.Status)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// notifyProgress はクライアントが進捗トークンを指定している場合に進捗を通知する
+func notifyProgress(ctx context.Context, req *mcp.CallToolRequest, progress, total float64, message string) {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return
+	}
+	// 通知の失敗は待機処理自体には影響させない
+	_ = req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: token,
+		Progress:      progress,
+		Total:         total,
+		Message:       message,
+	})
+}
+
+func waitForPipelineHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input WaitForPipelineInput) (*mcp.CallToolResult, WaitForPipelineOutput, error) {
+	timeout := defaultWaitTimeout
+	if input.TimeoutSeconds > 0 {
+		timeout = min(time.Duration(input.TimeoutSeconds)*time.Second, maxWaitTimeout)
+	}
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	interval := initialPollInterval
+	lastFinished := -1
+
+	for {
+		p, err := client.GetPipeline(input.ProjectID, input.PipelineID)
+		if err != nil {
+			return nil, WaitForPipelineOutput{}, err
+		}
+
+		jobs, err := client.ListAllPipelineJobs(input.ProjectID, input.PipelineID)
+		if err != nil {
+			return nil, WaitForPipelineOutput{}, err
+		}
+
+		stages := summarizeStages(jobs)
+
+		finished := 0
+		for _, j := range jobs {
+			if isFinishedJobStatus(j.Status) {
+				finished++
+			}
+		}
+		if finished != lastFinished {
+			lastFinished = finished
+			notifyProgress(ctx, req, float64(finished), float64(len(jobs)),
+				fmt.Sprintf("pipeline %d: %s (%s)", p.ID, p.Status, formatStages(stages)))
+		}
+
+		terminal := isTerminalPipelineStatus(p.Status)
+		timedOut := !terminal && !time.Now().Before(deadline)
+
+		if terminal || timedOut {
+			var failedJobs []JobInfo
+			for _, j := range jobs {
+				if j.Status == "failed" {
+					failedJobs = append(failedJobs, JobInfo{
+						ID:           j.ID,
+						Name:         j.Name,
+						Stage:        j.Stage,
+						Status:       j.Status,
+						AllowFailure: j.AllowFailure,
+					})
+				}
+			}
+
+			return nil, WaitForPipelineOutput{
+				ID:             p.ID,
+				Status:         p.Status,
+				WebURL:         p.WebURL,
+				TimedOut:       timedOut,
+				ElapsedSeconds: int64(time.Since(start).Seconds()),
+				Stages:         stages,
+				FailedJobs:     failedJobs,
+			}, nil
+		}
+
+		timer := time.NewTimer(min(interval, time.Until(deadline)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, WaitForPipelineOutput{}, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = min(interval*2, maxPollInterval)
+	}
+}
@@ -0,0 +1,221 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// useFastPolling はテスト中のポーリング間隔を短縮する
+func useFastPolling(t *testing.T) {
+	origInitial, origMax := initialPollInterval, maxPollInterval
+	initialPollInterval = time.Millisecond
+	maxPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() {
+		initialPollInterval, maxPollInterval = origInitial, origMax
+	})
+}
+
+// progressingPipelineHandler は polls 回目の取得で成功するパイプラインを返すハンドラを作成する
+func progressingPipelineHandler(t *testing.T, polls int32) http.HandlerFunc {
+	var count atomic.Int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/pipelines/100":
+			n := count.Add(1)
+			status := "running"
+			if n >= polls {
+				status = "success"
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":      100,
+				"status":  status,
+				"web_url": "https://gitlab.example.com/project/-/pipelines/100",
+			})
+		case "/api/v4/projects/test-project/pipelines/100/jobs":
+			n := count.Load()
+			testStatus := "running"
+			if n >= polls {
+				testStatus = "success"
+			}
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 2, "name": "test", "stage": "test", "status": testStatus},
+				{"id": 1, "name": "build", "stage": "build", "status": "success"},
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestWaitForPipelineTool(t *testing.T) {
+	useFastPolling(t)
+
+	t.Run("waits until pipeline finishes", func(t *testing.T) {
+		client, reg, cleanup := setupTestServer(t, progressingPipelineHandler(t, 3))
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("wait_for_pipeline"))
+
+		input := WaitForPipelineInput{ProjectID: "test-project", PipelineID: 100}
+
+		ctx := context.Background()
+		_, output, err := waitForPipelineHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "success", output.Status)
+		assert.False(t, output.TimedOut)
+		assert.Equal(t, []StageStatus{
+			{Name: "build", Status: "success"},
+			{Name: "test", Status: "success"},
+		}, output.Stages)
+	})
+
+	t.Run("returns current state on timeout", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, progressingPipelineHandler(t, 1000))
+		defer cleanup()
+
+		input := WaitForPipelineInput{ProjectID: "test-project", PipelineID: 100, TimeoutSeconds: 1}
+
+		ctx := context.Background()
+		_, output, err := waitForPipelineHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.TimedOut)
+		assert.Equal(t, "running", output.Status)
+		assert.Equal(t, []StageStatus{
+			{Name: "build", Status: "success"},
+			{Name: "test", Status: "running"},
+		}, output.Stages)
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		client, _, cleanup := setupTestServer(t, progressingPipelineHandler(t, 1000))
+		defer cleanup()
+
+		input := WaitForPipelineInput{ProjectID: "test-project", PipelineID: 100}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, _, err := waitForPipelineHandler(client, ctx, nil, input)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("reports failed jobs", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/api/v4/projects/test-project/pipelines/100" {
+				json.NewEncoder(w).Encode(map[string]any{"id": 100, "status": "failed"})
+				return
+			}
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 3, "name": "lint", "stage": "test", "status": "failed", "allow_failure": true},
+				{"id": 2, "name": "unit", "stage": "test", "status": "failed"},
+				{"id": 1, "name": "build", "stage": "build", "status": "success"},
+			})
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		input := WaitForPipelineInput{ProjectID: "test-project", PipelineID: 100}
+
+		ctx := context.Background()
+		_, output, err := waitForPipelineHandler(client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "failed", output.Status)
+		assert.Len(t, output.FailedJobs, 2)
+		assert.Equal(t, "failed", output.Stages[1].Status)
+	})
+}
+
+func TestWaitForPipelineTool_ProgressNotifications(t *testing.T) {
+	useFastPolling(t)
+
+	_, reg, cleanup := setupTestServer(t, progressingPipelineHandler(t, 3))
+	defer cleanup()
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverCtx, serverCancel := context.WithCancel(context.Background())
+	defer serverCancel()
+	go reg.Server().Run(serverCtx, serverTransport)
+
+	var mu sync.Mutex
+	var messages []string
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(ctx context.Context, req *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, req.Params.Message)
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session, err := mcpClient.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	params := &mcp.CallToolParams{
+		Meta: mcp.Meta{"progressToken": "wait-token"},
+		Name: "wait_for_pipeline",
+		Arguments: map[string]any{
+			"project_id":  "test-project",
+			"pipeline_id": 100,
+		},
+	}
+
+	result, err := session.CallTool(ctx, params)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(messages) >= 2
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Contains(t, messages[0], "build: success, test: running")
+	assert.Contains(t, messages[len(messages)-1], "build: success, test: success")
+}
+
+func TestStageStatus(t *testing.T) {
+	job := func(status string, allowFailure bool) *gogitlab.Job {
+		return &gogitlab.Job{Status: status, AllowFailure: allowFailure}
+	}
+
+	tests := []struct {
+		name string
+		jobs []*gogitlab.Job
+		want string
+	}{
+		{name: "all succeeded", jobs: []*gogitlab.Job{job("success", false), job("skipped", false)}, want: "success"},
+		{name: "allowed failure", jobs: []*gogitlab.Job{job("failed", true)}, want: "success"},
+		{name: "failed", jobs: []*gogitlab.Job{job("success", false), job("failed", false)}, want: "failed"},
+		{name: "all skipped", jobs: []*gogitlab.Job{job("skipped", false), job("skipped", false)}, want: "skipped"},
+		{name: "no jobs", want: "skipped"},
+		{name: "manual", jobs: []*gogitlab.Job{job("success", false), job("manual", false)}, want: "manual"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, stageStatus(tt.jobs))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// releaseNotesInstructions は release_notes のモデルへの指示
+const releaseNotesInstructions = `Write release notes in Markdown from the commits below. Group the changes under these headings, omitting empty ones:
+- Breaking changes (with migration steps)
+- Features
+- Bug fixes
+- Performance
+- Documentation
+- Internal (refactoring, CI, dependencies; keep this section short)
+
+Write one line per user-visible change in plain language, merging commits that belong to the same change, and reference the merge request (e.g. !123) when one is listed. Leave out merge commits, reverts that were re-applied, and fixups of changes made within this release.`
+
+// mergeRequestRefPattern はマージコミットのメッセージに含まれる MR の参照
+var mergeRequestRefPattern = regexp.MustCompile(`See merge request (\S*![0-9]+)`)
+
+// registerPrompts はリポジトリのプロンプトを登録する
+func registerPrompts(reg *registry.Registry) {
+	registry.RegisterPrompt(reg, &mcp.Prompt{
+		Name:        "release_notes",
+		Title:       "Release Notes",
+		Description: "2 つの ref（タグ、ブランチ、コミット）の間のコミットからリリースノートを作成する",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "project_id", Description: "Project ID or URL-encoded path", Required: true},
+			{Name: "from", Description: "Previous release tag, branch or commit SHA", Required: true},
+			{Name: "to", Description: "Tag, branch or commit SHA of the new release", Required: true},
+			{Name: "version", Description: "Optional version name used as the release notes heading"},
+		},
+	}, func(ctx context.Context, req *mcp.GetPromptRequest, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+		return releaseNotesPrompt(holder.client, ctx, args)
+	})
+}
+
+func releaseNotesPrompt(client *gitlab.Client, ctx context.Context, args registry.PromptArgs) (*mcp.GetPromptResult, error) {
+	from, to := args["from"], args["to"]
+
+	compare, err := client.CompareRefs(args["project_id"], from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(releaseNotesInstructions)
+	if version := args["version"]; version != "" {
+		fmt.Fprintf(&b, "\n\nUse \"%s\" as the heading of the release notes.", version)
+	}
+
+	fmt.Fprintf(&b, "\n\n# Commits from %s to %s (%d)\n\n", from, to, len(compare.Commits))
+	for _, c := range compare.Commits {
+		fmt.Fprintf(&b, "- %s %s (%s)", c.ShortID, c.Title, c.AuthorName)
+		if m := mergeRequestRefPattern.FindStringSubmatch(c.Message); m != nil {
+			fmt.Fprintf(&b, " [%s]", m[1])
+		}
+		b.WriteString("\n")
+	}
+	if len(compare.Commits) == 0 {
+		b.WriteString("No commits.\n")
+	}
+
+	return registry.UserPrompt(fmt.Sprintf("Release notes for %s..%s", from, to), b.String()), nil
+}
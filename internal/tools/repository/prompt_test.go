@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseNotesPrompt(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/repository/compare", r.URL.Path)
+		assert.Equal(t, "v1.0.0", r.URL.Query().Get("from"))
+		assert.Equal(t, "main", r.URL.Query().Get("to"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"commits": []map[string]any{
+				{"short_id": "aaa1111", "title": "Add CSV export", "author_name": "Alice", "message": "Add CSV export\n"},
+				{"short_id": "bbb2222", "title": "Merge branch 'csv' into 'main'", "author_name": "Bob",
+					"message": "Merge branch 'csv' into 'main'\n\nAdd CSV export\n\nSee merge request group/project!12"},
+			},
+		})
+	}
+
+	client, _, cleanup := setupTestServer(t, handler)
+	defer cleanup()
+
+	res, err := releaseNotesPrompt(client, context.Background(), registry.PromptArgs{
+		"project_id": "test-project",
+		"from":       "v1.0.0",
+		"to":         "main",
+		"version":    "v1.1.0",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+	content, ok := res.Messages[0].Content.(*mcp.TextContent)
+	require.True(t, ok)
+
+	assert.Contains(t, content.Text, `Use "v1.1.0" as the heading`)
+	assert.Contains(t, content.Text, "# Commits from v1.0.0 to main (2)")
+	assert.Contains(t, content.Text, "- aaa1111 Add CSV export (Alice)\n")
+	assert.Contains(t, content.Text, "- bbb2222 Merge branch 'csv' into 'main' (Bob) [group/project!12]\n")
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"mime"
+	"path"
+	"unicode/utf8"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FileURITemplate はリポジトリのファイルのリソースの URI テンプレート
+// project はプロジェクト ID または URL エンコードしたパス、ref は URL エンコードしたブランチ・タグ名またはコミット SHA
+const FileURITemplate = "gitlab://{project}/files/{ref}/{+path}"
+
+// clientHolder holds the GitLab client for handlers
+type clientHolder struct {
+	client *gitlab.Client
+}
+
+var holder *clientHolder
+
+// Register はリポジトリ関連のリソースとプロンプトを登録する
+func Register(reg *registry.Registry, client *gitlab.Client) {
+	holder = &clientHolder{client: client}
+
+	registry.RegisterResourceTemplate(reg, &mcp.ResourceTemplate{
+		Name:        "file",
+		Title:       "GitLab Repository File",
+		Description: "GitLab リポジトリの指定した ref のファイルの内容",
+		URITemplate: FileURITemplate,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest, params registry.ResourceParams) (*mcp.ReadResourceResult, error) {
+		return readFileResource(holder.client, ctx, req, params)
+	}, registry.WithSubscription(func(ctx context.Context, params registry.ResourceParams) (string, error) {
+		return holder.client.GetFileBlobID(params["project"], params["path"], params["ref"])
+	}))
+
+	registerPrompts(reg)
+}
+
+func readFileResource(client *gitlab.Client, ctx context.Context, req *mcp.ReadResourceRequest, params registry.ResourceParams) (*mcp.ReadResourceResult, error) {
+	data, err := client.GetRawFile(params["project"], params["path"], params["ref"])
+	if err != nil {
+		return nil, err
+	}
+
+	contents := &mcp.ResourceContents{URI: req.Params.URI, MIMEType: fileMIMEType(params["path"], data)}
+	if utf8.Valid(data) {
+		contents.Text = string(data)
+	} else {
+		contents.Blob = data
+	}
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{contents}}, nil
+}
+
+// fileMIMEType はファイルの拡張子と内容から MIME タイプを決定する
+func fileMIMEType(filePath string, data []byte) string {
+	if t := mime.TypeByExtension(path.Ext(filePath)); t != "" {
+		return t
+	}
+	if utf8.Valid(data) {
+		return "text/plain"
+	}
+	return "application/octet-stream"
+}
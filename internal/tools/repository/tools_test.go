@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestServer(t *testing.T, handler http.HandlerFunc) (*gitlab.Client, *registry.Registry, func()) {
+	server := httptest.NewServer(handler)
+
+	cfg := &config.Config{
+		GitLabURL:   server.URL,
+		GitLabToken: "test-token",
+	}
+
+	client, err := gitlab.NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	reg := registry.New(cfg)
+	Register(reg, client)
+
+	return client, reg, server.Close
+}
+
+func readRequest(uri string) *mcp.ReadResourceRequest {
+	return &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: uri}}
+}
+
+func TestReadFileResource(t *testing.T) {
+	t.Run("returns text file contents", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/group/project/repository/files/docs/README.md/raw", r.URL.Path)
+			assert.Equal(t, "main", r.URL.Query().Get("ref"))
+			w.Write([]byte("# Title\n"))
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		uri := "gitlab://group%2Fproject/files/main/docs/README.md"
+		res, err := readFileResource(client, context.Background(), readRequest(uri), registry.ResourceParams{
+			"project": "group/project",
+			"ref":     "main",
+			"path":    "docs/README.md",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.Contents, 1)
+		assert.Equal(t, uri, res.Contents[0].URI)
+		assert.Equal(t, "# Title\n", res.Contents[0].Text)
+		assert.Nil(t, res.Contents[0].Blob)
+	})
+
+	t.Run("returns binary file contents as blob", func(t *testing.T) {
+		data := []byte{0x89, 'P', 'N', 'G', 0xff, 0xfe}
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Write(data)
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		res, err := readFileResource(client, context.Background(), readRequest("gitlab://1/files/main/logo.png"), registry.ResourceParams{
+			"project": "1",
+			"ref":     "main",
+			"path":    "logo.png",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.Contents, 1)
+		assert.Equal(t, "image/png", res.Contents[0].MIMEType)
+		assert.Equal(t, data, res.Contents[0].Blob)
+		assert.Empty(t, res.Contents[0].Text)
+	})
+
+	t.Run("returns not found error", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"404 File Not Found"}`))
+		}
+
+		client, _, cleanup := setupTestServer(t, handler)
+		defer cleanup()
+
+		_, err := readFileResource(client, context.Background(), readRequest("gitlab://1/files/main/missing.txt"), registry.ResourceParams{
+			"project": "1",
+			"ref":     "main",
+			"path":    "missing.txt",
+		})
+
+		assert.ErrorIs(t, err, gitlab.ErrNotFound)
+	})
+}
+
+func TestFileMIMEType(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		data     []byte
+		expected string
+	}{
+		{name: "by extension", path: "a/b.json", data: []byte("{}"), expected: "application/json"},
+		{name: "text without extension", path: "Makefile", data: []byte("all:\n"), expected: "text/plain"},
+		{name: "binary without extension", path: "bin/tool", data: []byte{0xff, 0xfe, 0x00}, expected: "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fileMIMEType(tt.path, tt.data))
+		})
+	}
+}
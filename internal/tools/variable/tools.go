@@ -0,0 +1,378 @@
+package variable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// VariableInfo は CI/CD 変数情報
+// masked/protected/hidden な変数の値は設定で許可されない限り Value を空にし ValueRedacted を true にする
+type VariableInfo struct {
+	Key              string `json:"key"`
+	Value            string `json:"value,omitempty"`
+	ValueRedacted    bool   `json:"value_redacted,omitempty"`
+	VariableType     string `json:"variable_type"`
+	EnvironmentScope string `json:"environment_scope"`
+	Description      string `json:"description,omitempty"`
+	Protected        bool   `json:"protected"`
+	Masked           bool   `json:"masked"`
+	Hidden           bool   `json:"hidden"`
+	Raw              bool   `json:"raw"`
+}
+
+// ListProjectVariablesInput は list_project_variables の入力パラメータ
+type ListProjectVariablesInput struct {
+	ProjectID string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Page      int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage   int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
+}
+
+// ListGroupVariablesInput は list_group_variables の入力パラメータ
+type ListGroupVariablesInput struct {
+	GroupID string `json:"group_id" jsonschema:"description:Group ID or URL-encoded path"`
+	Page    int    `json:"page,omitempty" jsonschema:"minimum:1,description:Page number (default: 1)"`
+	PerPage int    `json:"per_page,omitempty" jsonschema:"minimum:1,maximum:100,description:Number of items per page (default: 100, max: 100)"`
+}
+
+// ListVariablesOutput は list_project_variables / list_group_variables の出力
+type ListVariablesOutput struct {
+	Variables []VariableInfo `json:"variables"`
+}
+
+// GetProjectVariableInput は get_project_variable の入力パラメータ
+type GetProjectVariableInput struct {
+	ProjectID        string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Key              string `json:"key" jsonschema:"description:Variable key"`
+	EnvironmentScope string `json:"environment_scope,omitempty" jsonschema:"description:Environment scope to select when the key exists in several scopes"`
+}
+
+// GetGroupVariableInput は get_group_variable の入力パラメータ
+type GetGroupVariableInput struct {
+	GroupID          string `json:"group_id" jsonschema:"description:Group ID or URL-encoded path"`
+	Key              string `json:"key" jsonschema:"description:Variable key"`
+	EnvironmentScope string `json:"environment_scope,omitempty" jsonschema:"description:Environment scope to select when the key exists in several scopes"`
+}
+
+// VariableOptionsInput は変数の作成・更新で共通の属性
+type VariableOptionsInput struct {
+	Value        *string `json:"value,omitempty" jsonschema:"description:Variable value"`
+	Description  *string `json:"description,omitempty" jsonschema:"description:Variable description"`
+	VariableType *string `json:"variable_type,omitempty" jsonschema:"enum:env_var,enum:file,description:Variable type"`
+	Protected    *bool   `json:"protected,omitempty" jsonschema:"description:Only expose the variable to protected branches and tags"`
+	Masked       *bool   `json:"masked,omitempty" jsonschema:"description:Mask the variable value in job logs"`
+	Raw          *bool   `json:"raw,omitempty" jsonschema:"description:Do not expand variable references in the value"`
+}
+
+// CreateProjectVariableInput は create_project_variable の入力パラメータ
+type CreateProjectVariableInput struct {
+	ProjectID        string  `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Key              string  `json:"key" jsonschema:"description:Variable key"`
+	EnvironmentScope *string `json:"environment_scope,omitempty" jsonschema:"description:Environment scope (default: *)"`
+	VariableOptionsInput
+	registry.DryRunOption
+}
+
+// CreateGroupVariableInput は create_group_variable の入力パラメータ
+type CreateGroupVariableInput struct {
+	GroupID          string  `json:"group_id" jsonschema:"description:Group ID or URL-encoded path"`
+	Key              string  `json:"key" jsonschema:"description:Variable key"`
+	EnvironmentScope *string `json:"environment_scope,omitempty" jsonschema:"description:Environment scope (default: *)"`
+	VariableOptionsInput
+	registry.DryRunOption
+}
+
+// UpdateProjectVariableInput は update_project_variable の入力パラメータ
+type UpdateProjectVariableInput struct {
+	ProjectID        string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Key              string `json:"key" jsonschema:"description:Variable key"`
+	EnvironmentScope string `json:"environment_scope,omitempty" jsonschema:"description:Environment scope of the variable to update"`
+	VariableOptionsInput
+	registry.DryRunOption
+}
+
+// UpdateGroupVariableInput は update_group_variable の入力パラメータ
+type UpdateGroupVariableInput struct {
+	GroupID          string `json:"group_id" jsonschema:"description:Group ID or URL-encoded path"`
+	Key              string `json:"key" jsonschema:"description:Variable key"`
+	EnvironmentScope string `json:"environment_scope,omitempty" jsonschema:"description:Environment scope of the variable to update"`
+	VariableOptionsInput
+	registry.DryRunOption
+}
+
+// DeleteProjectVariableInput は delete_project_variable の入力パラメータ
+type DeleteProjectVariableInput struct {
+	ProjectID        string `json:"project_id" jsonschema:"description:Project ID or URL-encoded path"`
+	Key              string `json:"key" jsonschema:"description:Variable key to delete"`
+	EnvironmentScope string `json:"environment_scope,omitempty" jsonschema:"description:Environment scope of the variable to delete"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// DeleteGroupVariableInput は delete_group_variable の入力パラメータ
+type DeleteGroupVariableInput struct {
+	GroupID          string `json:"group_id" jsonschema:"description:Group ID or URL-encoded path"`
+	Key              string `json:"key" jsonschema:"description:Variable key to delete"`
+	EnvironmentScope string `json:"environment_scope,omitempty" jsonschema:"description:Environment scope of the variable to delete"`
+	registry.Confirmation
+	registry.DryRunOption
+}
+
+// DeleteVariableOutput は delete_project_variable / delete_group_variable の出力
+type DeleteVariableOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// clientHolder holds the GitLab client for handlers
+type clientHolder struct {
+	client *gitlab.Client
+	// exposeSecrets が true の場合は masked/protected な変数の値も返す
+	exposeSecrets bool
+}
+
+var holder *clientHolder
+
+// Register は CI/CD 変数関連ツールを登録する
+func Register(reg *registry.Registry, client *gitlab.Client) {
+	holder = &clientHolder{
+		client:        client,
+		exposeSecrets: reg.Config().ExposeSecretVariables,
+	}
+
+	registry.RegisterTool(reg, "list_project_variables",
+		"GitLab プロジェクトの CI/CD 変数一覧を取得します（masked/protected な値は伏せられます）",
+		func(ctx context.Context, req *mcp.CallToolRequest, input ListProjectVariablesInput) (*mcp.CallToolResult, ListVariablesOutput, error) {
+			return listProjectVariablesHandler(holder, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "get_project_variable",
+		"GitLab プロジェクトの CI/CD 変数を取得します（masked/protected な値は伏せられます）",
+		func(ctx context.Context, req *mcp.CallToolRequest, input GetProjectVariableInput) (*mcp.CallToolResult, VariableInfo, error) {
+			return getProjectVariableHandler(holder, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "create_project_variable",
+		"GitLab プロジェクトに CI/CD 変数を作成します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input CreateProjectVariableInput) (*mcp.CallToolResult, VariableInfo, error) {
+			return createProjectVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input, holder.exposeSecrets)
+		})
+
+	registry.RegisterTool(reg, "update_project_variable",
+		"GitLab プロジェクトの CI/CD 変数を更新します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input UpdateProjectVariableInput) (*mcp.CallToolResult, VariableInfo, error) {
+			return updateProjectVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input, holder.exposeSecrets)
+		})
+
+	registry.RegisterTool(reg, "delete_project_variable",
+		"GitLab プロジェクトの CI/CD 変数を削除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input DeleteProjectVariableInput) (*mcp.CallToolResult, DeleteVariableOutput, error) {
+			return deleteProjectVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeleteProjectVariableInput) (string, error) {
+			return deleteProjectVariableSummary(holder.client, input)
+		}))
+
+	registry.RegisterTool(reg, "list_group_variables",
+		"GitLab グループの CI/CD 変数一覧を取得します（masked/protected な値は伏せられます）",
+		func(ctx context.Context, req *mcp.CallToolRequest, input ListGroupVariablesInput) (*mcp.CallToolResult, ListVariablesOutput, error) {
+			return listGroupVariablesHandler(holder, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "get_group_variable",
+		"GitLab グループの CI/CD 変数を取得します（masked/protected な値は伏せられます）",
+		func(ctx context.Context, req *mcp.CallToolRequest, input GetGroupVariableInput) (*mcp.CallToolResult, VariableInfo, error) {
+			return getGroupVariableHandler(holder, ctx, req, input)
+		}, registry.WithReadOnly())
+
+	registry.RegisterTool(reg, "create_group_variable",
+		"GitLab グループに CI/CD 変数を作成します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input CreateGroupVariableInput) (*mcp.CallToolResult, VariableInfo, error) {
+			return createGroupVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input, holder.exposeSecrets)
+		})
+
+	registry.RegisterTool(reg, "update_group_variable",
+		"GitLab グループの CI/CD 変数を更新します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input UpdateGroupVariableInput) (*mcp.CallToolResult, VariableInfo, error) {
+			return updateGroupVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input, holder.exposeSecrets)
+		})
+
+	registry.RegisterTool(reg, "delete_group_variable",
+		"GitLab グループの CI/CD 変数を削除します",
+		func(ctx context.Context, req *mcp.CallToolRequest, input DeleteGroupVariableInput) (*mcp.CallToolResult, DeleteVariableOutput, error) {
+			return deleteGroupVariableHandler(holder.client.WithDryRun(ctx), ctx, req, input)
+		}, registry.WithDestructive(), registry.WithConfirmation(func(ctx context.Context, input DeleteGroupVariableInput) (string, error) {
+			return deleteGroupVariableSummary(holder.client, input)
+		}))
+}
+
+// isSecret は値を伏せるべき変数かを返す
+func isSecret(protected, masked, hidden bool) bool {
+	return protected || masked || hidden
+}
+
+// newVariableInfo は変数情報を作成し、必要に応じて値を伏せる
+func newVariableInfo(exposeSecrets bool, key, value string, variableType gogitlab.VariableTypeValue, environmentScope, description string, protected, masked, hidden, raw bool) VariableInfo {
+	info := VariableInfo{
+		Key:              key,
+		Value:            value,
+		VariableType:     string(variableType),
+		EnvironmentScope: environmentScope,
+		Description:      description,
+		Protected:        protected,
+		Masked:           masked,
+		Hidden:           hidden,
+		Raw:              raw,
+	}
+	if isSecret(protected, masked, hidden) && !exposeSecrets {
+		info.Value = ""
+		info.ValueRedacted = true
+	}
+	return info
+}
+
+func toProjectVariableInfo(exposeSecrets bool, v *gogitlab.ProjectVariable) VariableInfo {
+	return newVariableInfo(exposeSecrets, v.Key, v.Value, v.VariableType, v.EnvironmentScope, v.Description, v.Protected, v.Masked, v.Hidden, v.Raw)
+}
+
+func toGroupVariableInfo(exposeSecrets bool, v *gogitlab.GroupVariable) VariableInfo {
+	return newVariableInfo(exposeSecrets, v.Key, v.Value, v.VariableType, v.EnvironmentScope, v.Description, v.Protected, v.Masked, v.Hidden, v.Raw)
+}
+
+// toCIVariableOptions は入力パラメータを GitLab クライアントのオプションに変換する
+func toCIVariableOptions(input VariableOptionsInput, environmentScope *string) *gitlab.CIVariableOptions {
+	return &gitlab.CIVariableOptions{
+		Value:            input.Value,
+		Description:      input.Description,
+		EnvironmentScope: environmentScope,
+		VariableType:     input.VariableType,
+		Protected:        input.Protected,
+		Masked:           input.Masked,
+		Raw:              input.Raw,
+	}
+}
+
+func listProjectVariablesHandler(h *clientHolder, ctx context.Context, req *mcp.CallToolRequest, input ListProjectVariablesInput) (*mcp.CallToolResult, ListVariablesOutput, error) {
+	variables, err := h.client.ListProjectVariables(input.ProjectID, &gitlab.PaginationOptions{
+		Page:    input.Page,
+		PerPage: input.PerPage,
+	})
+	if err != nil {
+		return nil, ListVariablesOutput{}, err
+	}
+
+	output := ListVariablesOutput{Variables: make([]VariableInfo, len(variables))}
+	for i, v := range variables {
+		output.Variables[i] = toProjectVariableInfo(h.exposeSecrets, v)
+	}
+	return nil, output, nil
+}
+
+func getProjectVariableHandler(h *clientHolder, ctx context.Context, req *mcp.CallToolRequest, input GetProjectVariableInput) (*mcp.CallToolResult, VariableInfo, error) {
+	v, err := h.client.GetProjectVariable(input.ProjectID, input.Key, input.EnvironmentScope)
+	if err != nil {
+		return nil, VariableInfo{}, err
+	}
+	return nil, toProjectVariableInfo(h.exposeSecrets, v), nil
+}
+
+func createProjectVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CreateProjectVariableInput, exposeSecrets bool) (*mcp.CallToolResult, VariableInfo, error) {
+	v, err := client.CreateProjectVariable(input.ProjectID, input.Key, toCIVariableOptions(input.VariableOptionsInput, input.EnvironmentScope))
+	if err != nil {
+		return nil, VariableInfo{}, err
+	}
+	return nil, toProjectVariableInfo(exposeSecrets, v), nil
+}
+
+func updateProjectVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input UpdateProjectVariableInput, exposeSecrets bool) (*mcp.CallToolResult, VariableInfo, error) {
+	v, err := client.UpdateProjectVariable(input.ProjectID, input.Key, input.EnvironmentScope, toCIVariableOptions(input.VariableOptionsInput, nil))
+	if err != nil {
+		return nil, VariableInfo{}, err
+	}
+	return nil, toProjectVariableInfo(exposeSecrets, v), nil
+}
+
+// deleteProjectVariableSummary は確認のために削除する変数を要約する（値は示さない）
+func deleteProjectVariableSummary(client *gitlab.Client, input DeleteProjectVariableInput) (string, error) {
+	v, err := client.GetProjectVariable(input.ProjectID, input.Key, input.EnvironmentScope)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("delete CI/CD variable %s (environment scope %s) from project %s", v.Key, v.EnvironmentScope, input.ProjectID), nil
+}
+
+func deleteProjectVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeleteProjectVariableInput) (*mcp.CallToolResult, DeleteVariableOutput, error) {
+	err := client.DeleteProjectVariable(input.ProjectID, input.Key, input.EnvironmentScope)
+	if err != nil {
+		return nil, DeleteVariableOutput{}, err
+	}
+
+	return nil, DeleteVariableOutput{
+		Success: true,
+		Message: "Project variable deleted successfully",
+	}, nil
+}
+
+func listGroupVariablesHandler(h *clientHolder, ctx context.Context, req *mcp.CallToolRequest, input ListGroupVariablesInput) (*mcp.CallToolResult, ListVariablesOutput, error) {
+	variables, err := h.client.ListGroupVariables(input.GroupID, &gitlab.PaginationOptions{
+		Page:    input.Page,
+		PerPage: input.PerPage,
+	})
+	if err != nil {
+		return nil, ListVariablesOutput{}, err
+	}
+
+	output := ListVariablesOutput{Variables: make([]VariableInfo, len(variables))}
+	for i, v := range variables {
+		output.Variables[i] = toGroupVariableInfo(h.exposeSecrets, v)
+	}
+	return nil, output, nil
+}
+
+func getGroupVariableHandler(h *clientHolder, ctx context.Context, req *mcp.CallToolRequest, input GetGroupVariableInput) (*mcp.CallToolResult, VariableInfo, error) {
+	v, err := h.client.GetGroupVariable(input.GroupID, input.Key, input.EnvironmentScope)
+	if err != nil {
+		return nil, VariableInfo{}, err
+	}
+	return nil, toGroupVariableInfo(h.exposeSecrets, v), nil
+}
+
+func createGroupVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input CreateGroupVariableInput, exposeSecrets bool) (*mcp.CallToolResult, VariableInfo, error) {
+	v, err := client.CreateGroupVariable(input.GroupID, input.Key, toCIVariableOptions(input.VariableOptionsInput, input.EnvironmentScope))
+	if err != nil {
+		return nil, VariableInfo{}, err
+	}
+	return nil, toGroupVariableInfo(exposeSecrets, v), nil
+}
+
+func updateGroupVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input UpdateGroupVariableInput, exposeSecrets bool) (*mcp.CallToolResult, VariableInfo, error) {
+	v, err := client.UpdateGroupVariable(input.GroupID, input.Key, input.EnvironmentScope, toCIVariableOptions(input.VariableOptionsInput, nil))
+	if err != nil {
+		return nil, VariableInfo{}, err
+	}
+	return nil, toGroupVariableInfo(exposeSecrets, v), nil
+}
+
+// deleteGroupVariableSummary は確認のために削除する変数を要約する（値は示さない）
+func deleteGroupVariableSummary(client *gitlab.Client, input DeleteGroupVariableInput) (string, error) {
+	v, err := client.GetGroupVariable(input.GroupID, input.Key, input.EnvironmentScope)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("delete CI/CD variable %s (environment scope %s) from group %s", v.Key, v.EnvironmentScope, input.GroupID), nil
+}
+
+func deleteGroupVariableHandler(client *gitlab.Client, ctx context.Context, req *mcp.CallToolRequest, input DeleteGroupVariableInput) (*mcp.CallToolResult, DeleteVariableOutput, error) {
+	err := client.DeleteGroupVariable(input.GroupID, input.Key, input.EnvironmentScope)
+	if err != nil {
+		return nil, DeleteVariableOutput{}, err
+	}
+
+	return nil, DeleteVariableOutput{
+		Success: true,
+		Message: "Group variable deleted successfully",
+	}, nil
+}
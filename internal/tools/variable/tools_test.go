@@ -0,0 +1,354 @@
+package variable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestServer(t *testing.T, handler http.HandlerFunc, exposeSecrets bool) (*clientHolder, *registry.Registry, func()) {
+	server := httptest.NewServer(handler)
+
+	cfg := &config.Config{
+		GitLabURL:             server.URL,
+		GitLabToken:           "test-token",
+		ExposeSecretVariables: exposeSecrets,
+	}
+
+	client, err := gitlab.NewClient(server.URL, "test-token")
+	require.NoError(t, err)
+
+	reg := registry.New(cfg)
+	Register(reg, client)
+
+	return holder, reg, server.Close
+}
+
+func variablesHandler(t *testing.T, path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, path, r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"key": "PLAIN", "value": "visible", "variable_type": "env_var", "environment_scope": "*"},
+			{"key": "TOKEN", "value": "s3cr3t-value", "variable_type": "env_var", "environment_scope": "*", "masked": true},
+			{"key": "DEPLOY_KEY", "value": "deploy", "variable_type": "file", "environment_scope": "production", "protected": true},
+		})
+	}
+}
+
+func TestListProjectVariablesTool(t *testing.T) {
+	t.Run("redacts masked and protected values by default", func(t *testing.T) {
+		h, reg, cleanup := setupTestServer(t, variablesHandler(t, "/api/v4/projects/test-project/variables"), false)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("list_project_variables"))
+
+		input := ListProjectVariablesInput{ProjectID: "test-project"}
+
+		ctx := context.Background()
+		_, output, err := listProjectVariablesHandler(h, ctx, nil, input)
+
+		require.NoError(t, err)
+		require.Len(t, output.Variables, 3)
+		assert.Equal(t, "visible", output.Variables[0].Value)
+		assert.False(t, output.Variables[0].ValueRedacted)
+		assert.Empty(t, output.Variables[1].Value)
+		assert.True(t, output.Variables[1].ValueRedacted)
+		assert.Empty(t, output.Variables[2].Value)
+		assert.True(t, output.Variables[2].ValueRedacted)
+		assert.Equal(t, "production", output.Variables[2].EnvironmentScope)
+		assert.Equal(t, "file", output.Variables[2].VariableType)
+	})
+
+	t.Run("returns secret values when exposure is enabled", func(t *testing.T) {
+		h, _, cleanup := setupTestServer(t, variablesHandler(t, "/api/v4/projects/test-project/variables"), true)
+		defer cleanup()
+
+		input := ListProjectVariablesInput{ProjectID: "test-project"}
+
+		ctx := context.Background()
+		_, output, err := listProjectVariablesHandler(h, ctx, nil, input)
+
+		require.NoError(t, err)
+		require.Len(t, output.Variables, 3)
+		assert.Equal(t, "s3cr3t-value", output.Variables[1].Value)
+		assert.False(t, output.Variables[1].ValueRedacted)
+	})
+}
+
+func TestGetProjectVariableTool(t *testing.T) {
+	t.Run("filters by environment scope", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/variables/DEPLOY_KEY", r.URL.Path)
+			assert.Equal(t, "production", r.URL.Query().Get("filter[environment_scope]"))
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"key": "DEPLOY_KEY", "value": "deploy", "environment_scope": "production", "protected": true,
+			})
+		}
+
+		h, reg, cleanup := setupTestServer(t, handler, false)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("get_project_variable"))
+
+		input := GetProjectVariableInput{ProjectID: "test-project", Key: "DEPLOY_KEY", EnvironmentScope: "production"}
+
+		ctx := context.Background()
+		_, output, err := getProjectVariableHandler(h, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "DEPLOY_KEY", output.Key)
+		assert.True(t, output.ValueRedacted)
+		assert.Empty(t, output.Value)
+	})
+}
+
+func TestCreateProjectVariableTool(t *testing.T) {
+	t.Run("creates variable and redacts masked value in response", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/variables", r.URL.Path)
+			assert.Equal(t, "POST", r.Method)
+
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "API_TOKEN", body["key"])
+			assert.Equal(t, "abcdefgh12345678", body["value"])
+			assert.Equal(t, "staging", body["environment_scope"])
+			assert.Equal(t, true, body["masked"])
+			assert.Equal(t, true, body["raw"])
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"key": "API_TOKEN", "value": "abcdefgh12345678", "environment_scope": "staging", "masked": true, "raw": true,
+			})
+		}
+
+		h, reg, cleanup := setupTestServer(t, handler, false)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("create_project_variable"))
+
+		value := "abcdefgh12345678"
+		scope := "staging"
+		masked := true
+		raw := true
+		input := CreateProjectVariableInput{
+			ProjectID:        "test-project",
+			Key:              "API_TOKEN",
+			EnvironmentScope: &scope,
+			VariableOptionsInput: VariableOptionsInput{
+				Value:  &value,
+				Masked: &masked,
+				Raw:    &raw,
+			},
+		}
+
+		ctx := context.Background()
+		_, output, err := createProjectVariableHandler(h.client, ctx, nil, input, h.exposeSecrets)
+
+		require.NoError(t, err)
+		assert.Equal(t, "API_TOKEN", output.Key)
+		assert.True(t, output.Masked)
+		assert.True(t, output.Raw)
+		assert.True(t, output.ValueRedacted)
+		assert.Empty(t, output.Value)
+	})
+}
+
+func TestUpdateProjectVariableTool(t *testing.T) {
+	t.Run("updates variable in the given scope", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/variables/PLAIN", r.URL.Path)
+			assert.Equal(t, "PUT", r.Method)
+
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "new-value", body["value"])
+			assert.Equal(t, map[string]any{"environment_scope": "review/*"}, body["filter"])
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"key": "PLAIN", "value": "new-value", "environment_scope": "review/*",
+			})
+		}
+
+		h, reg, cleanup := setupTestServer(t, handler, false)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("update_project_variable"))
+
+		value := "new-value"
+		input := UpdateProjectVariableInput{
+			ProjectID:            "test-project",
+			Key:                  "PLAIN",
+			EnvironmentScope:     "review/*",
+			VariableOptionsInput: VariableOptionsInput{Value: &value},
+		}
+
+		ctx := context.Background()
+		_, output, err := updateProjectVariableHandler(h.client, ctx, nil, input, h.exposeSecrets)
+
+		require.NoError(t, err)
+		assert.Equal(t, "new-value", output.Value)
+	})
+}
+
+func TestDeleteProjectVariableTool(t *testing.T) {
+	t.Run("deletes variable successfully", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/test-project/variables/PLAIN", r.URL.Path)
+			assert.Equal(t, "DELETE", r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+		h, reg, cleanup := setupTestServer(t, handler, false)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("delete_project_variable"))
+
+		input := DeleteProjectVariableInput{ProjectID: "test-project", Key: "PLAIN"}
+
+		ctx := context.Background()
+		_, output, err := deleteProjectVariableHandler(h.client, ctx, nil, input)
+
+		require.NoError(t, err)
+		assert.True(t, output.Success)
+	})
+
+	t.Run("returns error when variable not found", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "404 Variable Not Found"})
+		}
+
+		h, _, cleanup := setupTestServer(t, handler, false)
+		defer cleanup()
+
+		input := DeleteProjectVariableInput{ProjectID: "test-project", Key: "MISSING"}
+
+		ctx := context.Background()
+		_, _, err := deleteProjectVariableHandler(h.client, ctx, nil, input)
+
+		require.Error(t, err)
+	})
+}
+
+func TestListGroupVariablesTool(t *testing.T) {
+	t.Run("redacts masked and protected values by default", func(t *testing.T) {
+		h, reg, cleanup := setupTestServer(t, variablesHandler(t, "/api/v4/groups/test-group/variables"), false)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("list_group_variables"))
+
+		input := ListGroupVariablesInput{GroupID: "test-group"}
+
+		ctx := context.Background()
+		_, output, err := listGroupVariablesHandler(h, ctx, nil, input)
+
+		require.NoError(t, err)
+		require.Len(t, output.Variables, 3)
+		assert.Equal(t, "visible", output.Variables[0].Value)
+		assert.True(t, output.Variables[1].ValueRedacted)
+		assert.True(t, output.Variables[2].ValueRedacted)
+	})
+}
+
+func TestGroupVariableWriteTools(t *testing.T) {
+	t.Run("creates, updates and deletes group variables", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case "POST":
+				assert.Equal(t, "/api/v4/groups/test-group/variables", r.URL.Path)
+				json.NewEncoder(w).Encode(map[string]any{"key": "SHARED", "value": "v1", "environment_scope": "*"})
+			case "PUT":
+				assert.Equal(t, "/api/v4/groups/test-group/variables/SHARED", r.URL.Path)
+				json.NewEncoder(w).Encode(map[string]any{"key": "SHARED", "value": "v2", "environment_scope": "*", "protected": true})
+			case "DELETE":
+				assert.Equal(t, "/api/v4/groups/test-group/variables/SHARED", r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+		}
+
+		h, reg, cleanup := setupTestServer(t, handler, false)
+		defer cleanup()
+
+		assert.True(t, reg.IsRegistered("create_group_variable"))
+		assert.True(t, reg.IsRegistered("update_group_variable"))
+		assert.True(t, reg.IsRegistered("delete_group_variable"))
+		assert.True(t, reg.IsRegistered("get_group_variable"))
+
+		ctx := context.Background()
+		v1, v2, protected := "v1", "v2", true
+
+		_, created, err := createGroupVariableHandler(h.client, ctx, nil, CreateGroupVariableInput{
+			GroupID:              "test-group",
+			Key:                  "SHARED",
+			VariableOptionsInput: VariableOptionsInput{Value: &v1},
+		}, h.exposeSecrets)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", created.Value)
+
+		_, updated, err := updateGroupVariableHandler(h.client, ctx, nil, UpdateGroupVariableInput{
+			GroupID:              "test-group",
+			Key:                  "SHARED",
+			VariableOptionsInput: VariableOptionsInput{Value: &v2, Protected: &protected},
+		}, h.exposeSecrets)
+		require.NoError(t, err)
+		assert.True(t, updated.ValueRedacted)
+
+		_, deleted, err := deleteGroupVariableHandler(h.client, ctx, nil, DeleteGroupVariableInput{GroupID: "test-group", Key: "SHARED"})
+		require.NoError(t, err)
+		assert.True(t, deleted.Success)
+	})
+}
+
+func TestDeleteProjectVariableSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/test-project/variables/API_KEY", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"key": "API_KEY", "value": "secret", "environment_scope": "production"})
+	}
+
+	h, _, cleanup := setupTestServer(t, handler, false)
+	defer cleanup()
+
+	summary, err := deleteProjectVariableSummary(h.client, DeleteProjectVariableInput{ProjectID: "test-project", Key: "API_KEY", EnvironmentScope: "production"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "delete CI/CD variable API_KEY (environment scope production) from project test-project", summary)
+}
+
+func TestDeleteGroupVariableSummary(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/groups/test-group/variables/API_KEY", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"key": "API_KEY", "value": "secret", "environment_scope": "*"})
+	}
+
+	h, _, cleanup := setupTestServer(t, handler, false)
+	defer cleanup()
+
+	summary, err := deleteGroupVariableSummary(h.client, DeleteGroupVariableInput{GroupID: "test-group", Key: "API_KEY"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "delete CI/CD variable API_KEY (environment scope *) from group test-group", summary)
+}
@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_Complete(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects":
+			json.NewEncoder(w).Encode([]map[string]any{{"id": 1, "path_with_namespace": "group/test-project"}})
+		case "/api/v4/projects/group/test-project/repository/branches":
+			json.NewEncoder(w).Encode([]map[string]any{{"name": "main"}, {"name": "maintenance"}})
+		case "/api/v4/projects/group/test-project/repository/tags":
+			json.NewEncoder(w).Encode([]map[string]any{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.NotNil(t, session.InitializeResult().Capabilities.Completions)
+
+	t.Run("project of a prompt", func(t *testing.T) {
+		res, err := session.Complete(ctx, &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "write_mr_description"},
+			Argument: mcp.CompleteParamsArgument{Name: "project_id", Value: "test"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"group/test-project"}, res.Completion.Values)
+	})
+
+	t.Run("branch scoped by the chosen project", func(t *testing.T) {
+		res, err := session.Complete(ctx, &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "write_mr_description"},
+			Argument: mcp.CompleteParamsArgument{Name: "target_branch", Value: "ma"},
+			Context:  &mcp.CompleteContext{Arguments: map[string]string{"project_id": "group/test-project"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "maintenance"}, res.Completion.Values)
+	})
+
+	t.Run("ref of a resource template", func(t *testing.T) {
+		res, err := session.Complete(ctx, &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/resource", URI: "gitlab://{project}/files/{ref}/{+path}"},
+			Argument: mcp.CompleteParamsArgument{Name: "ref", Value: "ma"},
+			Context:  &mcp.CompleteContext{Arguments: map[string]string{"project": "group%2Ftest-project"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "maintenance"}, res.Completion.Values)
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		res, err := session.Complete(ctx, &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "write_mr_description"},
+			Argument: mcp.CompleteParamsArgument{Name: "source_branch", Value: ""},
+			Context:  &mcp.CompleteContext{Arguments: map[string]string{"project_id": "missing"}},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res.Completion.Values)
+	})
+}
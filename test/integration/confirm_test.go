@@ -0,0 +1,139 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/kqns91/gitlab-mcp/internal/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mergeHandler は MR の取得とマージに応答し、マージが呼ばれたかを merged に記録する
+func mergeHandler(merged *bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/test-project/merge_requests/42":
+			json.NewEncoder(w).Encode(map[string]any{"id": 100, "iid": 42, "title": "Add billing", "target_branch": "main", "state": "opened"})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v4/projects/test-project/merge_requests/42/merge":
+			*merged = true
+			json.NewEncoder(w).Encode(map[string]any{"id": 100, "iid": 42, "title": "Add billing", "state": "merged"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestIntegration_Confirmation_Elicitation(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	merged := false
+	var message string
+	session, cleanup := setupIntegrationTestWithClient(t, cfg, mergeHandler(&merged), &mcp.ClientOptions{
+		ElicitationHandler: func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			message = req.Params.Message
+			return &mcp.ElicitResult{Action: "accept"}, nil
+		},
+	})
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "merge_merge_request",
+		Arguments: map[string]any{
+			"project_id":                  "test-project",
+			"merge_request_iid":           42,
+			"squash":                      true,
+			"should_remove_source_branch": true,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.True(t, merged)
+	assert.Contains(t, message, "merge !42 'Add billing' into main, squash, delete branch")
+}
+
+func TestIntegration_Confirmation_Fallback(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	merged := false
+	session, cleanup := setupIntegrationTest(t, cfg, mergeHandler(&merged))
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	args := map[string]any{"project_id": "test-project", "merge_request_iid": 42}
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "merge_merge_request", Arguments: args})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.False(t, merged)
+	assert.Equal(t, "confirmation_required", toolErrorDetail(t, result)["code"])
+
+	args["confirm"] = true
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "merge_merge_request", Arguments: args})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.True(t, merged)
+}
+
+func TestIntegration_Confirmation_DestructiveTools(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, func(w http.ResponseWriter, r *http.Request) {})
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tools, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+
+	// Every destructive tool, and close_merge_request, can ask for confirmation
+	var names []string
+	for _, tool := range tools.Tools {
+		destructive := tool.Annotations != nil && tool.Annotations.DestructiveHint != nil && *tool.Annotations.DestructiveHint
+		if !destructive && tool.Name != "close_merge_request" {
+			continue
+		}
+		names = append(names, tool.Name)
+
+		schema, err := json.Marshal(tool.InputSchema)
+		require.NoError(t, err)
+		var input struct {
+			Properties map[string]any `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(schema, &input))
+		assert.Contains(t, input.Properties, "confirm", "%s: confirm input", tool.Name)
+	}
+	require.Contains(t, names, "delete_issue_note")
+
+	client, err := gitlab.NewClient(cfg.GitLabURL, cfg.GitLabToken)
+	require.NoError(t, err)
+	reg := registry.New(&config.Config{ConfirmTools: append(names, "list_issues")})
+	registerAllTools(reg, client)
+
+	// Only tools that cannot ask are reported
+	assert.Equal(t, []string{"list_issues"}, reg.UnconfirmableTools())
+}
@@ -0,0 +1,244 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/gitlab"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dryRunRequests はドライランの結果の構造化された内容から送信するはずだった書き込みのリクエストを取り出す
+func dryRunRequests(t *testing.T, result *mcp.CallToolResult) []gitlab.DryRunRequest {
+	t.Helper()
+
+	require.False(t, result.IsError, "a dry run is a successful result")
+	require.Equal(t, true, result.Meta["dry_run"])
+	data, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	var output struct {
+		DryRun   bool                   `json:"dry_run"`
+		Message  string                 `json:"message"`
+		Requests []gitlab.DryRunRequest `json:"dry_run_requests"`
+	}
+	require.NoError(t, json.Unmarshal(data, &output))
+	require.True(t, output.DryRun)
+	require.NotEmpty(t, output.Message)
+	require.Len(t, result.Content, 1)
+	assert.JSONEq(t, string(data), result.Content[0].(*mcp.TextContent).Text)
+	return output.Requests
+}
+
+func TestIntegration_DryRun_Input(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	merged := false
+	elicited := false
+	session, cleanup := setupIntegrationTestWithClient(t, cfg, mergeHandler(&merged), &mcp.ClientOptions{
+		ElicitationHandler: func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			elicited = true
+			return &mcp.ElicitResult{Action: "decline"}, nil
+		},
+	})
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "merge_merge_request",
+		Arguments: map[string]any{
+			"project_id":        "test-project",
+			"merge_request_iid": 42,
+			"squash":            true,
+			"dry_run":           true,
+		},
+	})
+
+	require.NoError(t, err)
+	requests := dryRunRequests(t, result)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "PUT", requests[0].Method)
+	assert.Contains(t, requests[0].URL, "/api/v4/projects/test-project/merge_requests/42/merge")
+	assert.JSONEq(t, `{"squash": true}`, string(requests[0].Body))
+	assertMatchesOutputSchema(t, ctx, session, "merge_merge_request", result)
+	assert.False(t, merged)
+	assert.False(t, elicited, "a dry run does not ask for confirmation")
+}
+
+func TestIntegration_DryRun_Config(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+		DryRun:      true,
+	}
+
+	merged := false
+	session, cleanup := setupIntegrationTest(t, cfg, mergeHandler(&merged))
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "merge_merge_request",
+		Arguments: map[string]any{"project_id": "test-project", "merge_request_iid": 42},
+	})
+
+	require.NoError(t, err)
+	requests := dryRunRequests(t, result)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "PUT", requests[0].Method)
+	assert.False(t, merged)
+
+	// 存在しない MR は not_found になる
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "merge_merge_request",
+		Arguments: map[string]any{"project_id": "test-project", "merge_request_iid": 7},
+	})
+
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "not_found")
+	assert.False(t, merged)
+}
+
+func TestIntegration_DryRun_MutatingTools(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, nil)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tools, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+
+	// 読み取りのツール以外はすべて dry_run を受け付ける
+	for _, tool := range tools.Tools {
+		schema := tool.InputSchema.(map[string]any)
+		properties, _ := schema["properties"].(map[string]any)
+		_, hasDryRun := properties["dry_run"]
+		readOnly := tool.Annotations != nil && tool.Annotations.ReadOnlyHint
+		assert.Equal(t, !readOnly, hasDryRun, "%s: dry_run must be accepted exactly by the tools that write", tool.Name)
+	}
+}
+
+func TestIntegration_DryRun_CollectsEveryWrite(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	var writes []string
+	rebaseStatusReads, recentNoteReads := 0, 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method != http.MethodGet:
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/api/v4/projects/test-project":
+			json.NewEncoder(w).Encode(map[string]any{"id": 1, "path_with_namespace": "test-project"})
+		case r.URL.Path == "/api/v4/projects/test-project/merge_requests/42":
+			if r.URL.Query().Get("include_rebase_in_progress") != "" {
+				rebaseStatusReads++
+			}
+			json.NewEncoder(w).Encode(map[string]any{"id": 100, "iid": 42, "state": "opened"})
+		case r.URL.Path == "/api/v4/projects/test-project/merge_requests/42/draft_notes":
+			json.NewEncoder(w).Encode([]map[string]any{{"id": 1, "note": "Rename this"}})
+		case r.URL.Path == "/api/v4/projects/test-project/merge_requests/42/notes":
+			recentNoteReads++
+			json.NewEncoder(w).Encode([]map[string]any{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("publish_review", func(t *testing.T) {
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name: "publish_review",
+			Arguments: map[string]any{
+				"project_id":        "test-project",
+				"merge_request_iid": 42,
+				"summary":           "LGTM with nits",
+				"approve":           true,
+				"dry_run":           true,
+			},
+		})
+
+		require.NoError(t, err)
+		requests := dryRunRequests(t, result)
+		require.Len(t, requests, 3)
+		assert.Equal(t, "POST", requests[0].Method)
+		assert.True(t, strings.HasSuffix(requests[0].URL, "/merge_requests/42/draft_notes"))
+		assert.JSONEq(t, `{"note": "LGTM with nits"}`, string(requests[0].Body))
+		assert.True(t, strings.HasSuffix(requests[1].URL, "/merge_requests/42/draft_notes/bulk_publish"))
+		assert.True(t, strings.HasSuffix(requests[2].URL, "/merge_requests/42/approve"))
+		assert.Zero(t, recentNoteReads, "a dry run does not look for a summary that was never published")
+		assertMatchesOutputSchema(t, ctx, session, "publish_review", result)
+	})
+
+	t.Run("create_pipeline_schedule", func(t *testing.T) {
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name: "create_pipeline_schedule",
+			Arguments: map[string]any{
+				"project_id":  "test-project",
+				"description": "Nightly",
+				"ref":         "main",
+				"cron":        "0 2 * * *",
+				"variables": []map[string]any{
+					{"key": "DEPLOY_ENV", "value": "staging"},
+					{"key": "REGION", "value": "eu"},
+				},
+				"dry_run": true,
+			},
+		})
+
+		require.NoError(t, err)
+		requests := dryRunRequests(t, result)
+		require.Len(t, requests, 3)
+		assert.True(t, strings.HasSuffix(requests[0].URL, "/pipeline_schedules"))
+		assert.True(t, strings.HasSuffix(requests[1].URL, "/pipeline_schedules/0/variables"))
+		assert.JSONEq(t, `{"key": "DEPLOY_ENV", "value": "staging"}`, string(requests[1].Body))
+		assert.JSONEq(t, `{"key": "REGION", "value": "eu"}`, string(requests[2].Body))
+		assertMatchesOutputSchema(t, ctx, session, "create_pipeline_schedule", result)
+	})
+
+	t.Run("rebase_merge_request", func(t *testing.T) {
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      "rebase_merge_request",
+			Arguments: map[string]any{"project_id": "test-project", "merge_request_iid": 42, "dry_run": true},
+		})
+
+		require.NoError(t, err)
+		requests := dryRunRequests(t, result)
+		require.Len(t, requests, 1)
+		assert.Equal(t, "PUT", requests[0].Method)
+		assert.True(t, strings.HasSuffix(requests[0].URL, "/merge_requests/42/rebase"))
+		assert.Zero(t, rebaseStatusReads, "a dry run does not wait for a rebase that never started")
+		assertMatchesOutputSchema(t, ctx, session, "rebase_merge_request", result)
+	})
+
+	assert.Empty(t, writes, "a dry run sends no write to GitLab")
+}
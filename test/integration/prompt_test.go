@@ -0,0 +1,104 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_ListPrompts(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.ListPrompts(ctx, nil)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(result.Prompts))
+	for _, p := range result.Prompts {
+		names = append(names, p.Name)
+		assert.NotEmpty(t, p.Description, "prompt %s should have a description", p.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"review_merge_request",
+		"triage_failed_pipeline",
+		"write_mr_description",
+		"summarize_issue_thread",
+		"release_notes",
+	}, names)
+}
+
+func TestIntegration_GetPrompt(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/projects/test-project/issues/5":
+			json.NewEncoder(w).Encode(map[string]any{"id": 50, "iid": 5, "title": "Flaky login test", "state": "opened"})
+		case "/api/v4/projects/test-project/issues/5/discussions":
+			json.NewEncoder(w).Encode([]map[string]any{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "404 Not found"})
+		}
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("assembles context", func(t *testing.T) {
+		result, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+			Name:      "summarize_issue_thread",
+			Arguments: map[string]string{"project_id": "test-project", "issue_iid": "5"},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Messages, 1)
+		assert.Equal(t, mcp.Role("user"), result.Messages[0].Role)
+		text, ok := result.Messages[0].Content.(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "# Issue #5: Flaky login test")
+		assert.Contains(t, text.Text, "No comments yet.")
+	})
+
+	t.Run("missing argument", func(t *testing.T) {
+		_, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+			Name:      "summarize_issue_thread",
+			Arguments: map[string]string{"project_id": "test-project"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "issue_iid")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+			Name:      "summarize_issue_thread",
+			Arguments: map[string]string{"project_id": "test-project", "issue_iid": "999"},
+		})
+		require.Error(t, err)
+	})
+}
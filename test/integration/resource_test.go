@@ -0,0 +1,128 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/kqns91/gitlab-mcp/internal/tools/issue"
+	"github.com/kqns91/gitlab-mcp/internal/tools/mergerequest"
+	"github.com/kqns91/gitlab-mcp/internal/tools/pipeline"
+	"github.com/kqns91/gitlab-mcp/internal/tools/repository"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_ListResourceTemplates(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	caps := session.InitializeResult().Capabilities
+	require.NotNil(t, caps.Resources)
+	assert.True(t, caps.Resources.Subscribe)
+
+	result, err := session.ListResourceTemplates(ctx, nil)
+	require.NoError(t, err)
+
+	templates := make([]string, 0, len(result.ResourceTemplates))
+	for _, tmpl := range result.ResourceTemplates {
+		templates = append(templates, tmpl.URITemplate)
+	}
+	assert.ElementsMatch(t, []string{
+		mergerequest.MergeRequestURITemplate,
+		issue.IssueURITemplate,
+		pipeline.PipelineURITemplate,
+		pipeline.JobLogURITemplate,
+		repository.FileURITemplate,
+	}, templates)
+}
+
+func TestIntegration_ReadResource(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v4/projects/group/project/issues/5" && r.Method == "GET":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":     50,
+				"iid":    5,
+				"title":  "Resource issue",
+				"state":  "opened",
+				"labels": []string{"bug"},
+			})
+		case r.URL.Path == "/api/v4/projects/group/project/jobs/7/trace" && r.Method == "GET":
+			w.Write([]byte("Running tests...\nFAIL\n"))
+		case r.URL.Path == "/api/v4/projects/group/project/repository/files/src/main.go/raw" && r.Method == "GET":
+			assert.Equal(t, "release/1.0", r.URL.Query().Get("ref"))
+			w.Write([]byte("package main\n"))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "404 Not found"})
+		}
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("issue", func(t *testing.T) {
+		result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gitlab://group%2Fproject/issues/5"})
+		require.NoError(t, err)
+		require.Len(t, result.Contents, 1)
+		assert.Equal(t, "application/json", result.Contents[0].MIMEType)
+
+		var detail issue.GetIssueOutput
+		require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &detail))
+		assert.Equal(t, "Resource issue", detail.Title)
+		assert.Equal(t, []string{"bug"}, detail.Labels)
+	})
+
+	t.Run("job log", func(t *testing.T) {
+		result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gitlab://group%2Fproject/jobs/7/log"})
+		require.NoError(t, err)
+		require.Len(t, result.Contents, 1)
+		assert.Equal(t, "text/plain", result.Contents[0].MIMEType)
+		assert.Equal(t, "Running tests...\nFAIL\n", result.Contents[0].Text)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gitlab://group%2Fproject/files/release%2F1.0/src/main.go"})
+		require.NoError(t, err)
+		require.Len(t, result.Contents, 1)
+		assert.Equal(t, "package main\n", result.Contents[0].Text)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gitlab://group%2Fproject/issues/999"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("invalid iid", func(t *testing.T) {
+		_, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gitlab://group%2Fproject/issues/abc"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,134 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kqns91/gitlab-mcp/internal/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update はスナップショットを更新するフラグ（go test ./test/integration -run TestIntegration_ToolSchemasSnapshot -update）
+var update = flag.Bool("update", false, "update golden files")
+
+// toolSchemaSnapshot はスナップショットに記録するツールのスキーマ
+type toolSchemaSnapshot struct {
+	Name         string `json:"name"`
+	InputSchema  any    `json:"inputSchema"`
+	OutputSchema any    `json:"outputSchema,omitempty"`
+}
+
+func listAllTools(t *testing.T) map[string]*mcp.Tool {
+	t.Helper()
+
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	t.Cleanup(cleanup)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tools, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+
+	toolsByName := make(map[string]*mcp.Tool, len(tools.Tools))
+	for _, tool := range tools.Tools {
+		toolsByName[tool.Name] = tool
+	}
+	return toolsByName
+}
+
+func TestIntegration_ToolSchemasSnapshot(t *testing.T) {
+	toolsByName := listAllTools(t)
+
+	names := make([]string, 0, len(toolsByName))
+	for name := range toolsByName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	snapshots := make([]toolSchemaSnapshot, 0, len(names))
+	for _, name := range names {
+		tool := toolsByName[name]
+		snapshots = append(snapshots, toolSchemaSnapshot{
+			Name:         name,
+			InputSchema:  tool.InputSchema,
+			OutputSchema: tool.OutputSchema,
+		})
+	}
+
+	got, err := json.MarshalIndent(snapshots, "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "tool_schemas.golden.json")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+	}
+
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err, "run with -update to create the snapshot")
+	assert.Equal(t, string(want), string(got), "published tool schemas changed; run with -update if this is intended")
+}
+
+func TestIntegration_ToolSchemasConstraints(t *testing.T) {
+	toolsByName := listAllTools(t)
+
+	property := func(toolName, prop string) map[string]any {
+		t.Helper()
+		tool, ok := toolsByName[toolName]
+		require.True(t, ok, "tool %s should be registered", toolName)
+
+		data, err := json.Marshal(tool.InputSchema)
+		require.NoError(t, err)
+		var schema struct {
+			Properties map[string]map[string]any `json:"properties"`
+			Required   []string                  `json:"required"`
+		}
+		require.NoError(t, json.Unmarshal(data, &schema))
+		p, ok := schema.Properties[prop]
+		require.True(t, ok, "%s should have property %s", toolName, prop)
+		p["required"] = slices.Contains(schema.Required, prop)
+		return p
+	}
+
+	// enum はタグ全体が description になるのではなく、実際の enum として公開される
+	state := property("list_merge_requests", "state")
+	assert.Equal(t, []any{"opened", "closed", "merged", "all"}, state["enum"])
+	assert.Equal(t, "MR state filter", state["description"])
+
+	// per_page の上限
+	perPage := property("list_merge_requests", "per_page")
+	assert.Equal(t, float64(1), perPage["minimum"])
+	assert.Equal(t, float64(100), perPage["maximum"])
+	assert.Equal(t, false, perPage["required"])
+
+	// 必須パラメータ
+	assert.Equal(t, true, property("get_merge_request", "project_id")["required"])
+	assert.Equal(t, true, property("get_merge_request", "merge_request_iid")["required"])
+
+	// 配列の enum は要素のスキーマに適用される
+	include := property("get_merge_request", "include")
+	items, ok := include["items"].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, items["enum"], "approvals")
+
+	// 出力スキーマも公開される
+	assert.NotNil(t, toolsByName["get_merge_request"].OutputSchema)
+}
@@ -5,16 +5,23 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/kqns91/gitlab-mcp/internal/config"
 	"github.com/kqns91/gitlab-mcp/internal/gitlab"
 	"github.com/kqns91/gitlab-mcp/internal/registry"
 	"github.com/kqns91/gitlab-mcp/internal/tools/approval"
+	"github.com/kqns91/gitlab-mcp/internal/tools/completion"
 	"github.com/kqns91/gitlab-mcp/internal/tools/discussion"
+	"github.com/kqns91/gitlab-mcp/internal/tools/issue"
 	"github.com/kqns91/gitlab-mcp/internal/tools/mergerequest"
 	"github.com/kqns91/gitlab-mcp/internal/tools/pipeline"
+	"github.com/kqns91/gitlab-mcp/internal/tools/repository"
+	"github.com/kqns91/gitlab-mcp/internal/tools/variable"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,6 +29,11 @@ import (
 
 // setupIntegrationTest は統合テスト用のサーバーとクライアントセッションをセットアップする
 func setupIntegrationTest(t *testing.T, cfg *config.Config, gitlabHandler http.HandlerFunc) (*mcp.ClientSession, func()) {
+	return setupIntegrationTestWithClient(t, cfg, gitlabHandler, nil)
+}
+
+// setupIntegrationTestWithClient はクライアントのオプション（エリシテーションのハンドラーなど）を指定して統合テストをセットアップする
+func setupIntegrationTestWithClient(t *testing.T, cfg *config.Config, gitlabHandler http.HandlerFunc, clientOpts *mcp.ClientOptions) (*mcp.ClientSession, func()) {
 	// Create mock GitLab server
 	gitlabServer := httptest.NewServer(gitlabHandler)
 
@@ -34,10 +46,7 @@ func setupIntegrationTest(t *testing.T, cfg *config.Config, gitlabHandler http.H
 
 	// Create registry and register all tools
 	reg := registry.New(cfg)
-	mergerequest.Register(reg, gitlabClient)
-	discussion.Register(reg, gitlabClient)
-	approval.Register(reg, gitlabClient)
-	pipeline.Register(reg, gitlabClient)
+	registerAllTools(reg, gitlabClient)
 
 	// Create in-memory transports for testing
 	serverTransport, clientTransport := mcp.NewInMemoryTransports()
@@ -54,7 +63,7 @@ func setupIntegrationTest(t *testing.T, cfg *config.Config, gitlabHandler http.H
 	mcpClient := mcp.NewClient(&mcp.Implementation{
 		Name:    "test-client",
 		Version: "1.0.0",
-	}, nil)
+	}, clientOpts)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -72,6 +81,59 @@ func setupIntegrationTest(t *testing.T, cfg *config.Config, gitlabHandler http.H
 	return session, cleanup
 }
 
+// registerAllTools は main と同じく全てのツールをレジストリに登録する
+func registerAllTools(reg *registry.Registry, client *gitlab.Client) {
+	mergerequest.Register(reg, client)
+	discussion.Register(reg, client)
+	approval.Register(reg, client)
+	pipeline.Register(reg, client)
+	issue.Register(reg, client)
+	variable.Register(reg, client)
+	repository.Register(reg, client)
+	completion.Register(reg, client)
+}
+
+// toolErrorDetail はエラー結果の TextContent から JSON のエラー詳細を取り出す
+func toolErrorDetail(t *testing.T, result *mcp.CallToolResult) map[string]any {
+	t.Helper()
+
+	require.True(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	text, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	var content struct {
+		Error map[string]any `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &content))
+	require.NotNil(t, content.Error)
+	return content.Error
+}
+
+// assertMatchesOutputSchema は結果の StructuredContent がツール自身の出力スキーマに従うことを確認する
+func assertMatchesOutputSchema(t *testing.T, ctx context.Context, session *mcp.ClientSession, toolName string, result *mcp.CallToolResult) {
+	t.Helper()
+
+	tools, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+	idx := slices.IndexFunc(tools.Tools, func(tool *mcp.Tool) bool { return tool.Name == toolName })
+	require.GreaterOrEqual(t, idx, 0)
+	require.NotNil(t, tools.Tools[idx].OutputSchema)
+
+	if result.StructuredContent == nil {
+		// 出力スキーマを持つツールでも、エラー結果は StructuredContent を省略できる
+		require.True(t, result.IsError, "successful results must have structured content")
+		return
+	}
+
+	data, err := json.Marshal(tools.Tools[idx].OutputSchema)
+	require.NoError(t, err)
+	var schema jsonschema.Schema
+	require.NoError(t, json.Unmarshal(data, &schema))
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(result.StructuredContent))
+}
+
 func TestIntegration_ListTools(t *testing.T) {
 	cfg := &config.Config{
 		GitLabURL:   "https://gitlab.example.com",
@@ -263,6 +325,7 @@ func TestIntegration_CallTool_GetMergeRequest(t *testing.T) {
 	require.True(t, ok)
 	assert.Contains(t, textContent.Text, "Test MR")
 	assert.Contains(t, textContent.Text, "Test description")
+	assertMatchesOutputSchema(t, ctx, session, "get_merge_request", result)
 }
 
 func TestIntegration_CallTool_NotFound(t *testing.T) {
@@ -295,6 +358,87 @@ func TestIntegration_CallTool_NotFound(t *testing.T) {
 	require.NoError(t, err) // No transport error
 	require.NotNil(t, result)
 	assert.True(t, result.IsError, "Result should indicate error")
+
+	// Error details are returned as JSON in the text content
+	detail := toolErrorDetail(t, result)
+	assert.Equal(t, "not_found", detail["code"])
+	assert.Equal(t, float64(http.StatusNotFound), detail["http_status"])
+	assert.Equal(t, false, detail["retryable"])
+
+	// The error result must not violate the tool's own output schema
+	assertMatchesOutputSchema(t, ctx, session, "get_merge_request", result)
+}
+
+func TestIntegration_CallTool_ValidationError(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message": map[string][]string{"target_branch": {"is invalid"}},
+		})
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "create_merge_request",
+		Arguments: map[string]any{
+			"project_id":    "test-project",
+			"source_branch": "feature",
+			"target_branch": "missing",
+			"title":         "Test",
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.True(t, result.IsError)
+
+	detail := toolErrorDetail(t, result)
+	assert.Equal(t, "bad_request", detail["code"])
+	assert.Equal(t, "req-42", detail["request_id"])
+	assert.Equal(t, map[string]any{"target_branch": []any{"is invalid"}}, detail["field_errors"])
+}
+
+func TestIntegration_CallTool_InvalidArguments(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("GitLab should not be called for invalid arguments: %s", r.URL.Path)
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "list_merge_requests",
+		Arguments: map[string]any{
+			"project_id": "test-project",
+			"per_page":   500,
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.True(t, result.IsError)
+
+	detail := toolErrorDetail(t, result)
+	assert.Equal(t, "bad_request", detail["code"])
 }
 
 func TestIntegration_CallTool_Unauthorized(t *testing.T) {
@@ -518,3 +662,50 @@ func TestIntegration_EnabledToolsOnly(t *testing.T) {
 	assert.NotContains(t, toolNames, "create_merge_request")
 	assert.NotContains(t, toolNames, "approve_merge_request")
 }
+
+func TestIntegration_ToolAnnotations(t *testing.T) {
+	cfg := &config.Config{
+		GitLabURL:   "https://gitlab.example.com",
+		GitLabToken: "test-token",
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	session, cleanup := setupIntegrationTest(t, cfg, handler)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tools, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+
+	toolsByName := make(map[string]*mcp.Tool, len(tools.Tools))
+	for _, tool := range tools.Tools {
+		toolsByName[tool.Name] = tool
+	}
+
+	// Destructive pipeline schedule tools are annotated
+	deleteSchedule, ok := toolsByName["delete_pipeline_schedule"]
+	require.True(t, ok)
+	require.NotNil(t, deleteSchedule.Annotations)
+	require.NotNil(t, deleteSchedule.Annotations.DestructiveHint)
+	assert.True(t, *deleteSchedule.Annotations.DestructiveHint)
+
+	// Read-only pipeline schedule tools are annotated
+	listSchedules, ok := toolsByName["list_pipeline_schedules"]
+	require.True(t, ok)
+	require.NotNil(t, listSchedules.Annotations)
+	assert.True(t, listSchedules.Annotations.ReadOnlyHint)
+
+	// Every tool is annotated by what its verb does
+	for _, tool := range tools.Tools {
+		verb, _, _ := strings.Cut(tool.Name, "_")
+		readOnly := tool.Annotations != nil && tool.Annotations.ReadOnlyHint
+		destructive := tool.Annotations != nil && tool.Annotations.DestructiveHint != nil && *tool.Annotations.DestructiveHint
+		assert.Equal(t, slices.Contains([]string{"get", "list", "diff", "wait", "lint"}, verb), readOnly, "%s: read-only hint", tool.Name)
+		assert.Equal(t, verb == "delete" || verb == "erase", destructive, "%s: destructive hint", tool.Name)
+	}
+}